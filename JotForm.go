@@ -7,135 +7,79 @@
 package jotform
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"encoding/xml"
 	"fmt"
-	"io/ioutil"
-	"net/http"
-	"net/url"
+	"log"
 	"os"
 	"strconv"
 	"strings"
+
+	v2 "github.com/jotform/jotform-api-go/v2"
 )
 
-const baseURL = "http://api.jotform.com"
-const apiVersion = "v1"
+// transport is the v2 client that requests are delegated to.
+type transport interface {
+	Call(ctx context.Context, requestPath string, params interface{}, method string) ([]byte, error)
+	CreateFormSubmissionContext(ctx context.Context, formID int64, submission map[string]string) ([]byte, error)
+	EditSubmissionContext(ctx context.Context, sid int64, submission map[string]string) ([]byte, error)
+	SetOutputType(value string)
+	SetDebugMode(value bool)
+}
 
 type jotformAPIClient struct {
 	apiKey     string
 	outputType string
 	debugMode  bool
+	logger     *log.Logger
+	transport  transport
 }
 
+//NewJotFormAPIClient
+//Requests are sent by the v2 client, which retries transient failures.
+//Methods without an E suffix log errors and return nil, rather than exiting.
 func NewJotFormAPIClient(apiKey string, outputType string, debugMode bool) *jotformAPIClient {
-	client := &jotformAPIClient{apiKey, strings.ToLower(outputType), debugMode}
+	client := &jotformAPIClient{
+		apiKey:     apiKey,
+		outputType: strings.ToLower(outputType),
+		debugMode:  debugMode,
+		logger:     log.New(os.Stderr, "jotform: ", log.LstdFlags),
+		transport:  v2.NewJotFormAPIClient(apiKey, outputType, debugMode),
+	}
 
 	return client
 }
 
-func (client jotformAPIClient) GetOutputType() string       { return client.outputType }
-func (client *jotformAPIClient) SetOutputType(value string) { client.outputType = value }
-
-func (client jotformAPIClient) GetDebugMode() bool       { return client.debugMode }
-func (client *jotformAPIClient) SetDebugMode(value bool) { client.debugMode = value }
-
-func (client jotformAPIClient) debug(str interface{}) {
-	if client.debugMode {
-		fmt.Println(str)
-	}
+func (client jotformAPIClient) GetOutputType() string { return client.outputType }
+func (client *jotformAPIClient) SetOutputType(value string) {
+	client.outputType = value
+	client.transport.SetOutputType(value)
 }
 
-func (client jotformAPIClient) executeHttpRequest(requestPath string, params interface{}, method string) []byte {
-
-	if client.outputType != "json" {
-		requestPath = requestPath + ".xml"
-	}
-
-	var path = baseURL + "/" + apiVersion + "/" + requestPath
-	client.debug(path)
-
-	var response *http.Response
-	var request *http.Request
-	var err error
-
-	client.debug(params)
-
-	if method == "GET" {
-		if params != "" {
-			data := params.(map[string]string)
-			values := make(url.Values)
-
-			for k, _ := range data {
-				values.Set(k, data[k])
-			}
-			path = path + "?" + values.Encode()
-		}
-
-		request, err = http.NewRequest("GET", path, nil)
-		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-		request.Header.Add("apiKey", client.apiKey)
-		response, err = http.DefaultClient.Do(request)
-	} else if method == "POST" {
-		data := params.(map[string]string)
-		values := make(url.Values)
+func (client jotformAPIClient) GetDebugMode() bool { return client.debugMode }
+func (client *jotformAPIClient) SetDebugMode(value bool) {
+	client.debugMode = value
+	client.transport.SetDebugMode(value)
+}
 
-		for k, _ := range data {
-			values.Set(k, data[k])
-		}
+//SetLogger
+//Sets the logger errors are logged to by methods without an E suffix.
+//A nil logger discards them.
+func (client *jotformAPIClient) SetLogger(logger *log.Logger) { client.logger = logger }
 
-		request, err = http.NewRequest("POST", path, strings.NewReader(values.Encode()))
-		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-		request.Header.Add("apiKey", client.apiKey)
-		response, err = http.DefaultClient.Do(request)
-	} else if method == "DELETE" {
-		request, err = http.NewRequest("DELETE", path, nil)
-		request.Header.Add("apiKey", client.apiKey)
-		response, err = http.DefaultClient.Do(request)
-	} else if method == "PUT" {
-		parameters := params.([]byte)
-
-		if err != nil {
-			fmt.Printf("%s", err)
-			os.Exit(1)
-		} else {
-			request, err = http.NewRequest("PUT", path, bytes.NewBuffer(parameters))
-			request.Header.Add("apiKey", client.apiKey)
-			response, err = http.DefaultClient.Do(request)
-		}
-	}
+func (client jotformAPIClient) executeHttpRequest(requestPath string, params interface{}, method string) ([]byte, error) {
+	return client.transport.Call(context.Background(), requestPath, params, method)
+}
 
+// logged returns content, logging err if there is one.
+func (client jotformAPIClient) logged(content []byte, err error) []byte {
 	if err != nil {
-		fmt.Printf("%s", err)
-		os.Exit(1)
-	} else {
-		defer response.Body.Close()
-		contents, err := ioutil.ReadAll(response.Body)
-		if err != nil {
-			fmt.Printf("%s", err)
-			os.Exit(1)
-		}
-
-		if client.outputType == "json" {
-			var f interface{}
-			json.Unmarshal(contents, &f)
-			result := f.(map[string]interface{})["content"]
-			content, err := json.Marshal(result)
-
-			if err != nil {
-				fmt.Printf("%s", err)
-				os.Exit(1)
-			} else {
-				return content
-			}
-		} else if client.outputType == "xml" {
-			var f interface{}
-			xml.Unmarshal(contents, &f)
-			return contents
+		if client.logger != nil {
+			client.logger.Print(err)
 		}
+		return nil
 	}
-
-	return nil
+	return content
 }
 
 func createConditions(offset string, limit string, filter map[string]string, orderby string) map[string]string {
@@ -185,6 +129,12 @@ func createHistoryQuery(action string, date string, sortBy string, startDate str
 //Get user account details for a JotForm user.
 //Returns user account type, avatar URL, name, email, website URL and account limits.
 func (client jotformAPIClient) GetUser() []byte {
+	return client.logged(client.GetUserE())
+}
+
+//GetUserE
+//GetUser, returning any error instead of logging it.
+func (client jotformAPIClient) GetUserE() ([]byte, error) {
 	return client.executeHttpRequest("user", "", "GET")
 }
 
@@ -192,6 +142,12 @@ func (client jotformAPIClient) GetUser() []byte {
 //Get number of form submissions received this month
 //Returns number of submissions, number of SSL form submissions, payment form submissions and upload space used by user.
 func (client jotformAPIClient) GetUsage() []byte {
+	return client.logged(client.GetUsageE())
+}
+
+//GetUsageE
+//GetUsage, returning any error instead of logging it.
+func (client jotformAPIClient) GetUsageE() ([]byte, error) {
 	return client.executeHttpRequest("user/usage", "", "GET")
 }
 
@@ -203,6 +159,12 @@ func (client jotformAPIClient) GetUsage() []byte {
 //orderBy (string): Order results by a form field name.
 //Returns basic details such as title of the form, when it was created, number of new and total submissions.
 func (client jotformAPIClient) GetForms(offset string, limit string, filter map[string]string, orderBy string) []byte {
+	return client.logged(client.GetFormsE(offset, limit, filter, orderBy))
+}
+
+//GetFormsE
+//GetForms, returning any error instead of logging it.
+func (client jotformAPIClient) GetFormsE(offset string, limit string, filter map[string]string, orderBy string) ([]byte, error) {
 	var params = createConditions(offset, limit, filter, orderBy)
 
 	return client.executeHttpRequest("user/forms", params, "GET")
@@ -216,6 +178,12 @@ func (client jotformAPIClient) GetForms(offset string, limit string, filter map[
 //orderBy (string): Order results by a form field name.
 //Returns basic details such as title of the form, when it was created, number of new and total submissions.
 func (client jotformAPIClient) GetSubmissions(offset string, limit string, filter map[string]string, orderBy string) []byte {
+	return client.logged(client.GetSubmissionsE(offset, limit, filter, orderBy))
+}
+
+//GetSubmissionsE
+//GetSubmissions, returning any error instead of logging it.
+func (client jotformAPIClient) GetSubmissionsE(offset string, limit string, filter map[string]string, orderBy string) ([]byte, error) {
 	var params = createConditions(offset, limit, filter, orderBy)
 
 	return client.executeHttpRequest("user/submissions", params, "GET")
@@ -225,6 +193,12 @@ func (client jotformAPIClient) GetSubmissions(offset string, limit string, filte
 //Get a list of sub users for this account
 //Returns list of forms and form folders with access privileges.
 func (client jotformAPIClient) GetSubusers() []byte {
+	return client.logged(client.GetSubusersE())
+}
+
+//GetSubusersE
+//GetSubusers, returning any error instead of logging it.
+func (client jotformAPIClient) GetSubusersE() ([]byte, error) {
 	return client.executeHttpRequest("user/subusers", "", "GET")
 }
 
@@ -232,6 +206,12 @@ func (client jotformAPIClient) GetSubusers() []byte {
 //Get a list of form folders for this account
 //Returns name of the folder and owner of the folder for shared folders.
 func (client jotformAPIClient) GetFolders() []byte {
+	return client.logged(client.GetFoldersE())
+}
+
+//GetFoldersE
+//GetFolders, returning any error instead of logging it.
+func (client jotformAPIClient) GetFoldersE() ([]byte, error) {
 	return client.executeHttpRequest("user/folders", "", "GET")
 }
 
@@ -239,6 +219,12 @@ func (client jotformAPIClient) GetFolders() []byte {
 //List of URLS for reports in this account
 //Returns reports for all of the forms. ie. Excel, CSV, printable charts, embeddable HTML tables.
 func (client jotformAPIClient) GetReports() []byte {
+	return client.logged(client.GetReportsE())
+}
+
+//GetReportsE
+//GetReports, returning any error instead of logging it.
+func (client jotformAPIClient) GetReportsE() ([]byte, error) {
 	return client.executeHttpRequest("user/reports", "", "GET")
 }
 
@@ -246,6 +232,12 @@ func (client jotformAPIClient) GetReports() []byte {
 //New user setting values with setting keys
 //Returns changes on user settings
 func (client jotformAPIClient) GetSettings() []byte {
+	return client.logged(client.GetSettingsE())
+}
+
+//GetSettingsE
+//GetSettings, returning any error instead of logging it.
+func (client jotformAPIClient) GetSettingsE() ([]byte, error) {
 	return client.executeHttpRequest("user/settings", "", "GET")
 }
 
@@ -253,6 +245,12 @@ func (client jotformAPIClient) GetSettings() []byte {
 //Get user's settings for this account
 //Returns user's time zone and language.
 func (client jotformAPIClient) UpdateSettings(settings map[string]string) []byte {
+	return client.logged(client.UpdateSettingsE(settings))
+}
+
+//UpdateSettingsE
+//UpdateSettings, returning any error instead of logging it.
+func (client jotformAPIClient) UpdateSettingsE(settings map[string]string) ([]byte, error) {
 	return client.executeHttpRequest("user/settings", settings, "POST")
 }
 
@@ -265,6 +263,12 @@ func (client jotformAPIClient) UpdateSettings(settings map[string]string) []byte
 //endDate (string): Limit results to only before a specific date. Format: MM/DD/YYYY.
 //Returns activity log about things like forms created/modified/deleted, account logins and other operations.
 func (client jotformAPIClient) GetHistory(action string, date string, sortBy string, startDate string, endDate string) []byte {
+	return client.logged(client.GetHistoryE(action, date, sortBy, startDate, endDate))
+}
+
+//GetHistoryE
+//GetHistory, returning any error instead of logging it.
+func (client jotformAPIClient) GetHistoryE(action string, date string, sortBy string, startDate string, endDate string) ([]byte, error) {
 	var params = createHistoryQuery(action, date, sortBy, startDate, endDate)
 
 	return client.executeHttpRequest("user/history", params, "GET")
@@ -274,6 +278,12 @@ func (client jotformAPIClient) GetHistory(action string, date string, sortBy str
 //formID (int64): Form ID is the numbers you see on a form URL. You can get form IDs when you call /user/forms.
 //Returns form ID, status, update and creation dates, submission count etc.
 func (client jotformAPIClient) GetForm(formID int64) []byte {
+	return client.logged(client.GetFormE(formID))
+}
+
+//GetFormE
+//GetForm, returning any error instead of logging it.
+func (client jotformAPIClient) GetFormE(formID int64) ([]byte, error) {
 	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10), "", "GET")
 }
 
@@ -282,6 +292,12 @@ func (client jotformAPIClient) GetForm(formID int64) []byte {
 //formID (int64): Form ID is the numbers you see on a form URL. You can get form IDs when you call /user/forms.
 //Returns question properties of a form.
 func (client jotformAPIClient) GetFormQuestions(formID int64) []byte {
+	return client.logged(client.GetFormQuestionsE(formID))
+}
+
+//GetFormQuestionsE
+//GetFormQuestions, returning any error instead of logging it.
+func (client jotformAPIClient) GetFormQuestionsE(formID int64) ([]byte, error) {
 	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10)+"/questions", "", "GET")
 }
 
@@ -290,6 +306,12 @@ func (client jotformAPIClient) GetFormQuestions(formID int64) []byte {
 //qid (int): Identifier for each question on a form. You can get a list of question IDs from /form/{id}/questions.
 //Returns question properties like required and validation.
 func (client jotformAPIClient) GetFormQuestion(formID int64, qid int) []byte {
+	return client.logged(client.GetFormQuestionE(formID, qid))
+}
+
+//GetFormQuestionE
+//GetFormQuestion, returning any error instead of logging it.
+func (client jotformAPIClient) GetFormQuestionE(formID int64, qid int) ([]byte, error) {
 	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10)+"/question/"+strconv.Itoa(qid), "", "GET")
 }
 
@@ -302,6 +324,12 @@ func (client jotformAPIClient) GetFormQuestion(formID int64, qid int) []byte {
 //orderBy (string): Order results by a form field name.
 //Returns submissions of a specific form.
 func (client jotformAPIClient) GetFormSubmissions(formID int64, offset string, limit string, filter map[string]string, orderBy string) []byte {
+	return client.logged(client.GetFormSubmissionsE(formID, offset, limit, filter, orderBy))
+}
+
+//GetFormSubmissionsE
+//GetFormSubmissions, returning any error instead of logging it.
+func (client jotformAPIClient) GetFormSubmissionsE(formID int64, offset string, limit string, filter map[string]string, orderBy string) ([]byte, error) {
 	var params = createConditions(offset, limit, filter, orderBy)
 
 	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10)+"/submissions", params, "GET")
@@ -313,17 +341,13 @@ func (client jotformAPIClient) GetFormSubmissions(formID int64, offset string, l
 //submission (map[string]string): Submission data with question IDs.
 //Returns posted submission ID and URL.
 func (client jotformAPIClient) CreateFormSubmission(formId int64, submission map[string]string) []byte {
-	data := make(map[string]string)
-
-	for k, _ := range submission {
-		if strings.Contains(k, "_") {
-			data["submission["+k[0:strings.Index(k, "_")]+"]["+k[strings.Index(k, "_")+1:len(k)]+"]"] = submission[k]
-		} else {
-			data["submission["+k+"]"] = submission[k]
-		}
-	}
+	return client.logged(client.CreateFormSubmissionE(formId, submission))
+}
 
-	return client.executeHttpRequest("form/"+strconv.FormatInt(formId, 10)+"/submissions", data, "POST")
+//CreateFormSubmissionE
+//CreateFormSubmission, returning any error instead of logging it.
+func (client jotformAPIClient) CreateFormSubmissionE(formId int64, submission map[string]string) ([]byte, error) {
+	return client.transport.CreateFormSubmissionContext(context.Background(), formId, submission)
 }
 
 //CreateFormSubmissions
@@ -332,6 +356,12 @@ func (client jotformAPIClient) CreateFormSubmission(formId int64, submission map
 //submission (map[string]string): Submission data with question IDs.
 //Returns posted submission ID and URL.
 func (client jotformAPIClient) CreateFormSubmissions(formId int64, submission []byte) []byte {
+	return client.logged(client.CreateFormSubmissionsE(formId, submission))
+}
+
+//CreateFormSubmissionsE
+//CreateFormSubmissions, returning any error instead of logging it.
+func (client jotformAPIClient) CreateFormSubmissionsE(formId int64, submission []byte) ([]byte, error) {
 	return client.executeHttpRequest("form/"+strconv.FormatInt(formId, 10)+"/submissions", submission, "PUT")
 }
 
@@ -340,6 +370,12 @@ func (client jotformAPIClient) CreateFormSubmissions(formId int64, submission []
 //formID (int64): Form ID is the numbers you see on a form URL. You can get form IDs when you call /user/forms.
 //Returns uploaded file information and URLs on a specific form.
 func (client jotformAPIClient) GetFormFiles(formID int64) []byte {
+	return client.logged(client.GetFormFilesE(formID))
+}
+
+//GetFormFilesE
+//GetFormFiles, returning any error instead of logging it.
+func (client jotformAPIClient) GetFormFilesE(formID int64) ([]byte, error) {
 	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10)+"/files", "", "GET")
 }
 
@@ -348,6 +384,12 @@ func (client jotformAPIClient) GetFormFiles(formID int64) []byte {
 //formID (int64): Form ID is the numbers you see on a form URL. You can get form IDs when you call /user/forms.
 //Returns list of webhooks for a specific form.
 func (client jotformAPIClient) GetFormWebhooks(formID int64) []byte {
+	return client.logged(client.GetFormWebhooksE(formID))
+}
+
+//GetFormWebhooksE
+//GetFormWebhooks, returning any error instead of logging it.
+func (client jotformAPIClient) GetFormWebhooksE(formID int64) ([]byte, error) {
 	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10)+"/webhooks", "", "GET")
 }
 
@@ -357,6 +399,12 @@ func (client jotformAPIClient) GetFormWebhooks(formID int64) []byte {
 //webhookURL (string): Webhook URL is where form data will be posted when form is submitted.
 //Returns list of webhooks for a specific form.
 func (client jotformAPIClient) CreateFormWebhook(formId int64, webhookURL string) []byte {
+	return client.logged(client.CreateFormWebhookE(formId, webhookURL))
+}
+
+//CreateFormWebhookE
+//CreateFormWebhook, returning any error instead of logging it.
+func (client jotformAPIClient) CreateFormWebhookE(formId int64, webhookURL string) ([]byte, error) {
 	params := map[string]string{
 		"webhookURL": webhookURL,
 	}
@@ -370,6 +418,12 @@ func (client jotformAPIClient) CreateFormWebhook(formId int64, webhookURL string
 //webhookID (int64): You can get webhook IDs when you call /form/{formID}/webhooks.
 //Returns remaining webhook URLs of form.
 func (client jotformAPIClient) DeleteFormWebhook(formID int64, webhookID int64) []byte {
+	return client.logged(client.DeleteFormWebhookE(formID, webhookID))
+}
+
+//DeleteFormWebhookE
+//DeleteFormWebhook, returning any error instead of logging it.
+func (client jotformAPIClient) DeleteFormWebhookE(formID int64, webhookID int64) ([]byte, error) {
 	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10)+"/webhooks/"+strconv.FormatInt(webhookID, 10), nil, "DELETE")
 }
 
@@ -378,6 +432,12 @@ func (client jotformAPIClient) DeleteFormWebhook(formID int64, webhookID int64)
 //sid (int64): You can get submission IDs when you call /form/{id}/submissions.
 //Returns information and answers of a specific submission.
 func (client jotformAPIClient) GetSubmission(sid int64) []byte {
+	return client.logged(client.GetSubmissionE(sid))
+}
+
+//GetSubmissionE
+//GetSubmission, returning any error instead of logging it.
+func (client jotformAPIClient) GetSubmissionE(sid int64) ([]byte, error) {
 	return client.executeHttpRequest("user/submission/"+strconv.FormatInt(sid, 10), "", "GET")
 }
 
@@ -386,6 +446,12 @@ func (client jotformAPIClient) GetSubmission(sid int64) []byte {
 //reportID (int64): You can get a list of reports from /user/reports.
 //Returns properties of a speceific report like fields and status.
 func (client jotformAPIClient) GetReport(reportID int64) []byte {
+	return client.logged(client.GetReportE(reportID))
+}
+
+//GetReportE
+//GetReport, returning any error instead of logging it.
+func (client jotformAPIClient) GetReportE(reportID int64) ([]byte, error) {
 	return client.executeHttpRequest("user/report/"+strconv.FormatInt(reportID, 10), "", "GET")
 }
 
@@ -393,6 +459,12 @@ func (client jotformAPIClient) GetReport(reportID int64) []byte {
 //folderID (int64): You can get a list of folders from /user/folders.
 //Returns a list of forms in a folder, and other details about the form such as folder color.
 func (client jotformAPIClient) GetFolder(folderID string) []byte {
+	return client.logged(client.GetFolderE(folderID))
+}
+
+//GetFolderE
+//GetFolder, returning any error instead of logging it.
+func (client jotformAPIClient) GetFolderE(folderID string) ([]byte, error) {
 	return client.executeHttpRequest("folder/"+folderID, "", "GET")
 }
 
@@ -400,6 +472,12 @@ func (client jotformAPIClient) GetFolder(folderID string) []byte {
 //folderProperties (map[string]string): Properties of new folder.
 //Returns folder details.
 func (client jotformAPIClient) CreateFolder(folderProperties map[string]string) []byte {
+	return client.logged(client.CreateFolderE(folderProperties))
+}
+
+//CreateFolderE
+//CreateFolder, returning any error instead of logging it.
+func (client jotformAPIClient) CreateFolderE(folderProperties map[string]string) ([]byte, error) {
 	return client.executeHttpRequest("folder", folderProperties, "POST")
 }
 
@@ -407,6 +485,12 @@ func (client jotformAPIClient) CreateFolder(folderProperties map[string]string)
 //folderID (string): You can get the list of folders from /user/folders.
 //Returns status of the request.
 func (client jotformAPIClient) DeleteFolder(folderID string) []byte {
+	return client.logged(client.DeleteFolderE(folderID))
+}
+
+//DeleteFolderE
+//DeleteFolder, returning any error instead of logging it.
+func (client jotformAPIClient) DeleteFolderE(folderID string) ([]byte, error) {
 	return client.executeHttpRequest("folder/"+folderID, nil, "DELETE")
 }
 
@@ -415,6 +499,12 @@ func (client jotformAPIClient) DeleteFolder(folderID string) []byte {
 //folderProperties ([]byte): Properties of folder.
 //Returns status of the request.
 func (client jotformAPIClient) UpdateFolder(folderID string, folderProperties []byte) []byte {
+	return client.logged(client.UpdateFolderE(folderID, folderProperties))
+}
+
+//UpdateFolderE
+//UpdateFolder, returning any error instead of logging it.
+func (client jotformAPIClient) UpdateFolderE(folderID string, folderProperties []byte) ([]byte, error) {
 	return client.executeHttpRequest("folder/"+folderID, folderProperties, "PUT")
 }
 
@@ -423,13 +513,18 @@ func (client jotformAPIClient) UpdateFolder(folderID string, folderProperties []
 //formIDs ([]string): You can get the list of forms from /user/forms.
 //Returns status of the request.
 func (client jotformAPIClient) AddFormsToFolder(folderID string, formIDs []string) []byte {
+	return client.logged(client.AddFormsToFolderE(folderID, formIDs))
+}
+
+//AddFormsToFolderE
+//AddFormsToFolder, returning any error instead of logging it.
+func (client jotformAPIClient) AddFormsToFolderE(folderID string, formIDs []string) ([]byte, error) {
 	formattedFormIDs, err := json.Marshal(map[string][]string{
 		"forms": formIDs,
 	})
 
 	if err != nil {
-		fmt.Printf("%s", err)
-		os.Exit(1)
+		return nil, err
 	}
 
 	return client.executeHttpRequest("folder/"+folderID, formattedFormIDs, "PUT")
@@ -440,13 +535,18 @@ func (client jotformAPIClient) AddFormsToFolder(folderID string, formIDs []strin
 //formID (string): You can get the list of forms from /user/forms.
 //Returns status of the request.
 func (client jotformAPIClient) AddFormToFolder(folderID string, formID string) []byte {
+	return client.logged(client.AddFormToFolderE(folderID, formID))
+}
+
+//AddFormToFolderE
+//AddFormToFolder, returning any error instead of logging it.
+func (client jotformAPIClient) AddFormToFolderE(folderID string, formID string) ([]byte, error) {
 	formattedFormID, err := json.Marshal(map[string][]string{
 		"forms": {formID},
 	})
 
 	if err != nil {
-		fmt.Printf("%s", err)
-		os.Exit(1)
+		return nil, err
 	}
 
 	return client.executeHttpRequest("folder/"+folderID, formattedFormID, "PUT")
@@ -457,6 +557,12 @@ func (client jotformAPIClient) AddFormToFolder(folderID string, formID string) [
 //formID (int64): Form ID is the numbers you see on a form URL. You can get form IDs when you call /user/forms.
 //Returns form properties like width, expiration date, style etc.
 func (client jotformAPIClient) GetFormProperties(formID int64) []byte {
+	return client.logged(client.GetFormPropertiesE(formID))
+}
+
+//GetFormPropertiesE
+//GetFormProperties, returning any error instead of logging it.
+func (client jotformAPIClient) GetFormPropertiesE(formID int64) ([]byte, error) {
 	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10)+"/properties", "", "GET")
 }
 
@@ -465,6 +571,12 @@ func (client jotformAPIClient) GetFormProperties(formID int64) []byte {
 //formID (int64): Form ID is the numbers you see on a form URL. You can get form IDs when you call /user/forms.
 //Returns list of all reports in a form, and other details about the reports such as title.
 func (client jotformAPIClient) GetFormReports(formID int64) []byte {
+	return client.logged(client.GetFormReportsE(formID))
+}
+
+//GetFormReportsE
+//GetFormReports, returning any error instead of logging it.
+func (client jotformAPIClient) GetFormReportsE(formID int64) ([]byte, error) {
 	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10)+"/reports", "", "GET")
 }
 
@@ -474,6 +586,12 @@ func (client jotformAPIClient) GetFormReports(formID int64) []byte {
 //report (map[string]string): Report details. List type, title etc.
 //Returns report details and URL.
 func (client jotformAPIClient) CreateReport(formID int64, report map[string]string) []byte {
+	return client.logged(client.CreateReportE(formID, report))
+}
+
+//CreateReportE
+//CreateReport, returning any error instead of logging it.
+func (client jotformAPIClient) CreateReportE(formID int64, report map[string]string) ([]byte, error) {
 	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10)+"/reports", report, "POST")
 }
 
@@ -483,6 +601,12 @@ func (client jotformAPIClient) CreateReport(formID int64, report map[string]stri
 //propertyKey (string): You can get property keys when you call /form/{id}/properties.
 //Returns given property key value.
 func (client jotformAPIClient) GetFormProperty(formID int64, propertyKey string) []byte {
+	return client.logged(client.GetFormPropertyE(formID, propertyKey))
+}
+
+//GetFormPropertyE
+//GetFormProperty, returning any error instead of logging it.
+func (client jotformAPIClient) GetFormPropertyE(formID int64, propertyKey string) ([]byte, error) {
 	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10)+"/properties/"+propertyKey, "", "POST")
 }
 
@@ -491,6 +615,12 @@ func (client jotformAPIClient) GetFormProperty(formID int64, propertyKey string)
 //sid (int64): You can get submission IDs when you call /form/{id}/submissions.
 //Returns status of request.
 func (client jotformAPIClient) DeleteSubmission(sid int64) []byte {
+	return client.logged(client.DeleteSubmissionE(sid))
+}
+
+//DeleteSubmissionE
+//DeleteSubmission, returning any error instead of logging it.
+func (client jotformAPIClient) DeleteSubmissionE(sid int64) ([]byte, error) {
 	return client.executeHttpRequest("submission/"+strconv.FormatInt(sid, 10), nil, "DELETE")
 }
 
@@ -500,17 +630,13 @@ func (client jotformAPIClient) DeleteSubmission(sid int64) []byte {
 //submission (map[string]string): New submission data with question IDs.
 //Returns status of request.
 func (client jotformAPIClient) EditSubmission(sid int64, submission map[string]string) []byte {
-	data := make(map[string]string)
-
-	for k, _ := range submission {
-		if strings.Contains(k, "_") && k != "created_at" {
-			data["submission["+k[0:strings.Index(k, "_")]+"]["+k[strings.Index(k, "_")+1:len(k)]+"]"] = submission[k]
-		} else {
-			data["submission["+k+"]"] = submission[k]
-		}
-	}
+	return client.logged(client.EditSubmissionE(sid, submission))
+}
 
-	return client.executeHttpRequest("submission/"+strconv.FormatInt(sid, 10), data, "POST")
+//EditSubmissionE
+//EditSubmission, returning any error instead of logging it.
+func (client jotformAPIClient) EditSubmissionE(sid int64, submission map[string]string) ([]byte, error) {
+	return client.transport.EditSubmissionContext(context.Background(), sid, submission)
 }
 
 //CloneForm
@@ -518,6 +644,12 @@ func (client jotformAPIClient) EditSubmission(sid int64, submission map[string]s
 //formID (int64): Form ID is the numbers you see on a form URL. You can get form IDs when you call /user/forms.
 //Returns status of request.
 func (client jotformAPIClient) CloneForm(formID int64) []byte {
+	return client.logged(client.CloneFormE(formID))
+}
+
+//CloneFormE
+//CloneForm, returning any error instead of logging it.
+func (client jotformAPIClient) CloneFormE(formID int64) ([]byte, error) {
 	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10)+"/clone", nil, "POST")
 }
 
@@ -526,6 +658,12 @@ func (client jotformAPIClient) CloneForm(formID int64) []byte {
 //qid (int): Identifier for each question on a form. You can get a list of question IDs from /form/{id}/questions.
 //Returns status of request.
 func (client jotformAPIClient) DeleteFormQuestion(formID int64, qid int) []byte {
+	return client.logged(client.DeleteFormQuestionE(formID, qid))
+}
+
+//DeleteFormQuestionE
+//DeleteFormQuestion, returning any error instead of logging it.
+func (client jotformAPIClient) DeleteFormQuestionE(formID int64, qid int) ([]byte, error) {
 	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10)+"/question/"+strconv.Itoa(qid), nil, "DELETE")
 }
 
@@ -535,6 +673,12 @@ func (client jotformAPIClient) DeleteFormQuestion(formID int64, qid int) []byte
 //questionProperties (map[string]string): New question properties like type and text.
 //Returns properties of new question.
 func (client jotformAPIClient) CreateFormQuestion(formID int64, questionProperties map[string]string) []byte {
+	return client.logged(client.CreateFormQuestionE(formID, questionProperties))
+}
+
+//CreateFormQuestionE
+//CreateFormQuestion, returning any error instead of logging it.
+func (client jotformAPIClient) CreateFormQuestionE(formID int64, questionProperties map[string]string) ([]byte, error) {
 	question := make(map[string]string)
 
 	for k, _ := range questionProperties {
@@ -550,6 +694,12 @@ func (client jotformAPIClient) CreateFormQuestion(formID int64, questionProperti
 //questions ([]byte): New question properties like type and text.
 //Returns properties of new question.
 func (client jotformAPIClient) CreateFormQuestions(formID int64, questions []byte) []byte {
+	return client.logged(client.CreateFormQuestionsE(formID, questions))
+}
+
+//CreateFormQuestionsE
+//CreateFormQuestions, returning any error instead of logging it.
+func (client jotformAPIClient) CreateFormQuestionsE(formID int64, questions []byte) ([]byte, error) {
 	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10)+"/questions", questions, "PUT")
 }
 
@@ -560,6 +710,12 @@ func (client jotformAPIClient) CreateFormQuestions(formID int64, questions []byt
 //questionProperties (map[string]string): New question properties like type and text.
 //Returns edited property and type of question.
 func (client jotformAPIClient) EditFormQuestion(formID int64, qid int, questionProperties map[string]string) []byte {
+	return client.logged(client.EditFormQuestionE(formID, qid, questionProperties))
+}
+
+//EditFormQuestionE
+//EditFormQuestion, returning any error instead of logging it.
+func (client jotformAPIClient) EditFormQuestionE(formID int64, qid int, questionProperties map[string]string) ([]byte, error) {
 	question := make(map[string]string)
 
 	for k, _ := range questionProperties {
@@ -575,6 +731,12 @@ func (client jotformAPIClient) EditFormQuestion(formID int64, qid int, questionP
 //formProperties (map[string]string): New properties like label width.
 //Returns edited properties.
 func (client jotformAPIClient) SetFormProperties(formID int64, formProperties map[string]string) []byte {
+	return client.logged(client.SetFormPropertiesE(formID, formProperties))
+}
+
+//SetFormPropertiesE
+//SetFormProperties, returning any error instead of logging it.
+func (client jotformAPIClient) SetFormPropertiesE(formID int64, formProperties map[string]string) ([]byte, error) {
 	properties := make(map[string]string)
 
 	for k, _ := range formProperties {
@@ -590,6 +752,12 @@ func (client jotformAPIClient) SetFormProperties(formID int64, formProperties ma
 //formProperties ([]byte): New properties like label width.
 //Returns edited properties.
 func (client jotformAPIClient) SetMultipleFormProperties(formID int64, formProperties []byte) []byte {
+	return client.logged(client.SetMultipleFormPropertiesE(formID, formProperties))
+}
+
+//SetMultipleFormPropertiesE
+//SetMultipleFormProperties, returning any error instead of logging it.
+func (client jotformAPIClient) SetMultipleFormPropertiesE(formID int64, formProperties []byte) ([]byte, error) {
 	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10)+"/properties", formProperties, "PUT")
 }
 
@@ -598,22 +766,37 @@ func (client jotformAPIClient) SetMultipleFormProperties(formID int64, formPrope
 //form ([]byte): Questions, properties and emails of new form.
 //Returns new form.
 func (client jotformAPIClient) CreateForm(form map[string]interface{}) []byte {
+	return client.logged(client.CreateFormE(form))
+}
+
+//CreateFormE
+//CreateForm, returning any error instead of logging it.
+func (client jotformAPIClient) CreateFormE(form map[string]interface{}) ([]byte, error) {
 	params := make(map[string]string)
 
 	for formKey, formValue := range form {
 		if formKey == "properties" {
-			properties := formValue
+			properties, ok := formValue.(map[string]string)
+			if !ok {
+				return nil, fmt.Errorf("jotform: form properties are %T, not map[string]string", formValue)
+			}
 
-			for properyKey, propertyValue := range properties.(map[string]string) {
+			for properyKey, propertyValue := range properties {
 				params[formKey+"["+properyKey+"]"] = propertyValue
 			}
 		} else {
-			formItem := formValue
+			formItem, ok := formValue.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("jotform: form %s are %T, not map[string]interface{}", formKey, formValue)
+			}
 
-			for formItemKey, formItemValue := range formItem.(map[string]interface{}) {
-				item := formItemValue
+			for formItemKey, formItemValue := range formItem {
+				item, ok := formItemValue.(map[string]string)
+				if !ok {
+					return nil, fmt.Errorf("jotform: form %s[%s] is %T, not map[string]string", formKey, formItemKey, formItemValue)
+				}
 
-				for itemKey, itemValue := range item.(map[string]string) {
+				for itemKey, itemValue := range item {
 					params[formKey+"["+formItemKey+"]["+itemKey+"]"] = itemValue
 				}
 			}
@@ -628,6 +811,12 @@ func (client jotformAPIClient) CreateForm(form map[string]interface{}) []byte {
 //form ([]byte): Questions, properties and emails of forms.
 //Returns new forms.
 func (client jotformAPIClient) CreateForms(form []byte) []byte {
+	return client.logged(client.CreateFormsE(form))
+}
+
+//CreateFormsE
+//CreateForms, returning any error instead of logging it.
+func (client jotformAPIClient) CreateFormsE(form []byte) ([]byte, error) {
 	return client.executeHttpRequest("user/forms", form, "PUT")
 }
 
@@ -635,6 +824,12 @@ func (client jotformAPIClient) CreateForms(form []byte) []byte {
 //formID (int64): Form ID is the numbers you see on a form URL. You can get form IDs when you call /user/forms.
 //Returns properties of deleted form.
 func (client jotformAPIClient) DeleteForm(formID int64) []byte {
+	return client.logged(client.DeleteFormE(formID))
+}
+
+//DeleteFormE
+//DeleteForm, returning any error instead of logging it.
+func (client jotformAPIClient) DeleteFormE(formID int64) ([]byte, error) {
 	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10), nil, "DELETE")
 }
 
@@ -643,6 +838,12 @@ func (client jotformAPIClient) DeleteForm(formID int64) []byte {
 //userDetails (map[string]string): Username, password and email to register a new user
 //Returns new user's details
 func (client jotformAPIClient) RegisterUser(userDetails map[string]string) []byte {
+	return client.logged(client.RegisterUserE(userDetails))
+}
+
+//RegisterUserE
+//RegisterUser, returning any error instead of logging it.
+func (client jotformAPIClient) RegisterUserE(userDetails map[string]string) ([]byte, error) {
 	return client.executeHttpRequest("user/register", userDetails, "POST")
 }
 
@@ -651,6 +852,12 @@ func (client jotformAPIClient) RegisterUser(userDetails map[string]string) []byt
 //credentials (map[string]string): Username, password, application name and access type of user
 //Returns logged in user's settings and app key
 func (client jotformAPIClient) LoginUser(credentials map[string]string) []byte {
+	return client.logged(client.LoginUserE(credentials))
+}
+
+//LoginUserE
+//LoginUser, returning any error instead of logging it.
+func (client jotformAPIClient) LoginUserE(credentials map[string]string) ([]byte, error) {
 	return client.executeHttpRequest("user/login", credentials, "POST")
 }
 
@@ -658,6 +865,12 @@ func (client jotformAPIClient) LoginUser(credentials map[string]string) []byte {
 //Logout user
 //Returns status of request
 func (client jotformAPIClient) LogoutUser() []byte {
+	return client.logged(client.LogoutUserE())
+}
+
+//LogoutUserE
+//LogoutUser, returning any error instead of logging it.
+func (client jotformAPIClient) LogoutUserE() ([]byte, error) {
 	return client.executeHttpRequest("user/logout", "", "GET")
 }
 
@@ -666,6 +879,12 @@ func (client jotformAPIClient) LogoutUser() []byte {
 //planName (string): Name of the requested plan. FREE, PREMIUM etc.
 //Returns details of a plan
 func (client jotformAPIClient) GetPlan(planName string) []byte {
+	return client.logged(client.GetPlanE(planName))
+}
+
+//GetPlanE
+//GetPlan, returning any error instead of logging it.
+func (client jotformAPIClient) GetPlanE(planName string) ([]byte, error) {
 	return client.executeHttpRequest("system/plan/"+planName, "", "GET")
 }
 
@@ -673,5 +892,11 @@ func (client jotformAPIClient) GetPlan(planName string) []byte {
 //reportID (int64): You can get a list of reports from /user/reports.
 //Returns status of request.
 func (client jotformAPIClient) DeleteReport(reportID int64) []byte {
+	return client.logged(client.DeleteReportE(reportID))
+}
+
+//DeleteReportE
+//DeleteReport, returning any error instead of logging it.
+func (client jotformAPIClient) DeleteReportE(reportID int64) ([]byte, error) {
 	return client.executeHttpRequest("report/"+strconv.FormatInt(reportID, 10), nil, "DELETE")
 }
@@ -0,0 +1,135 @@
+package jotform
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"testing"
+
+	v2 "github.com/jotform/jotform-api-go/v2"
+	"github.com/jotform/jotform-api-go/v2/jotformtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestClient returns a client for server, logging to the returned buffer.
+func newTestClient(server *jotformtest.Server) (*jotformAPIClient, *bytes.Buffer) {
+	client := NewJotFormAPIClient("api-key", "json", false)
+	transport := v2.New("api-key", v2.WithBaseURL(server.URL))
+	transport.Retry = nil
+	client.transport = transport
+
+	var logs bytes.Buffer
+	client.SetLogger(log.New(&logs, "", 0))
+	return client, &logs
+}
+
+func TestErrors(t *testing.T) {
+	server := jotformtest.NewServer()
+	defer server.Close()
+	server.APIKey = "api-key"
+
+	t.Run("happy - E variant returns content", func(t *testing.T) {
+		client, logs := newTestClient(server)
+
+		content, err := client.GetUserE()
+		assert.Nil(t, err)
+		assert.True(t, json.Valid(content))
+		assert.Equal(t, "", logs.String())
+	})
+
+	t.Run("sad - E variant returns the error", func(t *testing.T) {
+		client, logs := newTestClient(server)
+		client.transport = v2.New("wrong", v2.WithBaseURL(server.URL))
+
+		content, err := client.GetUserE()
+		assert.Nil(t, content)
+		assert.ErrorIs(t, err, v2.ErrUnauthorized)
+		assert.Equal(t, "", logs.String())
+	})
+
+	t.Run("sad - method logs the error and returns nil", func(t *testing.T) {
+		client, logs := newTestClient(server)
+		client.transport = v2.New("wrong", v2.WithBaseURL(server.URL))
+
+		assert.Nil(t, client.GetUser())
+		assert.Contains(t, logs.String(), "not authorized")
+
+		client.SetLogger(nil)
+		assert.Nil(t, client.GetUsage())
+	})
+}
+
+func TestSubmissionE(t *testing.T) {
+	server := jotformtest.NewServer()
+	defer server.Close()
+	form := server.AddForm(v2.Form{Title: "Contact"},
+		v2.Question{Type: "control_fullname", Text: "Name"},
+		v2.Question{Type: "control_textbox", Text: "Note"})
+
+	t.Run("happy - create sends subfields as brackets", func(t *testing.T) {
+		client, _ := newTestClient(server)
+
+		_, err := client.CreateFormSubmissionE(int64(form.ID), map[string]string{"1_first": "Ada", "1_last": "Lovelace", "2": "Hello"})
+		assert.Nil(t, err)
+
+		requests := server.Requests()
+		sent := requests[len(requests)-1].Form
+		assert.Equal(t, "Ada", sent.Get("submission[1][first]"))
+		assert.Equal(t, "Lovelace", sent.Get("submission[1][last]"))
+		assert.Equal(t, "Hello", sent.Get("submission[2]"))
+	})
+
+	t.Run("happy - edit keeps created_at whole", func(t *testing.T) {
+		client, _ := newTestClient(server)
+		submission := server.AddSubmission(v2.Submission{FormID: form.ID})
+
+		_, err := client.EditSubmissionE(int64(submission.ID), map[string]string{"2": "Edited", "created_at": "2024-05-01 09:00:00"})
+		assert.Nil(t, err)
+
+		requests := server.Requests()
+		sent := requests[len(requests)-1].Form
+		assert.Equal(t, "Edited", sent.Get("submission[2]"))
+		assert.Equal(t, "2024-05-01 09:00:00", sent.Get("submission[created_at]"))
+	})
+}
+
+func TestCreateFormE(t *testing.T) {
+	server := jotformtest.NewServer()
+	defer server.Close()
+
+	t.Run("happy - sends properties and questions", func(t *testing.T) {
+		client, _ := newTestClient(server)
+
+		_, err := client.CreateFormE(map[string]interface{}{
+			"properties": map[string]string{"title": "Contact"},
+			"questions": map[string]interface{}{
+				"1": map[string]string{"type": "control_textbox", "text": "Name"},
+			},
+		})
+		assert.Nil(t, err)
+
+		requests := server.Requests()
+		sent := requests[len(requests)-1].Form
+		assert.Equal(t, "Contact", sent.Get("properties[title]"))
+		assert.Equal(t, "control_textbox", sent.Get("questions[1][type]"))
+	})
+
+	t.Run("sad - wrong types are errors, not panics", func(t *testing.T) {
+		client, logs := newTestClient(server)
+
+		for _, form := range []map[string]interface{}{
+			{"properties": map[string]interface{}{"title": "Contact"}},
+			{"questions": []string{"Name"}},
+			{"questions": map[string]interface{}{"1": "control_textbox"}},
+		} {
+			before := len(server.Requests())
+
+			_, err := client.CreateFormE(form)
+			assert.Error(t, err)
+			assert.Equal(t, before, len(server.Requests()))
+
+			assert.Nil(t, client.CreateForm(form))
+		}
+		assert.Contains(t, logs.String(), "jotform: form questions[1] is string")
+	})
+}
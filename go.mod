@@ -0,0 +1,20 @@
+module github.com/jotform/jotform-api-go
+
+go 1.23
+
+// Builds within this repository use the v2 module next to it.
+// Other modules ignore this replace and need the v2 release required below,
+// which must have everything v1 calls. v2 lives in the v2 directory of the repository,
+// so its releases are tagged plainly, eg. v2.1.0, and must be published before v1 is.
+replace github.com/jotform/jotform-api-go/v2 => ./v2
+
+require (
+	github.com/jotform/jotform-api-go/v2 v2.1.0
+	github.com/stretchr/testify v1.7.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c // indirect
+)
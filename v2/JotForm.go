@@ -2,10 +2,11 @@ package jotform
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"encoding/xml"
 	"fmt"
 	"io/ioutil"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -20,96 +21,150 @@ type HttpClient interface {
 	Do(req *http.Request) (*http.Response, error)
 }
 
-type jotformAPIClient struct {
+// Client calls the JotForm API. Make one with New, or NewJotFormAPIClient.
+// Its methods are listed by the API interface.
+type Client struct {
 	apiKey     string
 	outputType string
 	debugMode  bool
 	HttpClient HttpClient
 	BaseURL    string
+	// Retry is the policy for retrying transient failures.
+	// A nil policy disables retries.
+	Retry *RetryPolicy
+	// RateLimiter, if set, paces requests and is told the remaining daily quota.
+	RateLimiter RateLimiter
+
+	quota      *quotaTracker
+	middleware []Middleware
+	logger     *slog.Logger
+	// fileHost is the region host whose files are downloaded with the API key.
+	fileHost string
 }
 
-func NewJotFormAPIClient(apiKey string, outputType string, debugMode bool) *jotformAPIClient {
-	client := &jotformAPIClient{
+// NewJotFormAPIClient returns a client for the API key, requesting outputType "json" or "xml".
+// If debugMode is set, requests are printed to standard output.
+// New configures a client with options instead.
+func NewJotFormAPIClient(apiKey string, outputType string, debugMode bool) *Client {
+	client := &Client{
 		apiKey:     apiKey,
 		outputType: strings.ToLower(outputType),
 		debugMode:  debugMode,
-		HttpClient: &http.Client{
-			Timeout: time.Second * 60,
-			Transport: &http.Transport{
-				// We occasionally see EOF responses, which may be caused
-				// by net/http's default connection reuse.
-				DisableKeepAlives: true,
-			},
-		},
-		BaseURL: defaultBaseURL,
+		HttpClient: newHTTPClient(DefaultTimeout),
+		BaseURL:    defaultBaseURL,
+		Retry:      DefaultRetryPolicy(),
+		quota:      &quotaTracker{},
 	}
 
 	return client
 }
 
-func (client jotformAPIClient) GetOutputType() string       { return client.outputType }
-func (client *jotformAPIClient) SetOutputType(value string) { client.outputType = value }
+// newHTTPClient returns the http.Client clients use by default.
+func newHTTPClient(timeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// We occasionally see EOF responses, which may be caused
+	// by net/http's default connection reuse.
+	// A POST that fails that way isn't retried, so connections aren't reused.
+	transport.DisableKeepAlives = true
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
+
+func (client Client) GetOutputType() string       { return client.outputType }
+func (client *Client) SetOutputType(value string) { client.outputType = value }
 
-func (client jotformAPIClient) GetDebugMode() bool       { return client.debugMode }
-func (client *jotformAPIClient) SetDebugMode(value bool) { client.debugMode = value }
+func (client Client) GetDebugMode() bool       { return client.debugMode }
+func (client *Client) SetDebugMode(value bool) { client.debugMode = value }
 
-func (client jotformAPIClient) debug(str interface{}) {
-	if client.debugMode {
+func (client Client) debug(str interface{}) {
+	if client.logger != nil {
+		client.logger.Debug("jotform", "debug", str)
+	} else if client.debugMode {
 		fmt.Println(str)
 	}
 }
 
-func (client jotformAPIClient) newRequest(requestPath string, params interface{}, method string) *http.Request {
-	if client.outputType != "json" {
-		requestPath = requestPath + ".xml"
-	}
-
+func (client Client) newRequest(ctx context.Context, requestPath string, params interface{}, method string) (*http.Request, error) {
 	var path = client.BaseURL + "/" + apiVersion + "/" + requestPath
 	client.debug(path)
 	client.debug(params)
 
 	var request *http.Request
+	var err error
 
 	if method == "GET" {
-		if params != "" {
-			data := params.(map[string]string)
+		if data, ok := params.(map[string]string); ok {
 			values := make(url.Values)
 
 			for k, _ := range data {
 				values.Set(k, data[k])
 			}
-			path = path + "?" + values.Encode()
+			if len(values) > 0 {
+				path = path + "?" + values.Encode()
+			}
 		}
 
-		request, _ = http.NewRequest("GET", path, nil)
-		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		request, err = http.NewRequestWithContext(ctx, "GET", path, nil)
+		if err == nil {
+			request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
 	} else if method == "POST" {
-		data := params.(map[string]string)
+		data, _ := params.(map[string]string)
 		values := make(url.Values)
 
 		for k, _ := range data {
 			values.Set(k, data[k])
 		}
 
-		request, _ = http.NewRequest("POST", path, strings.NewReader(values.Encode()))
-		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		request, err = http.NewRequestWithContext(ctx, "POST", path, strings.NewReader(values.Encode()))
+		if err == nil {
+			request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
 	} else if method == "DELETE" {
-		request, _ = http.NewRequest("DELETE", path, nil)
+		request, err = http.NewRequestWithContext(ctx, "DELETE", path, nil)
 	} else if method == "PUT" {
-		parameters := params.([]byte)
-		request, _ = http.NewRequest("PUT", path, bytes.NewBuffer(parameters))
+		parameters, _ := params.([]byte)
+		request, err = http.NewRequestWithContext(ctx, "PUT", path, bytes.NewBuffer(parameters))
+	} else {
+		err = fmt.Errorf("unsupported method %q", method)
+	}
+
+	if err != nil {
+		return nil, err
 	}
 
 	request.Header.Add("apiKey", client.apiKey)
-	return request
+	return request, nil
 }
 
-func (client jotformAPIClient) executeHttpRequest(requestPath string, params interface{}, method string) ([]byte, error) {
+// do sends the request through the middleware and HttpClient, retrying as configured.
+func (client Client) do(request *http.Request) (*http.Response, error) {
+	return client.chain().Do(request)
+}
+
+// Call sends a request to any endpoint, such as one without a method of its own,
+// and returns the content of the response.
+// params is a map[string]string of query or form parameters,
+// or the []byte body of a PUT request.
+func (client Client) Call(ctx context.Context, requestPath string, params interface{}, method string) ([]byte, error) {
+	return client.executeHttpRequest(ctx, requestPath, params, method)
+}
+
+func (client Client) executeHttpRequest(ctx context.Context, requestPath string, params interface{}, method string) ([]byte, error) {
+	result, err := client.execute(ctx, requestPath, params, method)
+	if err != nil {
+		return nil, err
+	}
+	return result.content, nil
+}
 
-	response, err := client.HttpClient.Do(
-		client.newRequest(requestPath, params, method),
-	)
+// apiResponse is the content of a response along with its paging metadata.
+type apiResponse struct {
+	content   []byte
+	resultSet *ResultSet
+}
 
+func (client Client) execute(ctx context.Context, requestPath string, params interface{}, method string) (*apiResponse, error) {
+	response, err := client.send(ctx, requestPath, params, method)
 	if err != nil {
 		return nil, err
 	}
@@ -120,27 +175,89 @@ func (client jotformAPIClient) executeHttpRequest(requestPath string, params int
 		return nil, err
 	}
 
+	var env envelope
+	var root *xmlNode
 	if client.outputType == "json" {
-		var f interface{}
-		json.Unmarshal(contents, &f)
-		result, ok := f.(map[string]interface{})
-		if !ok {
+		if err := json.Unmarshal(contents, &env); err != nil {
 			return nil, fmt.Errorf("Unexpected non-json response")
 		}
-		content, err := json.Marshal(result["content"])
+	} else if client.outputType == "xml" {
+		if root, err = parseXML(contents); err != nil {
+			return nil, fmt.Errorf("Unexpected non-xml response")
+		}
+		if err := root.decodeJSON(&env); err != nil {
+			return nil, err
+		}
+	} else {
+		return &apiResponse{}, nil
+	}
+
+	if err := client.checkEnvelope(response, env); err != nil {
+		return nil, err
+	}
+
+	content := []byte(env.Content)
+	if root != nil {
+		// The content element, without the envelope, as json content is.
+		if content, err = root.child("content").marshal(); err != nil {
+			return nil, err
+		}
+	} else if content == nil {
+		content = []byte("null")
+	}
+	return &apiResponse{content: content, resultSet: env.ResultSet}, nil
+}
+
+// send performs the request, returning the response if it succeeded,
+// or an *APIError if it failed with an error status.
+// Only responses in the API envelope come as XML, so only their paths take the .xml suffix.
+func (client Client) send(ctx context.Context, requestPath string, params interface{}, method string) (*http.Response, error) {
+	if client.outputType != "json" {
+		requestPath = requestPath + ".xml"
+	}
+
+	request, err := client.newRequest(ctx, requestPath, params, method)
+	if err != nil {
+		return nil, err
+	}
 
+	response, err := client.do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		defer response.Body.Close()
+		contents, err := ioutil.ReadAll(response.Body)
 		if err != nil {
 			return nil, err
-		} else {
-			return content, nil
 		}
-	} else if client.outputType == "xml" {
-		var f interface{}
-		xml.Unmarshal(contents, &f)
-		return contents, nil
+		if client.outputType == "xml" {
+			if converted, err := xmlToJSON(contents); err == nil {
+				contents = converted
+			}
+		}
+		apiErr := newAPIError(response, contents)
+		if apiErr.limitLeft != nil {
+			client.observeQuota(*apiErr.limitLeft)
+		}
+		return nil, apiErr
 	}
+	return response, nil
+}
 
-	return nil, nil
+// checkEnvelope observes the quota left in env, and returns an *APIError if it reports a failure.
+func (client Client) checkEnvelope(response *http.Response, env envelope) error {
+	if env.LimitLeft != nil {
+		client.observeQuota(int(*env.LimitLeft))
+	}
+
+	if env.failed() {
+		apiErr := newAPIError(response, nil)
+		apiErr.fromEnvelope(env)
+		return apiErr
+	}
+	return nil
 }
 
 func createConditions(offset string, limit string, filter map[string]string, orderby string) map[string]string {
@@ -189,15 +306,25 @@ func createHistoryQuery(action string, date string, sortBy string, startDate str
 //GetUser
 //Get user account details for a JotForm user.
 //Returns user account type, avatar URL, name, email, website URL and account limits.
-func (client jotformAPIClient) GetUser() ([]byte, error) {
-	return client.executeHttpRequest("user", "", "GET")
+func (client Client) GetUser() ([]byte, error) {
+	return client.GetUserContext(context.Background())
+}
+
+// GetUserContext is GetUser with a context.
+func (client Client) GetUserContext(ctx context.Context) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "user", "", "GET")
 }
 
 //GetUsage
 //Get number of form submissions received this month
 //Returns number of submissions, number of SSL form submissions, payment form submissions and upload space used by user.
-func (client jotformAPIClient) GetUsage() ([]byte, error) {
-	return client.executeHttpRequest("user/usage", "", "GET")
+func (client Client) GetUsage() ([]byte, error) {
+	return client.GetUsageContext(context.Background())
+}
+
+// GetUsageContext is GetUsage with a context.
+func (client Client) GetUsageContext(ctx context.Context) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "user/usage", "", "GET")
 }
 
 //GetForms
@@ -207,10 +334,15 @@ func (client jotformAPIClient) GetUsage() ([]byte, error) {
 //filter (map[string]string): Filters the query results to fetch a specific form range.
 //orderBy (string): Order results by a form field name.
 //Returns basic details such as title of the form, when it was created, number of new and total submissions.
-func (client jotformAPIClient) GetForms(offset string, limit string, filter map[string]string, orderBy string) ([]byte, error) {
+func (client Client) GetForms(offset string, limit string, filter map[string]string, orderBy string) ([]byte, error) {
+	return client.GetFormsContext(context.Background(), offset, limit, filter, orderBy)
+}
+
+// GetFormsContext is GetForms with a context.
+func (client Client) GetFormsContext(ctx context.Context, offset string, limit string, filter map[string]string, orderBy string) ([]byte, error) {
 	var params = createConditions(offset, limit, filter, orderBy)
 
-	return client.executeHttpRequest("user/forms", params, "GET")
+	return client.executeHttpRequest(ctx, "user/forms", params, "GET")
 }
 
 //GetSubmissions
@@ -220,45 +352,75 @@ func (client jotformAPIClient) GetForms(offset string, limit string, filter map[
 //filter (map[string]string): Filters the query results to fetch a specific form range.
 //orderBy (string): Order results by a form field name.
 //Returns basic details such as title of the form, when it was created, number of new and total submissions.
-func (client jotformAPIClient) GetSubmissions(offset string, limit string, filter map[string]string, orderBy string) ([]byte, error) {
+func (client Client) GetSubmissions(offset string, limit string, filter map[string]string, orderBy string) ([]byte, error) {
+	return client.GetSubmissionsContext(context.Background(), offset, limit, filter, orderBy)
+}
+
+// GetSubmissionsContext is GetSubmissions with a context.
+func (client Client) GetSubmissionsContext(ctx context.Context, offset string, limit string, filter map[string]string, orderBy string) ([]byte, error) {
 	var params = createConditions(offset, limit, filter, orderBy)
 
-	return client.executeHttpRequest("user/submissions", params, "GET")
+	return client.executeHttpRequest(ctx, "user/submissions", params, "GET")
 }
 
 //GetSubusers
 //Get a list of sub users for this account
 //Returns list of forms and form folders with access privileges.
-func (client jotformAPIClient) GetSubusers() ([]byte, error) {
-	return client.executeHttpRequest("user/subusers", "", "GET")
+func (client Client) GetSubusers() ([]byte, error) {
+	return client.GetSubusersContext(context.Background())
+}
+
+// GetSubusersContext is GetSubusers with a context.
+func (client Client) GetSubusersContext(ctx context.Context) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "user/subusers", "", "GET")
 }
 
 //GetFolders
 //Get a list of form folders for this account
 //Returns name of the folder and owner of the folder for shared folders.
-func (client jotformAPIClient) GetFolders() ([]byte, error) {
-	return client.executeHttpRequest("user/folders", "", "GET")
+func (client Client) GetFolders() ([]byte, error) {
+	return client.GetFoldersContext(context.Background())
+}
+
+// GetFoldersContext is GetFolders with a context.
+func (client Client) GetFoldersContext(ctx context.Context) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "user/folders", "", "GET")
 }
 
 //GetReports
 //List of URLS for reports in this account
 //Returns reports for all of the forms. ie. Excel, CSV, printable charts, embeddable HTML tables.
-func (client jotformAPIClient) GetReports() ([]byte, error) {
-	return client.executeHttpRequest("user/reports", "", "GET")
+func (client Client) GetReports() ([]byte, error) {
+	return client.GetReportsContext(context.Background())
+}
+
+// GetReportsContext is GetReports with a context.
+func (client Client) GetReportsContext(ctx context.Context) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "user/reports", "", "GET")
 }
 
 //Update user's settings
 //New user setting values with setting keys
 //Returns changes on user settings
-func (client jotformAPIClient) GetSettings() ([]byte, error) {
-	return client.executeHttpRequest("user/settings", "", "GET")
+func (client Client) GetSettings() ([]byte, error) {
+	return client.GetSettingsContext(context.Background())
+}
+
+// GetSettingsContext is GetSettings with a context.
+func (client Client) GetSettingsContext(ctx context.Context) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "user/settings", "", "GET")
 }
 
 //GetSettings
 //Get user's settings for this account
 //Returns user's time zone and language.
-func (client jotformAPIClient) UpdateSettings(settings map[string]string) ([]byte, error) {
-	return client.executeHttpRequest("user/settings", settings, "POST")
+func (client Client) UpdateSettings(settings map[string]string) ([]byte, error) {
+	return client.UpdateSettingsContext(context.Background(), settings)
+}
+
+// UpdateSettingsContext is UpdateSettings with a context.
+func (client Client) UpdateSettingsContext(ctx context.Context, settings map[string]string) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "user/settings", settings, "POST")
 }
 
 //GetHistory
@@ -269,33 +431,53 @@ func (client jotformAPIClient) UpdateSettings(settings map[string]string) ([]byt
 //startDate (string): Limit results to only after a specific date. Format: MM/DD/YYYY.
 //endDate (string): Limit results to only before a specific date. Format: MM/DD/YYYY.
 //Returns activity log about things like forms created/modified/deleted, account logins and other operations.
-func (client jotformAPIClient) GetHistory(action string, date string, sortBy string, startDate string, endDate string) ([]byte, error) {
+func (client Client) GetHistory(action string, date string, sortBy string, startDate string, endDate string) ([]byte, error) {
+	return client.GetHistoryContext(context.Background(), action, date, sortBy, startDate, endDate)
+}
+
+// GetHistoryContext is GetHistory with a context.
+func (client Client) GetHistoryContext(ctx context.Context, action string, date string, sortBy string, startDate string, endDate string) ([]byte, error) {
 	var params = createHistoryQuery(action, date, sortBy, startDate, endDate)
 
-	return client.executeHttpRequest("user/history", params, "GET")
+	return client.executeHttpRequest(ctx, "user/history", params, "GET")
 }
 
 //GetForm
 //formID (int64): Form ID is the numbers you see on a form URL. You can get form IDs when you call /user/forms.
 //Returns form ID, status, update and creation dates, submission count etc.
-func (client jotformAPIClient) GetForm(formID int64) ([]byte, error) {
-	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10), "", "GET")
+func (client Client) GetForm(formID int64) ([]byte, error) {
+	return client.GetFormContext(context.Background(), formID)
+}
+
+// GetFormContext is GetForm with a context.
+func (client Client) GetFormContext(ctx context.Context, formID int64) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "form/"+strconv.FormatInt(formID, 10), "", "GET")
 }
 
 //GetFormQuestions
 //Get a list of all questions on a form.
 //formID (int64): Form ID is the numbers you see on a form URL. You can get form IDs when you call /user/forms.
 //Returns question properties of a form.
-func (client jotformAPIClient) GetFormQuestions(formID int64) ([]byte, error) {
-	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10)+"/questions", "", "GET")
+func (client Client) GetFormQuestions(formID int64) ([]byte, error) {
+	return client.GetFormQuestionsContext(context.Background(), formID)
+}
+
+// GetFormQuestionsContext is GetFormQuestions with a context.
+func (client Client) GetFormQuestionsContext(ctx context.Context, formID int64) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "form/"+strconv.FormatInt(formID, 10)+"/questions", "", "GET")
 }
 
 //GetFormQuestion
 //formID (int64): Form ID is the numbers you see on a form URL. You can get form IDs when you call /user/forms.
 //qid (int): Identifier for each question on a form. You can get a list of question IDs from /form/{id}/questions.
 //Returns question properties like required and validation.
-func (client jotformAPIClient) GetFormQuestion(formID int64, qid int) ([]byte, error) {
-	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10)+"/question/"+strconv.Itoa(qid), "", "GET")
+func (client Client) GetFormQuestion(formID int64, qid int) ([]byte, error) {
+	return client.GetFormQuestionContext(context.Background(), formID, qid)
+}
+
+// GetFormQuestionContext is GetFormQuestion with a context.
+func (client Client) GetFormQuestionContext(ctx context.Context, formID int64, qid int) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "form/"+strconv.FormatInt(formID, 10)+"/question/"+strconv.Itoa(qid), "", "GET")
 }
 
 //GetFormSubmission
@@ -306,10 +488,15 @@ func (client jotformAPIClient) GetFormQuestion(formID int64, qid int) ([]byte, e
 //filter (map[string]string): Filters the query results to fetch a specific form range.
 //orderBy (string): Order results by a form field name.
 //Returns submissions of a specific form.
-func (client jotformAPIClient) GetFormSubmissions(formID int64, offset string, limit string, filter map[string]string, orderBy string) ([]byte, error) {
+func (client Client) GetFormSubmissions(formID int64, offset string, limit string, filter map[string]string, orderBy string) ([]byte, error) {
+	return client.GetFormSubmissionsContext(context.Background(), formID, offset, limit, filter, orderBy)
+}
+
+// GetFormSubmissionsContext is GetFormSubmissions with a context.
+func (client Client) GetFormSubmissionsContext(ctx context.Context, formID int64, offset string, limit string, filter map[string]string, orderBy string) ([]byte, error) {
 	var params = createConditions(offset, limit, filter, orderBy)
 
-	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10)+"/submissions", params, "GET")
+	return client.executeHttpRequest(ctx, "form/"+strconv.FormatInt(formID, 10)+"/submissions", params, "GET")
 }
 
 //CreateFormSubmission
@@ -317,18 +504,45 @@ func (client jotformAPIClient) GetFormSubmissions(formID int64, offset string, l
 //formID (int64): Form ID is the numbers you see on a form URL. You can get form IDs when you call /user/forms.
 //submission (map[string]string): Submission data with question IDs.
 //Returns posted submission ID and URL.
-func (client jotformAPIClient) CreateFormSubmission(formId int64, submission map[string]string) ([]byte, error) {
-	data := make(map[string]string)
+func (client Client) CreateFormSubmission(formId int64, submission map[string]string) ([]byte, error) {
+	return client.CreateFormSubmissionContext(context.Background(), formId, submission)
+}
 
-	for k, _ := range submission {
-		if strings.Contains(k, "_") {
-			data["submission["+k[0:strings.Index(k, "_")]+"]["+k[strings.Index(k, "_")+1:len(k)]+"]"] = submission[k]
-		} else {
-			data["submission["+k+"]"] = submission[k]
+// CreateFormSubmissionContext is CreateFormSubmission with a context.
+func (client Client) CreateFormSubmissionContext(ctx context.Context, formId int64, submission map[string]string) ([]byte, error) {
+	params, err := submissionParams(submission)
+	if err != nil {
+		return nil, err
+	}
+	return client.executeHttpRequest(ctx, "form/"+strconv.FormatInt(formId, 10)+"/submissions", params, "POST")
+}
+
+// submissionParams encodes answers keyed by qid, such as "3", or by qid and subfield, such as "3_first",
+// as submission[3] and submission[3][first].
+// Other keys, such as "created_at", are sent as they are.
+func submissionParams(submission map[string]string) (map[string]string, error) {
+	data := make(map[string]interface{})
+	subfields := make(map[string]map[string]string)
+
+	for k, v := range submission {
+		qid, field, ok := strings.Cut(k, "_")
+		if _, err := strconv.Atoi(qid); !ok || err != nil {
+			data[k] = v
+			continue
+		}
+		if subfields[qid] == nil {
+			subfields[qid] = make(map[string]string)
 		}
+		subfields[qid][field] = v
+	}
+	for qid, fields := range subfields {
+		if _, ok := data[qid]; ok {
+			return nil, fmt.Errorf("jotform: submission has both %s and %s_ subfields", qid, qid)
+		}
+		data[qid] = fields
 	}
 
-	return client.executeHttpRequest("form/"+strconv.FormatInt(formId, 10)+"/submissions", data, "POST")
+	return EncodeParams("submission", data)
 }
 
 //CreateFormSubmissions
@@ -336,24 +550,39 @@ func (client jotformAPIClient) CreateFormSubmission(formId int64, submission map
 //formID (int64): Form ID is the numbers you see on a form URL. You can get form IDs when you call /user/forms.
 //submission (map[string]string): Submission data with question IDs.
 //Returns posted submission ID and URL.
-func (client jotformAPIClient) CreateFormSubmissions(formId int64, submission []byte) ([]byte, error) {
-	return client.executeHttpRequest("form/"+strconv.FormatInt(formId, 10)+"/submissions", submission, "PUT")
+func (client Client) CreateFormSubmissions(formId int64, submission []byte) ([]byte, error) {
+	return client.CreateFormSubmissionsContext(context.Background(), formId, submission)
+}
+
+// CreateFormSubmissionsContext is CreateFormSubmissions with a context.
+func (client Client) CreateFormSubmissionsContext(ctx context.Context, formId int64, submission []byte) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "form/"+strconv.FormatInt(formId, 10)+"/submissions", submission, "PUT")
 }
 
 //GetFormFiles
 //List of files uploaded on a form
 //formID (int64): Form ID is the numbers you see on a form URL. You can get form IDs when you call /user/forms.
 //Returns uploaded file information and URLs on a specific form.
-func (client jotformAPIClient) GetFormFiles(formID int64) ([]byte, error) {
-	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10)+"/files", "", "GET")
+func (client Client) GetFormFiles(formID int64) ([]byte, error) {
+	return client.GetFormFilesContext(context.Background(), formID)
+}
+
+// GetFormFilesContext is GetFormFiles with a context.
+func (client Client) GetFormFilesContext(ctx context.Context, formID int64) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "form/"+strconv.FormatInt(formID, 10)+"/files", "", "GET")
 }
 
 //GetFormWebhooks
 //Get list of webhooks for a form
 //formID (int64): Form ID is the numbers you see on a form URL. You can get form IDs when you call /user/forms.
 //Returns list of webhooks for a specific form.
-func (client jotformAPIClient) GetFormWebhooks(formID int64) ([]byte, error) {
-	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10)+"/webhooks", "", "GET")
+func (client Client) GetFormWebhooks(formID int64) ([]byte, error) {
+	return client.GetFormWebhooksContext(context.Background(), formID)
+}
+
+// GetFormWebhooksContext is GetFormWebhooks with a context.
+func (client Client) GetFormWebhooksContext(ctx context.Context, formID int64) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "form/"+strconv.FormatInt(formID, 10)+"/webhooks", "", "GET")
 }
 
 //CreateFormWebhook
@@ -361,12 +590,17 @@ func (client jotformAPIClient) GetFormWebhooks(formID int64) ([]byte, error) {
 //formID (int64): Form ID is the numbers you see on a form URL. You can get form IDs when you call /user/forms.
 //webhookURL (string): Webhook URL is where form data will be posted when form is submitted.
 //Returns list of webhooks for a specific form.
-func (client jotformAPIClient) CreateFormWebhook(formId int64, webhookURL string) ([]byte, error) {
+func (client Client) CreateFormWebhook(formId int64, webhookURL string) ([]byte, error) {
+	return client.CreateFormWebhookContext(context.Background(), formId, webhookURL)
+}
+
+// CreateFormWebhookContext is CreateFormWebhook with a context.
+func (client Client) CreateFormWebhookContext(ctx context.Context, formId int64, webhookURL string) ([]byte, error) {
 	params := map[string]string{
 		"webhookURL": webhookURL,
 	}
 
-	return client.executeHttpRequest("form/"+strconv.FormatInt(formId, 10)+"/webhooks", params, "POST")
+	return client.executeHttpRequest(ctx, "form/"+strconv.FormatInt(formId, 10)+"/webhooks", params, "POST")
 }
 
 //Delete a specific webhook of a form.
@@ -374,47 +608,147 @@ func (client jotformAPIClient) CreateFormWebhook(formId int64, webhookURL string
 //formID (int64): Form ID is the numbers you see on a form URL. You can get form IDs when you call /user/forms.
 //webhookID (int64): You can get webhook IDs when you call /form/{formID}/webhooks.
 //Returns remaining webhook URLs of form.
-func (client jotformAPIClient) DeleteFormWebhook(formID int64, webhookID int64) ([]byte, error) {
-	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10)+"/webhooks/"+strconv.FormatInt(webhookID, 10), nil, "DELETE")
+func (client Client) DeleteFormWebhook(formID int64, webhookID int64) ([]byte, error) {
+	return client.DeleteFormWebhookContext(context.Background(), formID, webhookID)
+}
+
+// DeleteFormWebhookContext is DeleteFormWebhook with a context.
+func (client Client) DeleteFormWebhookContext(ctx context.Context, formID int64, webhookID int64) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "form/"+strconv.FormatInt(formID, 10)+"/webhooks/"+strconv.FormatInt(webhookID, 10), nil, "DELETE")
 }
 
 //GetSubmission
 //Get submission data
 //sid (int64): You can get submission IDs when you call /form/{id}/submissions.
 //Returns information and answers of a specific submission.
-func (client jotformAPIClient) GetSubmission(sid int64) ([]byte, error) {
-	return client.executeHttpRequest("user/submission/"+strconv.FormatInt(sid, 10), "", "GET")
+func (client Client) GetSubmission(sid int64) ([]byte, error) {
+	return client.GetSubmissionContext(context.Background(), sid)
+}
+
+// GetSubmissionContext is GetSubmission with a context.
+func (client Client) GetSubmissionContext(ctx context.Context, sid int64) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "user/submission/"+strconv.FormatInt(sid, 10), "", "GET")
 }
 
 //GetReport
 //Get report details
 //reportID (int64): You can get a list of reports from /user/reports.
 //Returns properties of a speceific report like fields and status.
-func (client jotformAPIClient) GetReport(reportID int64) ([]byte, error) {
-	return client.executeHttpRequest("user/report/"+strconv.FormatInt(reportID, 10), "", "GET")
+func (client Client) GetReport(reportID int64) ([]byte, error) {
+	return client.GetReportContext(context.Background(), reportID)
+}
+
+// GetReportContext is GetReport with a context.
+func (client Client) GetReportContext(ctx context.Context, reportID int64) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "user/report/"+strconv.FormatInt(reportID, 10), "", "GET")
 }
 
 //GetFolder
 //folderID (int64): You can get a list of folders from /user/folders.
 //Returns a list of forms in a folder, and other details about the form such as folder color.
-func (client jotformAPIClient) GetFolder(folderID string) ([]byte, error) {
-	return client.executeHttpRequest("folder/"+folderID, "", "GET")
+func (client Client) GetFolder(folderID string) ([]byte, error) {
+	return client.GetFolderContext(context.Background(), folderID)
+}
+
+// GetFolderContext is GetFolder with a context.
+func (client Client) GetFolderContext(ctx context.Context, folderID string) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "folder/"+folderID, "", "GET")
+}
+
+//CreateFolder
+//folderProperties (map[string]string): Properties of new folder, such as name, color and parent.
+//Returns folder details.
+func (client Client) CreateFolder(folderProperties map[string]string) ([]byte, error) {
+	return client.CreateFolderContext(context.Background(), folderProperties)
+}
+
+// CreateFolderContext is CreateFolder with a context.
+func (client Client) CreateFolderContext(ctx context.Context, folderProperties map[string]string) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "folder", folderProperties, "POST")
+}
+
+//DeleteFolder
+//folderID (string): You can get the list of folders from /user/folders.
+//Returns status of the request.
+func (client Client) DeleteFolder(folderID string) ([]byte, error) {
+	return client.DeleteFolderContext(context.Background(), folderID)
+}
+
+// DeleteFolderContext is DeleteFolder with a context.
+func (client Client) DeleteFolderContext(ctx context.Context, folderID string) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "folder/"+folderID, nil, "DELETE")
+}
+
+//UpdateFolder
+//folderID (string): You can get the list of folders from /user/folders.
+//folderProperties ([]byte): Properties of folder in JSON, such as name, color, parent and forms.
+//Returns folder details.
+func (client Client) UpdateFolder(folderID string, folderProperties []byte) ([]byte, error) {
+	return client.UpdateFolderContext(context.Background(), folderID, folderProperties)
+}
+
+// UpdateFolderContext is UpdateFolder with a context.
+func (client Client) UpdateFolderContext(ctx context.Context, folderID string, folderProperties []byte) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "folder/"+folderID, folderProperties, "PUT")
+}
+
+//AddFormsToFolder
+//folderID (string): You can get the list of folders from /user/folders.
+//formIDs ([]string): You can get the list of forms from /user/forms.
+//Returns folder details.
+func (client Client) AddFormsToFolder(folderID string, formIDs []string) ([]byte, error) {
+	return client.AddFormsToFolderContext(context.Background(), folderID, formIDs)
+}
+
+// AddFormsToFolderContext is AddFormsToFolder with a context.
+func (client Client) AddFormsToFolderContext(ctx context.Context, folderID string, formIDs []string) ([]byte, error) {
+	formattedFormIDs, err := json.Marshal(map[string][]string{
+		"forms": formIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return client.UpdateFolderContext(ctx, folderID, formattedFormIDs)
+}
+
+//AddFormToFolder
+//folderID (string): You can get a list of folders from /user/folders.
+//formID (string): You can get the list of forms from /user/forms.
+//Returns folder details.
+func (client Client) AddFormToFolder(folderID string, formID string) ([]byte, error) {
+	return client.AddFormToFolderContext(context.Background(), folderID, formID)
+}
+
+// AddFormToFolderContext is AddFormToFolder with a context.
+func (client Client) AddFormToFolderContext(ctx context.Context, folderID string, formID string) ([]byte, error) {
+	return client.AddFormsToFolderContext(ctx, folderID, []string{formID})
 }
 
 //GetFormProperties
 //Get a list of all properties on a form
 //formID (int64): Form ID is the numbers you see on a form URL. You can get form IDs when you call /user/forms.
 //Returns form properties like width, expiration date, style etc.
-func (client jotformAPIClient) GetFormProperties(formID int64) ([]byte, error) {
-	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10)+"/properties", "", "GET")
+func (client Client) GetFormProperties(formID int64) ([]byte, error) {
+	return client.GetFormPropertiesContext(context.Background(), formID)
+}
+
+// GetFormPropertiesContext is GetFormProperties with a context.
+func (client Client) GetFormPropertiesContext(ctx context.Context, formID int64) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "form/"+strconv.FormatInt(formID, 10)+"/properties", "", "GET")
 }
 
 //GetFormReports
 //Get all the reports of a form, such as excel, csv, grid, html, etc.
 //formID (int64): Form ID is the numbers you see on a form URL. You can get form IDs when you call /user/forms.
 //Returns list of all reports in a form, and other details about the reports such as title.
-func (client jotformAPIClient) GetFormReports(formID int64) ([]byte, error) {
-	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10)+"/reports", "", "GET")
+func (client Client) GetFormReports(formID int64) ([]byte, error) {
+	return client.GetFormReportsContext(context.Background(), formID)
+}
+
+// GetFormReportsContext is GetFormReports with a context.
+func (client Client) GetFormReportsContext(ctx context.Context, formID int64) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "form/"+strconv.FormatInt(formID, 10)+"/reports", "", "GET")
 }
 
 //CreateReport
@@ -422,8 +756,13 @@ func (client jotformAPIClient) GetFormReports(formID int64) ([]byte, error) {
 //formID (int64): Form ID is the numbers you see on a form URL. You can get form IDs when you call /user/forms.
 //report (map[string]string): Report details. List type, title etc.
 //Returns report details and URL.
-func (client jotformAPIClient) CreateReport(formID int64, report map[string]string) ([]byte, error) {
-	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10)+"/reports", report, "POST")
+func (client Client) CreateReport(formID int64, report map[string]string) ([]byte, error) {
+	return client.CreateReportContext(context.Background(), formID, report)
+}
+
+// CreateReportContext is CreateReport with a context.
+func (client Client) CreateReportContext(ctx context.Context, formID int64, report map[string]string) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "form/"+strconv.FormatInt(formID, 10)+"/reports", report, "POST")
 }
 
 //GetFormProperty
@@ -431,16 +770,26 @@ func (client jotformAPIClient) CreateReport(formID int64, report map[string]stri
 //formID (int64): Form ID is the numbers you see on a form URL. You can get form IDs when you call /user/forms.
 //propertyKey (string): You can get property keys when you call /form/{id}/properties.
 //Returns given property key value.
-func (client jotformAPIClient) GetFormProperty(formID int64, propertyKey string) ([]byte, error) {
-	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10)+"/properties/"+propertyKey, "", "POST")
+func (client Client) GetFormProperty(formID int64, propertyKey string) ([]byte, error) {
+	return client.GetFormPropertyContext(context.Background(), formID, propertyKey)
+}
+
+// GetFormPropertyContext is GetFormProperty with a context.
+func (client Client) GetFormPropertyContext(ctx context.Context, formID int64, propertyKey string) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "form/"+strconv.FormatInt(formID, 10)+"/properties/"+propertyKey, "", "POST")
 }
 
 //DeleteSubmission
 //Delete a single submission
 //sid (int64): You can get submission IDs when you call /form/{id}/submissions.
 //Returns status of request.
-func (client jotformAPIClient) DeleteSubmission(sid int64) ([]byte, error) {
-	return client.executeHttpRequest("submission/"+strconv.FormatInt(sid, 10), nil, "DELETE")
+func (client Client) DeleteSubmission(sid int64) ([]byte, error) {
+	return client.DeleteSubmissionContext(context.Background(), sid)
+}
+
+// DeleteSubmissionContext is DeleteSubmission with a context.
+func (client Client) DeleteSubmissionContext(ctx context.Context, sid int64) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "submission/"+strconv.FormatInt(sid, 10), nil, "DELETE")
 }
 
 //EditSubmission
@@ -448,34 +797,43 @@ func (client jotformAPIClient) DeleteSubmission(sid int64) ([]byte, error) {
 //sid (int64): You can get submission IDs when you call /form/{id}/submissions.
 //submission (map[string]string): New submission data with question IDs.
 //Returns status of request.
-func (client jotformAPIClient) EditSubmission(sid int64, submission map[string]string) ([]byte, error) {
-	data := make(map[string]string)
+func (client Client) EditSubmission(sid int64, submission map[string]string) ([]byte, error) {
+	return client.EditSubmissionContext(context.Background(), sid, submission)
+}
 
-	for k, _ := range submission {
-		if strings.Contains(k, "_") && k != "created_at" {
-			data["submission["+k[0:strings.Index(k, "_")]+"]["+k[strings.Index(k, "_")+1:len(k)]+"]"] = submission[k]
-		} else {
-			data["submission["+k+"]"] = submission[k]
-		}
+// EditSubmissionContext is EditSubmission with a context.
+func (client Client) EditSubmissionContext(ctx context.Context, sid int64, submission map[string]string) ([]byte, error) {
+	params, err := submissionParams(submission)
+	if err != nil {
+		return nil, err
 	}
-
-	return client.executeHttpRequest("submission/"+strconv.FormatInt(sid, 10), data, "POST")
+	return client.executeHttpRequest(ctx, "submission/"+strconv.FormatInt(sid, 10), params, "POST")
 }
 
 //CloneForm
 //Clone a single form.
 //formID (int64): Form ID is the numbers you see on a form URL. You can get form IDs when you call /user/forms.
 //Returns status of request.
-func (client jotformAPIClient) CloneForm(formID int64) ([]byte, error) {
-	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10)+"/clone", nil, "POST")
+func (client Client) CloneForm(formID int64) ([]byte, error) {
+	return client.CloneFormContext(context.Background(), formID)
+}
+
+// CloneFormContext is CloneForm with a context.
+func (client Client) CloneFormContext(ctx context.Context, formID int64) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "form/"+strconv.FormatInt(formID, 10)+"/clone", nil, "POST")
 }
 
 //DeleteFormQuestion
 //formID (int64): Form ID is the numbers you see on a form URL. You can get form IDs when you call /user/forms.
 //qid (int): Identifier for each question on a form. You can get a list of question IDs from /form/{id}/questions.
 //Returns status of request.
-func (client jotformAPIClient) DeleteFormQuestion(formID int64, qid int) ([]byte, error) {
-	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10)+"/question/"+strconv.Itoa(qid), nil, "DELETE")
+func (client Client) DeleteFormQuestion(formID int64, qid int) ([]byte, error) {
+	return client.DeleteFormQuestionContext(context.Background(), formID, qid)
+}
+
+// DeleteFormQuestionContext is DeleteFormQuestion with a context.
+func (client Client) DeleteFormQuestionContext(ctx context.Context, formID int64, qid int) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "form/"+strconv.FormatInt(formID, 10)+"/question/"+strconv.Itoa(qid), nil, "DELETE")
 }
 
 //CreateFormQuestion
@@ -483,14 +841,17 @@ func (client jotformAPIClient) DeleteFormQuestion(formID int64, qid int) ([]byte
 //formID (int64): Form ID is the numbers you see on a form URL. You can get form IDs when you call /user/forms.
 //questionProperties (map[string]string): New question properties like type and text.
 //Returns properties of new question.
-func (client jotformAPIClient) CreateFormQuestion(formID int64, questionProperties map[string]string) ([]byte, error) {
-	question := make(map[string]string)
+func (client Client) CreateFormQuestion(formID int64, questionProperties map[string]string) ([]byte, error) {
+	return client.CreateFormQuestionContext(context.Background(), formID, questionProperties)
+}
 
-	for k, _ := range questionProperties {
-		question["question["+k+"]"] = questionProperties[k]
+// CreateFormQuestionContext is CreateFormQuestion with a context.
+func (client Client) CreateFormQuestionContext(ctx context.Context, formID int64, questionProperties map[string]string) ([]byte, error) {
+	question, err := EncodeParams("question", questionProperties)
+	if err != nil {
+		return nil, err
 	}
-
-	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10)+"/questions", question, "POST")
+	return client.executeHttpRequest(ctx, "form/"+strconv.FormatInt(formID, 10)+"/questions", question, "POST")
 }
 
 //CreateFormQuestion
@@ -498,8 +859,13 @@ func (client jotformAPIClient) CreateFormQuestion(formID int64, questionProperti
 //formID (int64): Form ID is the numbers you see on a form URL. You can get form IDs when you call /user/forms.
 //questions ([]byte): New question properties like type and text.
 //Returns properties of new question.
-func (client jotformAPIClient) CreateFormQuestions(formID int64, questions []byte) ([]byte, error) {
-	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10)+"/questions", questions, "PUT")
+func (client Client) CreateFormQuestions(formID int64, questions []byte) ([]byte, error) {
+	return client.CreateFormQuestionsContext(context.Background(), formID, questions)
+}
+
+// CreateFormQuestionsContext is CreateFormQuestions with a context.
+func (client Client) CreateFormQuestionsContext(ctx context.Context, formID int64, questions []byte) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "form/"+strconv.FormatInt(formID, 10)+"/questions", questions, "PUT")
 }
 
 //EditFormQuestion
@@ -508,14 +874,17 @@ func (client jotformAPIClient) CreateFormQuestions(formID int64, questions []byt
 //qid (int): Identifier for each question on a form. You can get a list of question IDs from /form/{id}/questions.
 //questionProperties (map[string]string): New question properties like type and text.
 //Returns edited property and type of question.
-func (client jotformAPIClient) EditFormQuestion(formID int64, qid int, questionProperties map[string]string) ([]byte, error) {
-	question := make(map[string]string)
+func (client Client) EditFormQuestion(formID int64, qid int, questionProperties map[string]string) ([]byte, error) {
+	return client.EditFormQuestionContext(context.Background(), formID, qid, questionProperties)
+}
 
-	for k, _ := range questionProperties {
-		question["question["+k+"]"] = questionProperties[k]
+// EditFormQuestionContext is EditFormQuestion with a context.
+func (client Client) EditFormQuestionContext(ctx context.Context, formID int64, qid int, questionProperties map[string]string) ([]byte, error) {
+	question, err := EncodeParams("question", questionProperties)
+	if err != nil {
+		return nil, err
 	}
-
-	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10)+"/question/"+strconv.Itoa(qid), question, "POST")
+	return client.executeHttpRequest(ctx, "form/"+strconv.FormatInt(formID, 10)+"/question/"+strconv.Itoa(qid), question, "POST")
 }
 
 //SetFormProperties
@@ -523,14 +892,17 @@ func (client jotformAPIClient) EditFormQuestion(formID int64, qid int, questionP
 //formID (int64): Form ID is the numbers you see on a form URL. You can get form IDs when you call /user/forms.
 //formProperties (map[string]string): New properties like label width.
 //Returns edited properties.
-func (client jotformAPIClient) SetFormProperties(formID int64, formProperties map[string]string) ([]byte, error) {
-	properties := make(map[string]string)
+func (client Client) SetFormProperties(formID int64, formProperties map[string]string) ([]byte, error) {
+	return client.SetFormPropertiesContext(context.Background(), formID, formProperties)
+}
 
-	for k, _ := range formProperties {
-		properties["properties["+k+"]"] = formProperties[k]
+// SetFormPropertiesContext is SetFormProperties with a context.
+func (client Client) SetFormPropertiesContext(ctx context.Context, formID int64, formProperties map[string]string) ([]byte, error) {
+	properties, err := EncodeParams("properties", formProperties)
+	if err != nil {
+		return nil, err
 	}
-
-	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10)+"/properties", properties, "POST")
+	return client.executeHttpRequest(ctx, "form/"+strconv.FormatInt(formID, 10)+"/properties", properties, "POST")
 }
 
 //SetFormProperties
@@ -538,89 +910,118 @@ func (client jotformAPIClient) SetFormProperties(formID int64, formProperties ma
 //formID (int64): Form ID is the numbers you see on a form URL. You can get form IDs when you call /user/forms.
 //formProperties ([]byte): New properties like label width.
 //Returns edited properties.
-func (client jotformAPIClient) SetMultipleFormProperties(formID int64, formProperties []byte) ([]byte, error) {
-	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10)+"/properties", formProperties, "PUT")
+func (client Client) SetMultipleFormProperties(formID int64, formProperties []byte) ([]byte, error) {
+	return client.SetMultipleFormPropertiesContext(context.Background(), formID, formProperties)
+}
+
+// SetMultipleFormPropertiesContext is SetMultipleFormProperties with a context.
+func (client Client) SetMultipleFormPropertiesContext(ctx context.Context, formID int64, formProperties []byte) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "form/"+strconv.FormatInt(formID, 10)+"/properties", formProperties, "PUT")
 }
 
 //CreateForm
 //Create a new form
 //form ([]byte): Questions, properties and emails of new form.
 //Returns new form.
-func (client jotformAPIClient) CreateForm(form map[string]interface{}) ([]byte, error) {
-	params := make(map[string]string)
-
-	for formKey, formValue := range form {
-		if formKey == "properties" {
-			properties := formValue
-
-			for properyKey, propertyValue := range properties.(map[string]string) {
-				params[formKey+"["+properyKey+"]"] = propertyValue
-			}
-		} else {
-			formItem := formValue
-
-			for formItemKey, formItemValue := range formItem.(map[string]interface{}) {
-				item := formItemValue
+//The form is encoded with EncodeParams, eg. {"properties": {"title": ...}, "questions": {"1": {"type": ...}}}.
+//CreateFormFromBuilder creates a form built with a typed FormBuilder instead.
+func (client Client) CreateForm(form map[string]interface{}) ([]byte, error) {
+	return client.CreateFormContext(context.Background(), form)
+}
 
-				for itemKey, itemValue := range item.(map[string]string) {
-					params[formKey+"["+formItemKey+"]["+itemKey+"]"] = itemValue
-				}
-			}
-		}
+// CreateFormContext is CreateForm with a context.
+func (client Client) CreateFormContext(ctx context.Context, form map[string]interface{}) ([]byte, error) {
+	params, err := EncodeParams("", form)
+	if err != nil {
+		return nil, err
 	}
-
-	return client.executeHttpRequest("user/forms", params, "POST")
+	return client.executeHttpRequest(ctx, "user/forms", params, "POST")
 }
 
 //Create new forms
 //Create a new form
 //form ([]byte): Questions, properties and emails of forms.
 //Returns new forms.
-func (client jotformAPIClient) CreateForms(form []byte) ([]byte, error) {
-	return client.executeHttpRequest("user/forms", form, "PUT")
+func (client Client) CreateForms(form []byte) ([]byte, error) {
+	return client.CreateFormsContext(context.Background(), form)
+}
+
+// CreateFormsContext is CreateForms with a context.
+func (client Client) CreateFormsContext(ctx context.Context, form []byte) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "user/forms", form, "PUT")
 }
 
 //DeleteForm
 //formID (int64): Form ID is the numbers you see on a form URL. You can get form IDs when you call /user/forms.
 //Returns properties of deleted form.
-func (client jotformAPIClient) DeleteForm(formID int64) ([]byte, error) {
-	return client.executeHttpRequest("form/"+strconv.FormatInt(formID, 10), nil, "DELETE")
+func (client Client) DeleteForm(formID int64) ([]byte, error) {
+	return client.DeleteFormContext(context.Background(), formID)
+}
+
+// DeleteFormContext is DeleteForm with a context.
+func (client Client) DeleteFormContext(ctx context.Context, formID int64) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "form/"+strconv.FormatInt(formID, 10), nil, "DELETE")
 }
 
 //RegisterUser
 //Register with username, password and email
 //userDetails (map[string]string): Username, password and email to register a new user
 //Returns new user's details
-func (client jotformAPIClient) RegisterUser(userDetails map[string]string) ([]byte, error) {
-	return client.executeHttpRequest("user/register", userDetails, "POST")
+func (client Client) RegisterUser(userDetails map[string]string) ([]byte, error) {
+	return client.RegisterUserContext(context.Background(), userDetails)
+}
+
+// RegisterUserContext is RegisterUser with a context.
+func (client Client) RegisterUserContext(ctx context.Context, userDetails map[string]string) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "user/register", userDetails, "POST")
 }
 
 //LoginUser
 //Login user with given credentials
 //credentials (map[string]string): Username, password, application name and access type of user
 //Returns logged in user's settings and app key
-func (client jotformAPIClient) LoginUser(credentials map[string]string) ([]byte, error) {
-	return client.executeHttpRequest("user/login", credentials, "POST")
+func (client Client) LoginUser(credentials map[string]string) ([]byte, error) {
+	return client.LoginUserContext(context.Background(), credentials)
+}
+
+// LoginUserContext is LoginUser with a context.
+func (client Client) LoginUserContext(ctx context.Context, credentials map[string]string) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "user/login", credentials, "POST")
 }
 
 //LogoutUser
 //Logout user
 //Returns status of request
-func (client jotformAPIClient) LogoutUser() ([]byte, error) {
-	return client.executeHttpRequest("user/logout", "", "GET")
+func (client Client) LogoutUser() ([]byte, error) {
+	return client.LogoutUserContext(context.Background())
+}
+
+// LogoutUserContext is LogoutUser with a context.
+func (client Client) LogoutUserContext(ctx context.Context) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "user/logout", "", "GET")
 }
 
 //GetPlan
 //Get details of a plan
 //planName (string): Name of the requested plan. FREE, PREMIUM etc.
 //Returns details of a plan
-func (client jotformAPIClient) GetPlan(planName string) ([]byte, error) {
-	return client.executeHttpRequest("system/plan/"+planName, "", "GET")
+func (client Client) GetPlan(planName string) ([]byte, error) {
+	return client.GetPlanContext(context.Background(), planName)
+}
+
+// GetPlanContext is GetPlan with a context.
+func (client Client) GetPlanContext(ctx context.Context, planName string) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "system/plan/"+planName, "", "GET")
 }
 
 //DeleteReport
 //reportID (int64): You can get a list of reports from /user/reports.
 //Returns status of request.
-func (client jotformAPIClient) DeleteReport(reportID int64) ([]byte, error) {
-	return client.executeHttpRequest("report/"+strconv.FormatInt(reportID, 10), nil, "DELETE")
+func (client Client) DeleteReport(reportID int64) ([]byte, error) {
+	return client.DeleteReportContext(context.Background(), reportID)
+}
+
+// DeleteReportContext is DeleteReport with a context.
+func (client Client) DeleteReportContext(ctx context.Context, reportID int64) ([]byte, error) {
+	return client.executeHttpRequest(ctx, "report/"+strconv.FormatInt(reportID, 10), nil, "DELETE")
 }
@@ -0,0 +1,392 @@
+package jotform
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FullName is the answer to a control_fullname question.
+type FullName struct {
+	Prefix string `json:"prefix"`
+	First  string `json:"first"`
+	Middle string `json:"middle"`
+	Last   string `json:"last"`
+	Suffix string `json:"suffix"`
+}
+
+// String joins the non-empty parts of the name.
+func (n FullName) String() string {
+	return joinNonEmpty(" ", n.Prefix, n.First, n.Middle, n.Last, n.Suffix)
+}
+
+// Address is the answer to a control_address question.
+type Address struct {
+	Line1   string `json:"addr_line1"`
+	Line2   string `json:"addr_line2"`
+	City    string `json:"city"`
+	State   string `json:"state"`
+	Postal  string `json:"postal"`
+	Country string `json:"country"`
+}
+
+// String joins the non-empty parts of the address.
+func (a Address) String() string {
+	return joinNonEmpty(", ", a.Line1, a.Line2, a.City, a.State, a.Postal, a.Country)
+}
+
+// DateTime is the answer to a control_datetime or control_birthdate question.
+// The parts are as submitted; Time is set when they form a valid date.
+type DateTime struct {
+	Year   string `json:"year"`
+	Month  string `json:"month"`
+	Day    string `json:"day"`
+	Hour   string `json:"hour"`
+	Minute string `json:"min"`
+	AMPM   string `json:"ampm"`
+	// Time isn't decoded from the answer, whose "time" is the time of day as text, eg. "03:30 PM".
+	Time time.Time `json:"-"`
+}
+
+// Matrix is the answer to a control_matrix question:
+// the selected columns, keyed by row.
+type Matrix map[string][]string
+
+// PaymentItems is the answer to a payment question, such as control_stripe.
+type PaymentItems struct {
+	// Products describes each product bought, as formatted by JotForm,
+	// eg. "T-Shirt (Amount: 10.00 USD, Quantity: 2)".
+	Products []string
+	Currency string
+	Total    string
+}
+
+// AnswerDecoder decodes the raw answer to a question into a typed value.
+type AnswerDecoder func(raw json.RawMessage) (interface{}, error)
+
+// DecodedAnswer is an answer decoded according to its question's type.
+type DecodedAnswer struct {
+	QID      string
+	Question Question
+	// Value is the decoded answer: eg. a string, []string, FullName,
+	// Address, DateTime, Matrix or PaymentItems, depending on Question.Type.
+	// It is nil if the question was not answered.
+	Value interface{}
+}
+
+// AnswerRegistry maps question types to the AnswerDecoder for their answers.
+type AnswerRegistry struct {
+	mu       sync.RWMutex
+	decoders map[string]AnswerDecoder
+}
+
+// DefaultAnswerRegistry is the registry used by DecodeAnswers.
+var DefaultAnswerRegistry = NewAnswerRegistry()
+
+// NewAnswerRegistry returns a registry with decoders for JotForm's standard question types.
+func NewAnswerRegistry() *AnswerRegistry {
+	r := &AnswerRegistry{decoders: make(map[string]AnswerDecoder)}
+
+	for _, questionType := range []string{
+		"control_textbox", "control_textarea", "control_email", "control_dropdown",
+		"control_radio", "control_number", "control_spinner", "control_autocomp",
+		"control_hidden", "control_autoincrement", "control_scale", "control_rating",
+		"control_signature",
+	} {
+		r.Register(questionType, decodeString)
+	}
+	r.Register("control_checkbox", decodeStrings)
+	r.Register("control_fileupload", decodeStrings)
+	r.Register("control_fullname", decodeFullName)
+	r.Register("control_address", decodeAddress)
+	r.Register("control_datetime", decodeDateTime)
+	r.Register("control_birthdate", decodeDateTime)
+	r.Register("control_matrix", decodeMatrix)
+	for _, questionType := range []string{
+		"control_payment", "control_paypal", "control_paypalpro", "control_stripe",
+		"control_square", "control_authnet", "control_braintree",
+	} {
+		r.Register(questionType, decodePaymentItems)
+	}
+
+	return r
+}
+
+// Register sets the decoder for answers to questions of questionType,
+// replacing any existing decoder.
+func (r *AnswerRegistry) Register(questionType string, decoder AnswerDecoder) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.decoders[questionType] = decoder
+}
+
+// RegisterAnswerDecoder registers decoder with DefaultAnswerRegistry.
+func RegisterAnswerDecoder(questionType string, decoder AnswerDecoder) {
+	DefaultAnswerRegistry.Register(questionType, decoder)
+}
+
+// Decode decodes an answer to a question of questionType.
+// Answers to unknown types are decoded as a string or []string if possible,
+// and left as json.RawMessage otherwise.
+// Empty answers decode to nil.
+func (r *AnswerRegistry) Decode(questionType string, raw json.RawMessage) (interface{}, error) {
+	if isEmptyAnswer(raw) {
+		return nil, nil
+	}
+
+	r.mu.RLock()
+	decoder, ok := r.decoders[questionType]
+	r.mu.RUnlock()
+
+	if ok {
+		value, err := decoder(raw)
+		if err != nil {
+			return nil, fmt.Errorf("jotform: decoding %s answer: %w", questionType, err)
+		}
+		return value, nil
+	}
+
+	if value, err := decodeString(raw); err == nil {
+		return value, nil
+	}
+	if value, err := decodeStrings(raw); err == nil {
+		return value, nil
+	}
+	return raw, nil
+}
+
+// DecodeAnswers decodes every answer of submission,
+// using the types of questions, as returned by GetFormQuestionsTyped.
+// Answers to questions missing from questions are decoded by the type in the answer.
+func (r *AnswerRegistry) DecodeAnswers(submission Submission, questions []Question) (map[string]DecodedAnswer, error) {
+	byID := make(map[string]Question, len(questions))
+	for _, question := range questions {
+		byID[strconv.FormatInt(int64(question.QID), 10)] = question
+	}
+
+	decoded := make(map[string]DecodedAnswer, len(submission.Answers))
+	for qid, answer := range submission.Answers {
+		question, ok := byID[qid]
+		if !ok {
+			n, _ := strconv.ParseInt(qid, 10, 64)
+			question = Question{
+				QID:   Int(n),
+				Type:  answer.Type,
+				Name:  answer.Name,
+				Text:  answer.Text,
+				Order: answer.Order,
+			}
+		}
+
+		value, err := r.Decode(question.Type, answer.Answer)
+		if err != nil {
+			return nil, fmt.Errorf("question %s: %w", qid, err)
+		}
+		decoded[qid] = DecodedAnswer{QID: qid, Question: question, Value: value}
+	}
+	return decoded, nil
+}
+
+// DecodeAnswers decodes every answer of submission with DefaultAnswerRegistry.
+func DecodeAnswers(submission Submission, questions []Question) (map[string]DecodedAnswer, error) {
+	return DefaultAnswerRegistry.DecodeAnswers(submission, questions)
+}
+
+func isEmptyAnswer(raw json.RawMessage) bool {
+	trimmed := bytes.TrimSpace(raw)
+	switch string(trimmed) {
+	case "", "null", `""`, "[]", "{}":
+		return true
+	}
+	return false
+}
+
+func decodeString(raw json.RawMessage) (interface{}, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// decodeStrings decodes a list answer, which JotForm sends as an array,
+// or as a single string when only one item was chosen.
+func decodeStrings(raw json.RawMessage) (interface{}, error) {
+	var list []string
+	if err := json.Unmarshal(raw, &list); err == nil {
+		return list, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, err
+	}
+	return []string{s}, nil
+}
+
+func decodeFullName(raw json.RawMessage) (interface{}, error) {
+	var name FullName
+	if err := json.Unmarshal(raw, &name); err != nil {
+		return nil, err
+	}
+	return name, nil
+}
+
+func decodeAddress(raw json.RawMessage) (interface{}, error) {
+	var address Address
+	if err := json.Unmarshal(raw, &address); err != nil {
+		return nil, err
+	}
+	return address, nil
+}
+
+func decodeDateTime(raw json.RawMessage) (interface{}, error) {
+	var fields struct {
+		DateTime
+		Combined string `json:"datetime"`
+	}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+
+	dt := fields.DateTime
+	if fields.Combined != "" {
+		var t Time
+		if err := t.parse(fields.Combined); err == nil {
+			dt.Time = t.Time
+			return dt, nil
+		}
+	}
+	dt.Time = dt.parts()
+	return dt, nil
+}
+
+// parts returns the time formed by the parts of dt, or the zero time if they don't form one.
+func (dt DateTime) parts() time.Time {
+	year, err := strconv.Atoi(dt.Year)
+	if err != nil {
+		return time.Time{}
+	}
+	month, err := strconv.Atoi(dt.Month)
+	if err != nil {
+		parsed, err := time.Parse("January", dt.Month)
+		if err != nil {
+			return time.Time{}
+		}
+		month = int(parsed.Month())
+	}
+	day, err := strconv.Atoi(dt.Day)
+	if err != nil {
+		return time.Time{}
+	}
+
+	hour, _ := strconv.Atoi(dt.Hour)
+	minute, _ := strconv.Atoi(dt.Minute)
+	switch strings.ToUpper(dt.AMPM) {
+	case "AM":
+		if hour == 12 {
+			hour = 0
+		}
+	case "PM":
+		if hour < 12 {
+			hour += 12
+		}
+	}
+
+	return time.Date(year, time.Month(month), day, hour, minute, 0, 0, TimeLocation)
+}
+
+func decodeMatrix(raw json.RawMessage) (interface{}, error) {
+	// Some matrix answers arrive as JSON encoded within a string.
+	var encoded string
+	if err := json.Unmarshal(raw, &encoded); err == nil {
+		raw = json.RawMessage(encoded)
+	}
+
+	var rows map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &rows); err != nil {
+		return nil, err
+	}
+
+	matrix := make(Matrix, len(rows))
+	for row, value := range rows {
+		if isEmptyAnswer(value) {
+			matrix[row] = nil
+			continue
+		}
+		columns, err := decodeStrings(value)
+		if err != nil {
+			return nil, fmt.Errorf("row %q: %w", row, err)
+		}
+		matrix[row] = columns.([]string)
+	}
+	return matrix, nil
+}
+
+func decodePaymentItems(raw json.RawMessage) (interface{}, error) {
+	var answer struct {
+		PaymentArray string `json:"paymentArray"`
+	}
+	if err := json.Unmarshal(raw, &answer); err != nil {
+		return nil, err
+	}
+	if answer.PaymentArray == "" {
+		return PaymentItems{}, nil
+	}
+
+	var payment struct {
+		Product  json.RawMessage `json:"product"`
+		Currency string          `json:"currency"`
+		Total    json.RawMessage `json:"total"`
+	}
+	if err := json.Unmarshal([]byte(answer.PaymentArray), &payment); err != nil {
+		return nil, err
+	}
+
+	items := PaymentItems{Currency: payment.Currency}
+	if !isEmptyAnswer(payment.Product) {
+		products, err := decodeStrings(payment.Product)
+		if err != nil {
+			return nil, err
+		}
+		items.Products = products.([]string)
+	}
+	if !isEmptyAnswer(payment.Total) {
+		// The total is usually a string such as "10.00", but may be a number.
+		if err := json.Unmarshal(payment.Total, &items.Total); err != nil {
+			items.Total = string(bytes.TrimSpace(payment.Total))
+		}
+	}
+	return items, nil
+}
+
+// SortedAnswers returns the decoded answers in the order of their questions on the form.
+func SortedAnswers(answers map[string]DecodedAnswer) []DecodedAnswer {
+	sorted := make([]DecodedAnswer, 0, len(answers))
+	for _, answer := range answers {
+		sorted = append(sorted, answer)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Question.Order != sorted[j].Question.Order {
+			return sorted[i].Question.Order < sorted[j].Question.Order
+		}
+		return sorted[i].Question.QID < sorted[j].Question.QID
+	})
+	return sorted
+}
+
+func joinNonEmpty(sep string, parts ...string) string {
+	var nonEmpty []string
+	for _, part := range parts {
+		if part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	return strings.Join(nonEmpty, sep)
+}
@@ -0,0 +1,123 @@
+package jotform_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	jotform "github.com/jotform/jotform-api-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+const answersSubmission = `{
+	"id": "237955080346633702",
+	"form_id": "31751954731962",
+	"answers": {
+		"1": {"name": "name", "order": "1", "text": "Name", "type": "control_fullname", "answer": {"first": "John", "last": "Smith"}},
+		"2": {"name": "address", "order": "2", "text": "Address", "type": "control_address", "answer": {"addr_line1": "1 Main St", "city": "Springfield", "state": "IL", "postal": "62701", "country": "United States"}},
+		"3": {"name": "date", "order": "3", "text": "Date", "type": "control_datetime", "answer": {"month": "06", "day": "25", "year": "2013", "hour": "03", "min": "30", "ampm": "PM", "datetime": "2013-06-25 15:30:00"}},
+		"4": {"name": "toppings", "order": "4", "text": "Toppings", "type": "control_checkbox", "answer": ["Cheese", "Olives"]},
+		"5": {"name": "files", "order": "5", "text": "Files", "type": "control_fileupload", "answer": ["https://www.jotform.com/uploads/johnsmith/31751954731962/237955080346633702/cv.pdf"]},
+		"6": {"name": "rating", "order": "6", "text": "Rating", "type": "control_matrix", "answer": {"Service": "Good", "Food": ["Hot", "Tasty"]}},
+		"7": {"name": "payment", "order": "7", "text": "Payment", "type": "control_stripe", "answer": {"0": "T-Shirt", "paymentArray": "{\"product\":[\"T-Shirt (Amount: 10.00 USD, Quantity: 2)\"],\"currency\":\"USD\",\"total\":\"20.00\"}"}},
+		"8": {"name": "comments", "order": "8", "text": "Comments", "type": "control_textarea", "answer": "Great!"},
+		"9": {"name": "skipped", "order": "9", "text": "Skipped", "type": "control_textbox"},
+		"10": {"name": "widget", "order": "10", "text": "Widget", "type": "control_widget", "answer": {"rating": 5}},
+		"11": {"name": "birthday", "order": "11", "text": "Birthday", "type": "control_birthdate", "answer": {"month": "January", "day": "2", "year": "1990"}}
+	}
+}`
+
+const answersQuestions = `[
+	{"qid": "1", "type": "control_fullname", "text": "Name", "order": "1"},
+	{"qid": "4", "type": "control_checkbox", "text": "Toppings", "order": "4"},
+	{"qid": "10", "type": "control_widget", "text": "Widget", "order": "10"}
+]`
+
+func decodeFixture(t *testing.T, registry *jotform.AnswerRegistry) map[string]jotform.DecodedAnswer {
+	var submission jotform.Submission
+	assert.Nil(t, json.Unmarshal([]byte(answersSubmission), &submission))
+	var questions []jotform.Question
+	assert.Nil(t, json.Unmarshal([]byte(answersQuestions), &questions))
+
+	answers, err := registry.DecodeAnswers(submission, questions)
+	assert.Nil(t, err)
+	return answers
+}
+
+func TestDecodeAnswers(t *testing.T) {
+	t.Run("happy - decodes standard question types", func(t *testing.T) {
+		answers := decodeFixture(t, jotform.NewAnswerRegistry())
+
+		assert.Equal(t, jotform.FullName{First: "John", Last: "Smith"}, answers["1"].Value)
+		assert.Equal(t, "John Smith", answers["1"].Value.(jotform.FullName).String())
+
+		address := answers["2"].Value.(jotform.Address)
+		assert.Equal(t, "Springfield", address.City)
+		assert.Equal(t, "1 Main St, Springfield, IL, 62701, United States", address.String())
+
+		dt := answers["3"].Value.(jotform.DateTime)
+		assert.Equal(t, "PM", dt.AMPM)
+		assert.Equal(t, time.Date(2013, 6, 25, 15, 30, 0, 0, time.UTC), dt.Time)
+
+		assert.Equal(t, []string{"Cheese", "Olives"}, answers["4"].Value)
+		assert.Equal(t, 1, len(answers["5"].Value.([]string)))
+		assert.Equal(t, jotform.Matrix{"Service": {"Good"}, "Food": {"Hot", "Tasty"}}, answers["6"].Value)
+		assert.Equal(t, jotform.PaymentItems{
+			Products: []string{"T-Shirt (Amount: 10.00 USD, Quantity: 2)"},
+			Currency: "USD",
+			Total:    "20.00",
+		}, answers["7"].Value)
+		assert.Equal(t, "Great!", answers["8"].Value)
+		assert.Nil(t, answers["9"].Value)
+
+		birthday := answers["11"].Value.(jotform.DateTime)
+		assert.Equal(t, time.Date(1990, 1, 2, 0, 0, 0, 0, time.UTC), birthday.Time)
+	})
+
+	t.Run("happy - time of day doesn't clash with Time", func(t *testing.T) {
+		value, err := jotform.NewAnswerRegistry().Decode("control_datetime",
+			json.RawMessage(`{"month":"06","day":"25","year":"2013","hour":"03","min":"30","ampm":"PM","time":"03:30 PM"}`))
+		assert.Nil(t, err)
+		assert.Equal(t, time.Date(2013, 6, 25, 15, 30, 0, 0, time.UTC), value.(jotform.DateTime).Time)
+	})
+
+	t.Run("happy - unknown types are left raw", func(t *testing.T) {
+		answers := decodeFixture(t, jotform.NewAnswerRegistry())
+
+		assert.Equal(t, json.RawMessage(`{"rating": 5}`), answers["10"].Value)
+		assert.Equal(t, "Widget", answers["10"].Question.Text)
+	})
+
+	t.Run("happy - custom decoders can be registered", func(t *testing.T) {
+		registry := jotform.NewAnswerRegistry()
+		registry.Register("control_widget", func(raw json.RawMessage) (interface{}, error) {
+			var v struct {
+				Rating int `json:"rating"`
+			}
+			err := json.Unmarshal(raw, &v)
+			return v.Rating, err
+		})
+
+		answers := decodeFixture(t, registry)
+		assert.Equal(t, 5, answers["10"].Value)
+	})
+
+	t.Run("sad - decoder errors name the question", func(t *testing.T) {
+		registry := jotform.NewAnswerRegistry()
+		var submission jotform.Submission
+		assert.Nil(t, json.Unmarshal([]byte(`{"answers":{"4":{"type":"control_fullname","answer":["not","a","name"]}}}`), &submission))
+
+		_, err := registry.DecodeAnswers(submission, nil)
+		assert.NotNil(t, err)
+		assert.True(t, strings.Contains(err.Error(), "question 4"))
+	})
+
+	t.Run("happy - sorted by question order", func(t *testing.T) {
+		answers := jotform.SortedAnswers(decodeFixture(t, jotform.NewAnswerRegistry()))
+
+		assert.Equal(t, 11, len(answers))
+		assert.Equal(t, "1", answers[0].QID)
+		assert.Equal(t, "11", answers[10].QID)
+	})
+}
@@ -0,0 +1,175 @@
+package jotform
+
+import (
+	"context"
+	"io"
+)
+
+// API is every JotForm endpoint the Client calls,
+// so that code using the client can be tested with a fake implementation.
+type API interface {
+	// Raw endpoints, returning the content of the response.
+	Call(ctx context.Context, requestPath string, params interface{}, method string) ([]byte, error)
+	GetUser() ([]byte, error)
+	GetUserContext(ctx context.Context) ([]byte, error)
+	GetUsage() ([]byte, error)
+	GetUsageContext(ctx context.Context) ([]byte, error)
+	GetForms(offset string, limit string, filter map[string]string, orderBy string) ([]byte, error)
+	GetFormsContext(ctx context.Context, offset string, limit string, filter map[string]string, orderBy string) ([]byte, error)
+	GetSubmissions(offset string, limit string, filter map[string]string, orderBy string) ([]byte, error)
+	GetSubmissionsContext(ctx context.Context, offset string, limit string, filter map[string]string, orderBy string) ([]byte, error)
+	GetSubusers() ([]byte, error)
+	GetSubusersContext(ctx context.Context) ([]byte, error)
+	GetFolders() ([]byte, error)
+	GetFoldersContext(ctx context.Context) ([]byte, error)
+	GetReports() ([]byte, error)
+	GetReportsContext(ctx context.Context) ([]byte, error)
+	GetSettings() ([]byte, error)
+	GetSettingsContext(ctx context.Context) ([]byte, error)
+	UpdateSettings(settings map[string]string) ([]byte, error)
+	UpdateSettingsContext(ctx context.Context, settings map[string]string) ([]byte, error)
+	GetHistory(action string, date string, sortBy string, startDate string, endDate string) ([]byte, error)
+	GetHistoryContext(ctx context.Context, action string, date string, sortBy string, startDate string, endDate string) ([]byte, error)
+	GetForm(formID int64) ([]byte, error)
+	GetFormContext(ctx context.Context, formID int64) ([]byte, error)
+	GetFormQuestions(formID int64) ([]byte, error)
+	GetFormQuestionsContext(ctx context.Context, formID int64) ([]byte, error)
+	GetFormQuestion(formID int64, qid int) ([]byte, error)
+	GetFormQuestionContext(ctx context.Context, formID int64, qid int) ([]byte, error)
+	GetFormSubmissions(formID int64, offset string, limit string, filter map[string]string, orderBy string) ([]byte, error)
+	GetFormSubmissionsContext(ctx context.Context, formID int64, offset string, limit string, filter map[string]string, orderBy string) ([]byte, error)
+	CreateFormSubmission(formId int64, submission map[string]string) ([]byte, error)
+	CreateFormSubmissionContext(ctx context.Context, formId int64, submission map[string]string) ([]byte, error)
+	CreateFormSubmissions(formId int64, submission []byte) ([]byte, error)
+	CreateFormSubmissionsContext(ctx context.Context, formId int64, submission []byte) ([]byte, error)
+	GetFormFiles(formID int64) ([]byte, error)
+	GetFormFilesContext(ctx context.Context, formID int64) ([]byte, error)
+	GetFormWebhooks(formID int64) ([]byte, error)
+	GetFormWebhooksContext(ctx context.Context, formID int64) ([]byte, error)
+	CreateFormWebhook(formId int64, webhookURL string) ([]byte, error)
+	CreateFormWebhookContext(ctx context.Context, formId int64, webhookURL string) ([]byte, error)
+	DeleteFormWebhook(formID int64, webhookID int64) ([]byte, error)
+	DeleteFormWebhookContext(ctx context.Context, formID int64, webhookID int64) ([]byte, error)
+	GetSubmission(sid int64) ([]byte, error)
+	GetSubmissionContext(ctx context.Context, sid int64) ([]byte, error)
+	GetReport(reportID int64) ([]byte, error)
+	GetReportContext(ctx context.Context, reportID int64) ([]byte, error)
+	GetFolder(folderID string) ([]byte, error)
+	GetFolderContext(ctx context.Context, folderID string) ([]byte, error)
+	CreateFolder(folderProperties map[string]string) ([]byte, error)
+	CreateFolderContext(ctx context.Context, folderProperties map[string]string) ([]byte, error)
+	DeleteFolder(folderID string) ([]byte, error)
+	DeleteFolderContext(ctx context.Context, folderID string) ([]byte, error)
+	UpdateFolder(folderID string, folderProperties []byte) ([]byte, error)
+	UpdateFolderContext(ctx context.Context, folderID string, folderProperties []byte) ([]byte, error)
+	AddFormsToFolder(folderID string, formIDs []string) ([]byte, error)
+	AddFormsToFolderContext(ctx context.Context, folderID string, formIDs []string) ([]byte, error)
+	AddFormToFolder(folderID string, formID string) ([]byte, error)
+	AddFormToFolderContext(ctx context.Context, folderID string, formID string) ([]byte, error)
+	GetFormProperties(formID int64) ([]byte, error)
+	GetFormPropertiesContext(ctx context.Context, formID int64) ([]byte, error)
+	GetFormReports(formID int64) ([]byte, error)
+	GetFormReportsContext(ctx context.Context, formID int64) ([]byte, error)
+	CreateReport(formID int64, report map[string]string) ([]byte, error)
+	CreateReportContext(ctx context.Context, formID int64, report map[string]string) ([]byte, error)
+	GetFormProperty(formID int64, propertyKey string) ([]byte, error)
+	GetFormPropertyContext(ctx context.Context, formID int64, propertyKey string) ([]byte, error)
+	DeleteSubmission(sid int64) ([]byte, error)
+	DeleteSubmissionContext(ctx context.Context, sid int64) ([]byte, error)
+	EditSubmission(sid int64, submission map[string]string) ([]byte, error)
+	EditSubmissionContext(ctx context.Context, sid int64, submission map[string]string) ([]byte, error)
+	CloneForm(formID int64) ([]byte, error)
+	CloneFormContext(ctx context.Context, formID int64) ([]byte, error)
+	DeleteFormQuestion(formID int64, qid int) ([]byte, error)
+	DeleteFormQuestionContext(ctx context.Context, formID int64, qid int) ([]byte, error)
+	CreateFormQuestion(formID int64, questionProperties map[string]string) ([]byte, error)
+	CreateFormQuestionContext(ctx context.Context, formID int64, questionProperties map[string]string) ([]byte, error)
+	CreateFormQuestions(formID int64, questions []byte) ([]byte, error)
+	CreateFormQuestionsContext(ctx context.Context, formID int64, questions []byte) ([]byte, error)
+	EditFormQuestion(formID int64, qid int, questionProperties map[string]string) ([]byte, error)
+	EditFormQuestionContext(ctx context.Context, formID int64, qid int, questionProperties map[string]string) ([]byte, error)
+	SetFormProperties(formID int64, formProperties map[string]string) ([]byte, error)
+	SetFormPropertiesContext(ctx context.Context, formID int64, formProperties map[string]string) ([]byte, error)
+	SetMultipleFormProperties(formID int64, formProperties []byte) ([]byte, error)
+	SetMultipleFormPropertiesContext(ctx context.Context, formID int64, formProperties []byte) ([]byte, error)
+	CreateForm(form map[string]interface{}) ([]byte, error)
+	CreateFormContext(ctx context.Context, form map[string]interface{}) ([]byte, error)
+	CreateForms(form []byte) ([]byte, error)
+	CreateFormsContext(ctx context.Context, form []byte) ([]byte, error)
+	DeleteForm(formID int64) ([]byte, error)
+	DeleteFormContext(ctx context.Context, formID int64) ([]byte, error)
+	RegisterUser(userDetails map[string]string) ([]byte, error)
+	RegisterUserContext(ctx context.Context, userDetails map[string]string) ([]byte, error)
+	LoginUser(credentials map[string]string) ([]byte, error)
+	LoginUserContext(ctx context.Context, credentials map[string]string) ([]byte, error)
+	LogoutUser() ([]byte, error)
+	LogoutUserContext(ctx context.Context) ([]byte, error)
+	GetPlan(planName string) ([]byte, error)
+	GetPlanContext(ctx context.Context, planName string) ([]byte, error)
+	DeleteReport(reportID int64) ([]byte, error)
+	DeleteReportContext(ctx context.Context, reportID int64) ([]byte, error)
+
+	// Typed endpoints, decoding the content into models.
+	GetUserTyped(ctx context.Context) (*User, error)
+	GetUsageTyped(ctx context.Context) (*Usage, error)
+	GetFormsTyped(ctx context.Context, opts *ListOptions) ([]Form, error)
+	GetSubmissionsTyped(ctx context.Context, opts *ListOptions) ([]Submission, error)
+	GetFoldersTyped(ctx context.Context) (*Folder, error)
+	GetReportsTyped(ctx context.Context) ([]Report, error)
+	GetSettingsTyped(ctx context.Context) (*Settings, error)
+	UpdateSettingsTyped(ctx context.Context, settings map[string]string) (*Settings, error)
+	GetHistoryTyped(ctx context.Context, action string, date string, sortBy string, startDate string, endDate string) ([]HistoryEntry, error)
+	GetFormTyped(ctx context.Context, formID int64) (*Form, error)
+	GetFormQuestionsTyped(ctx context.Context, formID int64) ([]Question, error)
+	GetFormQuestionTyped(ctx context.Context, formID int64, qid int) (*Question, error)
+	GetFormSubmissionsTyped(ctx context.Context, formID int64, opts *ListOptions) ([]Submission, error)
+	CreateFormSubmissionTyped(ctx context.Context, formID int64, submission map[string]string) (*SubmissionResult, error)
+	GetFormFilesTyped(ctx context.Context, formID int64) ([]File, error)
+	GetFormWebhooksTyped(ctx context.Context, formID int64) ([]Webhook, error)
+	CreateFormWebhookTyped(ctx context.Context, formID int64, webhookURL string) ([]Webhook, error)
+	DeleteFormWebhookTyped(ctx context.Context, formID int64, webhookID int64) ([]Webhook, error)
+	GetSubmissionTyped(ctx context.Context, sid int64) (*Submission, error)
+	GetReportTyped(ctx context.Context, reportID int64) (*Report, error)
+	GetFolderTyped(ctx context.Context, folderID string) (*Folder, error)
+	GetFormReportsTyped(ctx context.Context, formID int64) ([]Report, error)
+	CreateReportTyped(ctx context.Context, formID int64, report map[string]string) (*Report, error)
+	CloneFormTyped(ctx context.Context, formID int64) (*Form, error)
+	DeleteFormTyped(ctx context.Context, formID int64) (*Form, error)
+	GetPlanTyped(ctx context.Context, planName string) (*Plan, error)
+
+	// Folders.
+	CreateFolderTyped(ctx context.Context, name string, color string, parentID string) (*Folder, error)
+	RenameFolder(ctx context.Context, folderID string, name string) (*Folder, error)
+	RecolorFolder(ctx context.Context, folderID string, color string) (*Folder, error)
+	MoveFolder(ctx context.Context, folderID string, parentID string) (*Folder, error)
+	DeleteFolderTyped(ctx context.Context, folderID string) error
+	AddFormsToFolderTyped(ctx context.Context, folderID string, formIDs ...int64) (*Folder, error)
+	RemoveFormsFromFolder(ctx context.Context, folderID string, formIDs ...int64) error
+	GetFolderTree(ctx context.Context) (*FolderTree, error)
+
+	// Iterators over every page of a listing.
+	IterForms(ctx context.Context, opts *IterOptions) *FormIterator
+	IterSubmissions(ctx context.Context, opts *IterOptions) *SubmissionIterator
+	IterFormSubmissions(ctx context.Context, formID int64, opts *IterOptions) *SubmissionIterator
+	IterHistory(ctx context.Context, action string, date string, sortBy string, startDate string, endDate string) *HistoryIterator
+
+	// Exports and downloads.
+	ExportCSV(ctx context.Context, w io.Writer, formID int64, listOpts *ListOptions, opts *CSVOptions) error
+	DownloadRichPDFSubmission(formID, submissionID string) ([]byte, error)
+	DownloadRichPDFSubmissionContext(ctx context.Context, formID, submissionID string) ([]byte, error)
+	DownloadSimplePDFSubmission(formID, submissionID, reportID string) ([]byte, error)
+	DownloadSimplePDFSubmissionContext(ctx context.Context, formID, submissionID, reportID string) ([]byte, error)
+	WriteRichPDFSubmission(ctx context.Context, w io.Writer, formID, submissionID string) (int64, error)
+	WriteSimplePDFSubmission(ctx context.Context, w io.Writer, formID, submissionID, reportID string) (int64, error)
+	SaveRichPDFSubmission(ctx context.Context, path string, formID, submissionID string) (int64, error)
+	SaveSimplePDFSubmission(ctx context.Context, path string, formID, submissionID, reportID string) (int64, error)
+	DownloadPDFs(ctx context.Context, formID int64, opts *BatchPDFOptions) ([]PDFResult, error)
+	GetSubmissionFiles(ctx context.Context, submissionID int64) ([]File, error)
+	DownloadFile(ctx context.Context, w io.Writer, file File) (int64, error)
+	SaveFile(ctx context.Context, dir string, file File) (string, error)
+
+	// Forms built with a FormBuilder.
+	CreateFormFromBuilder(ctx context.Context, b *FormBuilder) (*Form, error)
+}
+
+var _ API = (*Client)(nil)
@@ -0,0 +1,312 @@
+package jotform
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidForm is returned when a FormBuilder is missing something JotForm requires.
+var ErrInvalidForm = errors.New("jotform: invalid form")
+
+// FormBuilder builds a form to create, as a typed alternative to the map CreateForm takes.
+//
+//	form := jotform.NewFormBuilder("Contact us").
+//		Property("thankYouText", "Thanks, we'll be in touch.").
+//		Question(
+//			jotform.FullNameQuestion("name", "Your name").Require(),
+//			jotform.EmailQuestion("email", "Email").Require(),
+//			jotform.DropdownQuestion("topic", "Topic", "Sales", "Support"),
+//		).
+//		Email(jotform.FormEmail{To: "team@example.com", Subject: "New message"})
+//	created, err := jotformAPI.CreateFormFromBuilder(ctx, form)
+type FormBuilder struct {
+	properties map[string]string
+	questions  []FormQuestion
+	emails     []FormEmail
+}
+
+// NewFormBuilder starts a form with the given title.
+func NewFormBuilder(title string) *FormBuilder {
+	return &FormBuilder{properties: map[string]string{"title": title}}
+}
+
+// Property sets a form property, such as "thankYouText".
+func (b *FormBuilder) Property(key, value string) *FormBuilder {
+	b.properties[key] = value
+	return b
+}
+
+// Question adds questions to the end of the form.
+func (b *FormBuilder) Question(questions ...FormQuestion) *FormBuilder {
+	b.questions = append(b.questions, questions...)
+	return b
+}
+
+// Email adds emails for the form to send on each submission.
+func (b *FormBuilder) Email(emails ...FormEmail) *FormBuilder {
+	b.emails = append(b.emails, emails...)
+	return b
+}
+
+// Validate checks the form has a title, and that its questions and emails
+// have the fields JotForm requires.
+func (b *FormBuilder) Validate() error {
+	if b.properties["title"] == "" {
+		return fmt.Errorf("%w: no title", ErrInvalidForm)
+	}
+
+	names := make(map[string]bool, len(b.questions))
+	for i, question := range b.questions {
+		if err := question.validate(); err != nil {
+			return fmt.Errorf("%w: question %d: %s", ErrInvalidForm, i+1, err)
+		}
+		if question.Name != "" {
+			if names[question.Name] {
+				return fmt.Errorf("%w: question %d: duplicate name %q", ErrInvalidForm, i+1, question.Name)
+			}
+			names[question.Name] = true
+		}
+	}
+
+	for i, email := range b.emails {
+		if err := email.validate(); err != nil {
+			return fmt.Errorf("%w: email %d: %s", ErrInvalidForm, i+1, err)
+		}
+	}
+	return nil
+}
+
+// Params returns the form as the bracketed parameters CreateForm sends,
+// such as properties[title] and questions[1][type].
+func (b *FormBuilder) Params() (map[string]string, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	return EncodeParams("", b.encoded())
+}
+
+// JSON returns the form as the JSON body CreateForms sends.
+func (b *FormBuilder) JSON() ([]byte, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(b.encoded())
+}
+
+// builtForm is a form as CreateForm and CreateForms send it.
+type builtForm struct {
+	Properties map[string]string            `json:"properties" jotform:"properties"`
+	Questions  map[string]map[string]string `json:"questions" jotform:"questions"`
+	Emails     map[string]map[string]string `json:"emails" jotform:"emails"`
+}
+
+// encoded returns the form as it is sent, with questions numbered from 1 and emails from 0.
+func (b *FormBuilder) encoded() builtForm {
+	form := builtForm{
+		Properties: b.properties,
+		Questions:  make(map[string]map[string]string, len(b.questions)),
+		Emails:     make(map[string]map[string]string, len(b.emails)),
+	}
+	for i, question := range b.questions {
+		form.Questions[strconv.Itoa(i+1)] = question.properties(i + 1)
+	}
+	for i, email := range b.emails {
+		form.Emails[strconv.Itoa(i)] = email.properties()
+	}
+	return form
+}
+
+// CreateFormFromBuilder validates and creates the form built by b, returning the new Form.
+func (client Client) CreateFormFromBuilder(ctx context.Context, b *FormBuilder) (*Form, error) {
+	params, err := b.Params()
+	if err != nil {
+		return nil, err
+	}
+
+	var form Form
+	if err := client.decodeContent(ctx, "user/forms", params, "POST", &form); err != nil {
+		return nil, err
+	}
+	return &form, nil
+}
+
+// FormQuestion is a question of a FormBuilder.
+// The constructors, such as TextboxQuestion and DropdownQuestion, set the properties each type needs.
+type FormQuestion struct {
+	// Type is the question type, eg. "control_textbox".
+	Type string
+	// Name identifies the question, eg. in webhook posts. JotForm names it if empty.
+	Name     string
+	Text     string
+	Required bool
+	// Properties are other question properties, such as "hint".
+	Properties map[string]string
+}
+
+// requiredProperties are the properties a question type can't be created without.
+var requiredProperties = map[string][]string{
+	"control_dropdown": {"options"},
+	"control_matrix":   {"mrows", "mcolumns"},
+}
+
+// Require returns the question marked as required.
+func (q FormQuestion) Require() FormQuestion {
+	q.Required = true
+	return q
+}
+
+// With returns the question with a property set, such as "hint" or "maxsize".
+func (q FormQuestion) With(key, value string) FormQuestion {
+	properties := make(map[string]string, len(q.Properties)+1)
+	for k, v := range q.Properties {
+		properties[k] = v
+	}
+	properties[key] = value
+	q.Properties = properties
+	return q
+}
+
+func (q FormQuestion) validate() error {
+	if q.Type == "" {
+		return errors.New("no type")
+	}
+	if q.Text == "" {
+		return errors.New("no text")
+	}
+	for _, key := range requiredProperties[q.Type] {
+		if q.Properties[key] == "" {
+			return fmt.Errorf("%s needs %s", q.Type, key)
+		}
+	}
+	return nil
+}
+
+// properties returns the question's properties, at the given position on the form.
+func (q FormQuestion) properties(order int) map[string]string {
+	properties := make(map[string]string, len(q.Properties)+5)
+	for key, value := range q.Properties {
+		properties[key] = value
+	}
+	properties["type"] = q.Type
+	properties["text"] = q.Text
+	properties["order"] = strconv.Itoa(order)
+	if q.Name != "" {
+		properties["name"] = q.Name
+	}
+	if q.Required {
+		properties["required"] = "Yes"
+	} else {
+		properties["required"] = "No"
+	}
+	return properties
+}
+
+// TextboxQuestion is a single line text question.
+func TextboxQuestion(name, text string) FormQuestion {
+	return FormQuestion{Type: "control_textbox", Name: name, Text: text}
+}
+
+// EmailQuestion is an email address question.
+func EmailQuestion(name, text string) FormQuestion {
+	return FormQuestion{Type: "control_email", Name: name, Text: text}
+}
+
+// DropdownQuestion is a question answered by choosing one of options.
+func DropdownQuestion(name, text string, options ...string) FormQuestion {
+	return FormQuestion{Type: "control_dropdown", Name: name, Text: text,
+		Properties: map[string]string{"options": strings.Join(options, "|")}}
+}
+
+// FullNameQuestion is a question answered with first and last names.
+func FullNameQuestion(name, text string) FormQuestion {
+	return FormQuestion{Type: "control_fullname", Name: name, Text: text}
+}
+
+// AddressQuestion is a postal address question.
+func AddressQuestion(name, text string) FormQuestion {
+	return FormQuestion{Type: "control_address", Name: name, Text: text}
+}
+
+// DateTimeQuestion is a date question.
+func DateTimeQuestion(name, text string) FormQuestion {
+	return FormQuestion{Type: "control_datetime", Name: name, Text: text}
+}
+
+// FileUploadQuestion is a question answered by uploading files.
+// If extensions are given, such as "pdf", only files with them can be uploaded.
+func FileUploadQuestion(name, text string, extensions ...string) FormQuestion {
+	question := FormQuestion{Type: "control_fileupload", Name: name, Text: text}
+	if len(extensions) > 0 {
+		question.Properties = map[string]string{"extensions": strings.Join(extensions, ", ")}
+	}
+	return question
+}
+
+// MatrixQuestion is a grid question, with one radio button answer for each of rows.
+func MatrixQuestion(name, text string, rows []string, columns []string) FormQuestion {
+	return FormQuestion{Type: "control_matrix", Name: name, Text: text, Properties: map[string]string{
+		"mrows":     strings.Join(rows, "|"),
+		"mcolumns":  strings.Join(columns, "|"),
+		"inputType": "Radio Button",
+	}}
+}
+
+// FormEmail is an email a form sends on each submission.
+type FormEmail struct {
+	// Type is "notification", an email to the form's owner, or "autorespond",
+	// an email to the person who submitted it. Defaults to "notification".
+	Type string
+	Name string
+	// From is the sender's address. JotForm's default sender is used if empty.
+	From string
+	// To is the recipient's address.
+	// An autoresponder is usually sent to the answer of an email question, eg. "{email}".
+	To      string
+	Subject string
+	// Body is the HTML of the email. JotForm lists the answers if empty.
+	Body string
+	// Properties are other email properties, such as "replyTo".
+	Properties map[string]string
+}
+
+func (e FormEmail) validate() error {
+	switch e.Type {
+	case "", "notification", "autorespond":
+	default:
+		return fmt.Errorf("unknown type %q", e.Type)
+	}
+	if e.To == "" {
+		return errors.New("no recipient")
+	}
+	return nil
+}
+
+func (e FormEmail) properties() map[string]string {
+	properties := make(map[string]string, len(e.Properties)+7)
+	for key, value := range e.Properties {
+		properties[key] = value
+	}
+
+	properties["type"] = e.Type
+	if e.Type == "" {
+		properties["type"] = "notification"
+	}
+	properties["name"] = e.Name
+	if e.Name == "" {
+		properties["name"] = properties["type"]
+	}
+	properties["to"] = e.To
+	properties["subject"] = e.Subject
+	if e.From != "" {
+		properties["from"] = e.From
+	}
+	if e.Body != "" {
+		properties["body"] = e.Body
+		properties["html"] = "true"
+	}
+	return properties
+}
@@ -0,0 +1,151 @@
+package jotform_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	jotform "github.com/jotform/jotform-api-go/v2"
+	"github.com/jotform/jotform-api-go/v2/jotformtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func contactForm() *jotform.FormBuilder {
+	return jotform.NewFormBuilder("Contact us").
+		Property("thankYouText", "Thanks!").
+		Question(
+			jotform.FullNameQuestion("name", "Your name").Require(),
+			jotform.EmailQuestion("email", "Email").Require(),
+			jotform.DropdownQuestion("topic", "Topic", "Sales", "Support"),
+			jotform.FileUploadQuestion("attachment", "Attachment", "pdf", "png").With("maxFileSize", "1024"),
+			jotform.MatrixQuestion("rating", "Rate us", []string{"Speed", "Service"}, []string{"Bad", "Good"}),
+		).
+		Email(
+			jotform.FormEmail{To: "team@example.com", Subject: "New message"},
+			jotform.FormEmail{Type: "autorespond", To: "{email}", Subject: "Thanks", Body: "<p>Thanks!</p>"},
+		)
+}
+
+func TestFormBuilder(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("happy - params", func(t *testing.T) {
+		params, err := contactForm().Params()
+		assert.NoError(t, err)
+		assert.Equal(t, "Contact us", params["properties[title]"])
+		assert.Equal(t, "Thanks!", params["properties[thankYouText]"])
+		assert.Equal(t, "control_fullname", params["questions[1][type]"])
+		assert.Equal(t, "Yes", params["questions[1][required]"])
+		assert.Equal(t, "No", params["questions[3][required]"])
+		assert.Equal(t, "Sales|Support", params["questions[3][options]"])
+		assert.Equal(t, "pdf, png", params["questions[4][extensions]"])
+		assert.Equal(t, "1024", params["questions[4][maxFileSize]"])
+		assert.Equal(t, "Speed|Service", params["questions[5][mrows]"])
+		assert.Equal(t, "5", params["questions[5][order]"])
+		assert.Equal(t, "notification", params["emails[0][type]"])
+		assert.Equal(t, "team@example.com", params["emails[0][to]"])
+		assert.Equal(t, "autorespond", params["emails[1][type]"])
+		assert.Equal(t, "true", params["emails[1][html]"])
+	})
+
+	t.Run("happy - json", func(t *testing.T) {
+		data, err := contactForm().JSON()
+		assert.NoError(t, err)
+
+		var body struct {
+			Properties map[string]string            `json:"properties"`
+			Questions  map[string]map[string]string `json:"questions"`
+			Emails     map[string]map[string]string `json:"emails"`
+		}
+		assert.NoError(t, json.Unmarshal(data, &body))
+		assert.Equal(t, "Contact us", body.Properties["title"])
+		assert.Equal(t, "control_email", body.Questions["2"]["type"])
+		assert.Equal(t, "{email}", body.Emails["1"]["to"])
+	})
+
+	t.Run("happy - create from builder", func(t *testing.T) {
+		server := jotformtest.NewServer()
+		defer server.Close()
+		client := jotform.NewJotFormAPIClient("api-key", "json", false)
+		client.BaseURL = server.URL
+
+		form, err := client.CreateFormFromBuilder(ctx, contactForm())
+		assert.NoError(t, err)
+		assert.Equal(t, "Contact us", form.Title)
+
+		questions, err := client.GetFormQuestionsTyped(ctx, int64(form.ID))
+		assert.NoError(t, err)
+		if assert.Len(t, questions, 5) {
+			assert.Equal(t, "name", questions[0].Name)
+			assert.True(t, bool(questions[0].Required))
+			assert.Equal(t, "control_matrix", questions[4].Type)
+		}
+		emails := server.Emails(int64(form.ID))
+		if assert.Len(t, emails, 2) {
+			assert.Equal(t, "New message", emails[0]["subject"])
+		}
+	})
+
+	t.Run("happy - create forms from json", func(t *testing.T) {
+		server := jotformtest.NewServer()
+		defer server.Close()
+		client := jotform.NewJotFormAPIClient("api-key", "json", false)
+		client.BaseURL = server.URL
+
+		data, err := contactForm().JSON()
+		assert.NoError(t, err)
+		content, err := client.CreateForms(data)
+		assert.NoError(t, err)
+
+		var form jotform.Form
+		assert.NoError(t, json.Unmarshal(content, &form))
+		assert.Len(t, server.Emails(int64(form.ID)), 2)
+	})
+
+	for name, form := range map[string]*jotform.FormBuilder{
+		"no title":           jotform.NewFormBuilder(""),
+		"no question text":   jotform.NewFormBuilder("A").Question(jotform.TextboxQuestion("a", "")),
+		"no question type":   jotform.NewFormBuilder("A").Question(jotform.FormQuestion{Text: "A"}),
+		"no dropdown option": jotform.NewFormBuilder("A").Question(jotform.FormQuestion{Type: "control_dropdown", Text: "A"}),
+		"duplicate names":    jotform.NewFormBuilder("A").Question(jotform.TextboxQuestion("a", "A"), jotform.EmailQuestion("a", "B")),
+		"no email recipient": jotform.NewFormBuilder("A").Email(jotform.FormEmail{Subject: "Hi"}),
+		"unknown email type": jotform.NewFormBuilder("A").Email(jotform.FormEmail{Type: "digest", To: "a@example.com"}),
+	} {
+		t.Run("sad - "+name, func(t *testing.T) {
+			_, err := form.Params()
+			assert.ErrorIs(t, err, jotform.ErrInvalidForm)
+
+			client := jotform.NewJotFormAPIClient("api-key", "json", false)
+			client.BaseURL = "http://127.0.0.1:0"
+			_, err = client.CreateFormFromBuilder(ctx, form)
+			assert.ErrorIs(t, err, jotform.ErrInvalidForm)
+		})
+	}
+
+	t.Run("happy - CreateForm with any nested values", func(t *testing.T) {
+		server := jotformtest.NewServer()
+		defer server.Close()
+		client := jotform.NewJotFormAPIClient("api-key", "json", false)
+		client.BaseURL = server.URL
+
+		content, err := client.CreateForm(map[string]interface{}{
+			"properties": map[string]interface{}{"title": "Nested"},
+			"questions":  []map[string]string{{"type": "control_textbox", "text": "Name"}},
+		})
+		assert.NoError(t, err)
+
+		var form jotform.Form
+		assert.NoError(t, json.Unmarshal(content, &form))
+		assert.Equal(t, "Nested", form.Title)
+	})
+
+	t.Run("sad - CreateForm with values that can't be encoded", func(t *testing.T) {
+		client := jotform.NewJotFormAPIClient("api-key", "json", false)
+		client.BaseURL = "http://127.0.0.1:0"
+
+		_, err := client.CreateForm(map[string]interface{}{"properties": map[string]interface{}{"title": func() {}}})
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "cannot encode func()")
+		}
+	})
+}
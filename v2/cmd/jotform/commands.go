@@ -0,0 +1,620 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	jotform "github.com/jotform/jotform-api-go/v2"
+	"github.com/jotform/jotform-api-go/v2/formspec"
+)
+
+// api is the part of the client the commands use.
+type api interface {
+	formspec.Client
+	GetFormsTyped(ctx context.Context, opts *jotform.ListOptions) ([]jotform.Form, error)
+	GetSubmissionsTyped(ctx context.Context, opts *jotform.ListOptions) ([]jotform.Submission, error)
+	GetFormSubmissionsTyped(ctx context.Context, formID int64, opts *jotform.ListOptions) ([]jotform.Submission, error)
+	GetSubmissionTyped(ctx context.Context, sid int64) (*jotform.Submission, error)
+	DeleteSubmissionContext(ctx context.Context, sid int64) ([]byte, error)
+	EditSubmissionContext(ctx context.Context, sid int64, submission map[string]string) ([]byte, error)
+	GetFolderTree(ctx context.Context) (*jotform.FolderTree, error)
+	WriteRichPDFSubmission(ctx context.Context, w io.Writer, formID, submissionID string) (int64, error)
+	WriteSimplePDFSubmission(ctx context.Context, w io.Writer, formID, submissionID, reportID string) (int64, error)
+	SaveRichPDFSubmission(ctx context.Context, path string, formID, submissionID string) (int64, error)
+	SaveSimplePDFSubmission(ctx context.Context, path string, formID, submissionID, reportID string) (int64, error)
+	GetUsageTyped(ctx context.Context) (*jotform.Usage, error)
+	GetHistoryTyped(ctx context.Context, action string, date string, sortBy string, startDate string, endDate string) ([]jotform.HistoryEntry, error)
+}
+
+// cli is what a command runs with.
+type cli struct {
+	client api
+	out    printer
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// action runs a command with its positional arguments.
+type action func(ctx context.Context, cli *cli, args []string) error
+
+// command is a subcommand, such as "forms list".
+type command struct {
+	name    string
+	args    string
+	summary string
+	// setup registers the command's flags, and returns the action to run once they are parsed.
+	setup func(fs *flag.FlagSet) action
+}
+
+var commands = map[string]*command{}
+
+func init() {
+	for _, cmd := range []*command{
+		{name: "forms list", summary: "list forms", setup: formsList},
+		{name: "forms get", args: "FORM_ID", summary: "show a form", setup: formsGet},
+		{name: "forms plan", args: "SPEC", summary: "show the changes that make a form match a spec file", setup: formsPlan},
+		{name: "forms apply", args: "SPEC", summary: "change a form to match a spec file", setup: formsApply},
+		{name: "questions list", args: "FORM_ID", summary: "list the questions of a form", setup: questionsList},
+		{name: "submissions list", summary: "list submissions, of all forms or of --form", setup: submissionsList},
+		{name: "submissions get", args: "SUBMISSION_ID", summary: "show a submission", setup: submissionsGet},
+		{name: "submissions delete", args: "SUBMISSION_ID", summary: "delete a submission", setup: submissionsDelete},
+		{name: "submissions edit", args: "SUBMISSION_ID QID=VALUE...", summary: "change answers of a submission, eg. 3=Smith or 4_first=Ada", setup: submissionsEdit},
+		{name: "webhooks list", args: "FORM_ID", summary: "list the webhooks of a form", setup: webhooksList},
+		{name: "webhooks add", args: "FORM_ID URL", summary: "add a webhook to a form", setup: webhooksAdd},
+		{name: "webhooks rm", args: "FORM_ID WEBHOOK_ID", summary: "remove a webhook from a form", setup: webhooksRemove},
+		{name: "folders tree", summary: "show the folders and their forms", setup: foldersTree},
+		{name: "pdf download", args: "FORM_ID SUBMISSION_ID", summary: "download a submission as a PDF", setup: pdfDownload},
+		{name: "usage", summary: "show this month's API usage", setup: usage},
+		{name: "history", summary: "list account activity", setup: history},
+	} {
+		commands[cmd.name] = cmd
+	}
+}
+
+// listFlags are the flags of the list commands.
+type listFlags struct {
+	offset  int
+	limit   int
+	orderBy string
+	filters filterFlag
+}
+
+func (l *listFlags) register(fs *flag.FlagSet) {
+	fs.IntVar(&l.offset, "offset", 0, "start of the results")
+	fs.IntVar(&l.limit, "limit", 20, "number of results")
+	fs.StringVar(&l.orderBy, "orderby", "", `field to order by, eg. "created_at"`)
+	fs.Var(&l.filters, "filter", `filter as FIELD=VALUE, eg. "status=ACTIVE" or "created_at:gt=2024-01-01 00:00:00"; repeatable`)
+}
+
+func (l *listFlags) options() *jotform.ListOptions {
+	opts := &jotform.ListOptions{Offset: l.offset, Limit: l.limit, OrderBy: l.orderBy}
+	if len(l.filters) > 0 {
+		opts.Filter = map[string]string(l.filters)
+	}
+	return opts
+}
+
+// filterFlag collects repeated FIELD=VALUE flags.
+type filterFlag map[string]string
+
+func (f *filterFlag) String() string {
+	pairs := make([]string, 0, len(*f))
+	for field, value := range *f {
+		pairs = append(pairs, field+"="+value)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (f *filterFlag) Set(s string) error {
+	field, value, ok := strings.Cut(s, "=")
+	if !ok || field == "" {
+		return fmt.Errorf("filter %q is not FIELD=VALUE", s)
+	}
+	if *f == nil {
+		*f = make(filterFlag)
+	}
+	(*f)[field] = value
+	return nil
+}
+
+func formsList(fs *flag.FlagSet) action {
+	var list listFlags
+	list.register(fs)
+
+	return func(ctx context.Context, cli *cli, args []string) error {
+		if err := wantArgs(args, 0); err != nil {
+			return err
+		}
+		forms, err := cli.client.GetFormsTyped(ctx, list.options())
+		if err != nil {
+			return err
+		}
+		return cli.out.print(forms, formHeaders, formRows(forms...))
+	}
+}
+
+func formsGet(fs *flag.FlagSet) action {
+	return func(ctx context.Context, cli *cli, args []string) error {
+		ids, err := idArgs(args, "FORM_ID")
+		if err != nil {
+			return err
+		}
+		form, err := cli.client.GetFormTyped(ctx, ids[0])
+		if err != nil {
+			return err
+		}
+		return cli.out.print(form, formHeaders, formRows(*form))
+	}
+}
+
+var formHeaders = []string{"id", "title", "status", "count", "created_at", "url"}
+
+func formRows(forms ...jotform.Form) [][]string {
+	rows := make([][]string, len(forms))
+	for i, form := range forms {
+		rows[i] = []string{
+			formatInt(form.ID), form.Title, form.Status, formatInt(form.Count),
+			formatTime(form.CreatedAt), form.URL,
+		}
+	}
+	return rows
+}
+
+func formsPlan(fs *flag.FlagSet) action {
+	return func(ctx context.Context, cli *cli, args []string) error {
+		if err := wantArgs(args, 1); err != nil {
+			return err
+		}
+		_, err := planSpec(ctx, cli, args[0])
+		return err
+	}
+}
+
+func formsApply(fs *flag.FlagSet) action {
+	dryRun := fs.Bool("dry-run", false, "show the changes without making them, as forms plan")
+	return func(ctx context.Context, cli *cli, args []string) error {
+		if err := wantArgs(args, 1); err != nil {
+			return err
+		}
+		plan, err := planSpec(ctx, cli, args[0])
+		if err != nil || *dryRun || plan.Empty() {
+			return err
+		}
+
+		created := plan.Spec.ID == 0
+		if err := formspec.Apply(ctx, cli.client, plan); err != nil {
+			return err
+		}
+		if created {
+			fmt.Fprintf(cli.stdout, "created form %d; add \"id\": %d to %s to manage it\n", plan.Spec.ID, plan.Spec.ID, args[0])
+		} else {
+			fmt.Fprintf(cli.stdout, "applied %d changes to form %d\n", len(plan.Changes), plan.Spec.ID)
+		}
+		return nil
+	}
+}
+
+// planSpec loads the spec at path, and prints the plan for it.
+func planSpec(ctx context.Context, cli *cli, path string) (*formspec.FormPlan, error) {
+	spec, err := formspec.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := formspec.Plan(ctx, cli.client, spec)
+	if err != nil {
+		return nil, err
+	}
+	_, err = fmt.Fprint(cli.stdout, plan)
+	return plan, err
+}
+
+func questionsList(fs *flag.FlagSet) action {
+	return func(ctx context.Context, cli *cli, args []string) error {
+		ids, err := idArgs(args, "FORM_ID")
+		if err != nil {
+			return err
+		}
+		questions, err := cli.client.GetFormQuestionsTyped(ctx, ids[0])
+		if err != nil {
+			return err
+		}
+
+		rows := make([][]string, len(questions))
+		for i, question := range questions {
+			rows[i] = []string{
+				formatInt(question.QID), formatInt(question.Order), question.Type,
+				question.Name, question.Text, strconv.FormatBool(bool(question.Required)),
+			}
+		}
+		return cli.out.print(questions, []string{"qid", "order", "type", "name", "text", "required"}, rows)
+	}
+}
+
+func submissionsList(fs *flag.FlagSet) action {
+	var list listFlags
+	list.register(fs)
+	formID := fs.Int64("form", 0, "list the submissions of this form; csv output then has a column per question")
+	since := fs.String("since", "", `only submissions created after this time: a duration such as "24h", a date, or "2006-01-02 15:04:05"`)
+
+	return func(ctx context.Context, cli *cli, args []string) error {
+		if err := wantArgs(args, 0); err != nil {
+			return err
+		}
+
+		opts := list.options()
+		if *since != "" {
+			t, err := parseSince(*since, time.Now())
+			if err != nil {
+				return usagef("--since: %v", err)
+			}
+			if opts.Filter == nil {
+				opts.Filter = make(map[string]string)
+			}
+			opts.Filter["created_at:gt"] = t.In(jotform.TimeLocation).Format(jotform.TimeLayout)
+		}
+
+		var submissions []jotform.Submission
+		var err error
+		if *formID != 0 {
+			submissions, err = cli.client.GetFormSubmissionsTyped(ctx, *formID, opts)
+		} else {
+			submissions, err = cli.client.GetSubmissionsTyped(ctx, opts)
+		}
+		if err != nil {
+			return err
+		}
+
+		if cli.out.format == "csv" && *formID != 0 {
+			return writeSubmissionsCSV(ctx, cli, *formID, submissions)
+		}
+		return cli.out.print(submissions, submissionHeaders, submissionRows(submissions...))
+	}
+}
+
+// writeSubmissionsCSV writes submissions with a column per question of their form.
+func writeSubmissionsCSV(ctx context.Context, cli *cli, formID int64, submissions []jotform.Submission) error {
+	questions, err := cli.client.GetFormQuestionsTyped(ctx, formID)
+	if err != nil {
+		return err
+	}
+	w, err := jotform.NewCSVWriter(cli.stdout, questions, nil)
+	if err != nil {
+		return err
+	}
+	if err := w.WriteHeader(); err != nil {
+		return err
+	}
+	for _, submission := range submissions {
+		if err := w.Write(submission); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// parseSince parses a time, or a duration before now.
+func parseSince(s string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	for _, layout := range []string{time.RFC3339, jotform.TimeLayout, "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, s, jotform.TimeLocation); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q is not a duration, date or time", s)
+}
+
+func submissionsGet(fs *flag.FlagSet) action {
+	return func(ctx context.Context, cli *cli, args []string) error {
+		ids, err := idArgs(args, "SUBMISSION_ID")
+		if err != nil {
+			return err
+		}
+		submission, err := cli.client.GetSubmissionTyped(ctx, ids[0])
+		if err != nil {
+			return err
+		}
+		if cli.out.format == "json" {
+			return cli.out.print(submission, nil, nil)
+		}
+
+		// Show the answers, one per row, in the order of the questions.
+		questions, err := cli.client.GetFormQuestionsTyped(ctx, int64(submission.FormID))
+		if err != nil {
+			return err
+		}
+		answers, err := jotform.DecodeAnswers(*submission, questions)
+		if err != nil {
+			return err
+		}
+		rows := [][]string{
+			{"id", formatInt(submission.ID)},
+			{"form_id", formatInt(submission.FormID)},
+			{"created_at", formatTime(submission.CreatedAt)},
+			{"status", submission.Status},
+		}
+		for _, answer := range jotform.SortedAnswers(answers) {
+			if answer.Value == nil {
+				continue
+			}
+			label := answer.Question.Text
+			if label == "" {
+				label = answer.QID
+			}
+			rows = append(rows, []string{label, jotform.FormatAnswer(answer.Value)})
+		}
+		return cli.out.print(submission, []string{"field", "value"}, rows)
+	}
+}
+
+func submissionsDelete(fs *flag.FlagSet) action {
+	return func(ctx context.Context, cli *cli, args []string) error {
+		ids, err := idArgs(args, "SUBMISSION_ID")
+		if err != nil {
+			return err
+		}
+		content, err := cli.client.DeleteSubmissionContext(ctx, ids[0])
+		if err != nil {
+			return err
+		}
+		return cli.out.printRaw(content, fmt.Sprintf("deleted submission %d", ids[0]))
+	}
+}
+
+func submissionsEdit(fs *flag.FlagSet) action {
+	return func(ctx context.Context, cli *cli, args []string) error {
+		if len(args) < 2 {
+			return usagef("want SUBMISSION_ID and at least one QID=VALUE")
+		}
+		ids, err := idArgs(args[:1], "SUBMISSION_ID")
+		if err != nil {
+			return err
+		}
+
+		answers := make(map[string]string)
+		for _, arg := range args[1:] {
+			qid, value, ok := strings.Cut(arg, "=")
+			if !ok || qid == "" {
+				return usagef("%q is not QID=VALUE", arg)
+			}
+			answers[qid] = value
+		}
+
+		content, err := cli.client.EditSubmissionContext(ctx, ids[0], answers)
+		if err != nil {
+			return err
+		}
+		return cli.out.printRaw(content, fmt.Sprintf("edited submission %d", ids[0]))
+	}
+}
+
+var submissionHeaders = []string{"id", "form_id", "created_at", "status", "answers"}
+
+func submissionRows(submissions ...jotform.Submission) [][]string {
+	rows := make([][]string, len(submissions))
+	for i, submission := range submissions {
+		rows[i] = []string{
+			formatInt(submission.ID), formatInt(submission.FormID), formatTime(submission.CreatedAt),
+			submission.Status, strconv.Itoa(len(submission.Answers)),
+		}
+	}
+	return rows
+}
+
+func webhooksList(fs *flag.FlagSet) action {
+	return func(ctx context.Context, cli *cli, args []string) error {
+		ids, err := idArgs(args, "FORM_ID")
+		if err != nil {
+			return err
+		}
+		webhooks, err := cli.client.GetFormWebhooksTyped(ctx, ids[0])
+		if err != nil {
+			return err
+		}
+		return printWebhooks(cli, webhooks)
+	}
+}
+
+func webhooksAdd(fs *flag.FlagSet) action {
+	return func(ctx context.Context, cli *cli, args []string) error {
+		if err := wantArgs(args, 2); err != nil {
+			return err
+		}
+		ids, err := idArgs(args[:1], "FORM_ID")
+		if err != nil {
+			return err
+		}
+		webhooks, err := cli.client.CreateFormWebhookTyped(ctx, ids[0], args[1])
+		if err != nil {
+			return err
+		}
+		return printWebhooks(cli, webhooks)
+	}
+}
+
+func webhooksRemove(fs *flag.FlagSet) action {
+	return func(ctx context.Context, cli *cli, args []string) error {
+		ids, err := idArgs(args, "FORM_ID", "WEBHOOK_ID")
+		if err != nil {
+			return err
+		}
+		webhooks, err := cli.client.DeleteFormWebhookTyped(ctx, ids[0], ids[1])
+		if err != nil {
+			return err
+		}
+		return printWebhooks(cli, webhooks)
+	}
+}
+
+func printWebhooks(cli *cli, webhooks []jotform.Webhook) error {
+	rows := make([][]string, len(webhooks))
+	for i, webhook := range webhooks {
+		rows[i] = []string{formatInt(webhook.ID), webhook.URL}
+	}
+	return cli.out.print(webhooks, []string{"id", "url"}, rows)
+}
+
+func foldersTree(fs *flag.FlagSet) action {
+	return func(ctx context.Context, cli *cli, args []string) error {
+		if err := wantArgs(args, 0); err != nil {
+			return err
+		}
+		tree, err := cli.client.GetFolderTree(ctx)
+		if err != nil {
+			return err
+		}
+
+		var rows [][]string
+		err = tree.Walk(func(path string, folder *jotform.Folder) error {
+			name := path
+			if cli.out.format == "table" {
+				// Indent the folder's name by its depth.
+				depth := strings.Count(path, jotform.FolderPathSeparator)
+				if path == "" {
+					name = "/"
+				} else {
+					name = strings.Repeat("  ", depth+1) + folder.Name
+				}
+			}
+			rows = append(rows, []string{name, folder.ID, strconv.Itoa(len(folder.Forms))})
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return cli.out.print(tree.Root, []string{"folder", "id", "forms"}, rows)
+	}
+}
+
+func pdfDownload(fs *flag.FlagSet) action {
+	rich := fs.Bool("rich", false, "fill the form's own PDF, for forms created from a PDF")
+	reportID := fs.String("report", "", "ID of the PDF report to format the submission with")
+	output := fs.String("o", "", `file to write, or "-" for standard output (default SUBMISSION_ID.pdf)`)
+
+	return func(ctx context.Context, cli *cli, args []string) error {
+		if _, err := idArgs(args, "FORM_ID", "SUBMISSION_ID"); err != nil {
+			return err
+		}
+		if *rich && *reportID != "" {
+			return usagef("--rich and --report cannot be used together")
+		}
+
+		path := *output
+		if path == "-" {
+			var err error
+			if *rich {
+				_, err = cli.client.WriteRichPDFSubmission(ctx, cli.stdout, args[0], args[1])
+			} else {
+				_, err = cli.client.WriteSimplePDFSubmission(ctx, cli.stdout, args[0], args[1], *reportID)
+			}
+			return err
+		}
+		if path == "" {
+			path = args[1] + ".pdf"
+		}
+
+		var n int64
+		var err error
+		if *rich {
+			n, err = cli.client.SaveRichPDFSubmission(ctx, path, args[0], args[1])
+		} else {
+			n, err = cli.client.SaveSimplePDFSubmission(ctx, path, args[0], args[1], *reportID)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cli.stderr, "wrote %s (%d bytes)\n", path, n)
+		return nil
+	}
+}
+
+func usage(fs *flag.FlagSet) action {
+	return func(ctx context.Context, cli *cli, args []string) error {
+		if err := wantArgs(args, 0); err != nil {
+			return err
+		}
+		stats, err := cli.client.GetUsageTyped(ctx)
+		if err != nil {
+			return err
+		}
+		rows := [][]string{
+			{"submissions", formatInt(stats.Submissions)},
+			{"ssl_submissions", formatInt(stats.SSLSubmissions)},
+			{"payments", formatInt(stats.Payments)},
+			{"uploads", formatInt(stats.Uploads)},
+			{"total_submissions", formatInt(stats.TotalSubmissions)},
+			{"form_count", formatInt(stats.FormCount)},
+			{"views", formatInt(stats.Views)},
+			{"api", formatInt(stats.APICalls)},
+		}
+		return cli.out.print(stats, []string{"usage", "count"}, rows)
+	}
+}
+
+func history(fs *flag.FlagSet) action {
+	actionName := fs.String("action", "", `only this action, eg. "formCreation" or "userLogin"`)
+	date := fs.String("date", "", `period, eg. "lastWeek", "lastMonth" or "lastYear"`)
+	sortBy := fs.String("sortby", "", "ASC or DESC")
+	start := fs.String("start", "", "start date, as MM/DD/YYYY")
+	end := fs.String("end", "", "end date, as MM/DD/YYYY")
+
+	return func(ctx context.Context, cli *cli, args []string) error {
+		if err := wantArgs(args, 0); err != nil {
+			return err
+		}
+		entries, err := cli.client.GetHistoryTyped(ctx, *actionName, *date, *sortBy, *start, *end)
+		if err != nil {
+			return err
+		}
+
+		rows := make([][]string, len(entries))
+		for i, entry := range entries {
+			rows[i] = []string{
+				entry.Time().In(jotform.TimeLocation).Format(jotform.TimeLayout),
+				entry.Type, formatInt(entry.FormID), entry.FormTitle, entry.IP,
+			}
+		}
+		return cli.out.print(entries, []string{"time", "type", "form_id", "form_title", "ip"}, rows)
+	}
+}
+
+func wantArgs(args []string, n int) error {
+	if len(args) != n {
+		return usagef("want %d arguments, got %d", n, len(args))
+	}
+	return nil
+}
+
+// idArgs parses args as the numeric IDs named by names.
+func idArgs(args []string, names ...string) ([]int64, error) {
+	if len(args) != len(names) {
+		return nil, usagef("want %s", strings.Join(names, " "))
+	}
+
+	ids := make([]int64, len(args))
+	for i, arg := range args {
+		id, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			return nil, usagef("%s %q is not a number", names[i], arg)
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+func formatInt(i jotform.Int) string {
+	return strconv.FormatInt(int64(i), 10)
+}
+
+func formatTime(t jotform.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.In(jotform.TimeLocation).Format(jotform.TimeLayout)
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	jotform "github.com/jotform/jotform-api-go/v2"
+)
+
+// config is the contents of the config file.
+type config struct {
+	APIKey  string `json:"api_key"`
+	BaseURL string `json:"base_url"`
+	Region  string `json:"region"`
+	Output  string `json:"output"`
+}
+
+// globalFlags are the flags every command takes.
+type globalFlags struct {
+	apiKey  string
+	config  string
+	baseURL string
+	region  string
+	output  string
+	debug   bool
+}
+
+func (g *globalFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&g.apiKey, "api-key", "", "JotForm API key (default $JOTFORM_API_KEY)")
+	fs.StringVar(&g.config, "config", "", "config file (default $JOTFORM_CONFIG, or jotform/config.json in the user config directory)")
+	fs.StringVar(&g.baseURL, "base-url", "", "API base URL, eg. https://eu-api.jotform.com (default $JOTFORM_BASE_URL)")
+	fs.StringVar(&g.region, "region", "", "region: us, eu, hipaa, or an enterprise host such as acme.jotform.com (default $JOTFORM_REGION)")
+	fs.StringVar(&g.output, "output", "", "output format: json, table or csv (default $JOTFORM_OUTPUT, or table)")
+	fs.BoolVar(&g.debug, "debug", false, "print requests")
+}
+
+// loadConfig reads the config file at path.
+// If path is empty, the default config file is read, if there is one.
+func loadConfig(path string, getenv func(string) string) (config, error) {
+	var cfg config
+
+	explicit := true
+	if path == "" {
+		path = getenv("JOTFORM_CONFIG")
+	}
+	if path == "" {
+		explicit = false
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return cfg, nil
+		}
+		path = filepath.Join(dir, "jotform", "config.json")
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if !explicit && errors.Is(err, fs.ErrNotExist) {
+			return cfg, nil
+		}
+		return cfg, fmt.Errorf("reading config: %w", err)
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("reading config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// newCLI resolves the global flags, environment and config file into a cli.
+func newCLI(globals globalFlags, getenv func(string) string, stdout, stderr io.Writer) (*cli, error) {
+	cfg, err := loadConfig(globals.config, getenv)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey := firstNonEmpty(globals.apiKey, getenv("JOTFORM_API_KEY"), cfg.APIKey)
+	if apiKey == "" {
+		return nil, errors.New("no API key: set --api-key, $JOTFORM_API_KEY or api_key in the config file")
+	}
+
+	output := firstNonEmpty(globals.output, getenv("JOTFORM_OUTPUT"), cfg.Output, "table")
+	switch output {
+	case "json", "table", "csv":
+	default:
+		return nil, fmt.Errorf("unknown output format %q", output)
+	}
+
+	opts := []jotform.Option{jotform.WithUserAgent("jotform-cli")}
+	if name := firstNonEmpty(globals.region, getenv("JOTFORM_REGION"), cfg.Region); name != "" {
+		region, err := jotform.ParseRegion(name)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, jotform.WithRegion(region))
+	}
+	if baseURL := firstNonEmpty(globals.baseURL, getenv("JOTFORM_BASE_URL"), cfg.BaseURL); baseURL != "" {
+		opts = append(opts, jotform.WithBaseURL(baseURL))
+	}
+	client := jotform.New(apiKey, opts...)
+	client.SetDebugMode(globals.debug)
+
+	return &cli{
+		client: client,
+		out:    printer{format: output, w: stdout},
+		stdout: stdout,
+		stderr: stderr,
+	}, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,191 @@
+// Command jotform calls the JotForm API from the command line.
+//
+//	jotform forms list --limit 10
+//	jotform submissions list --form 231234567890 --since 24h --output csv
+//	jotform pdf download 231234567890 5512345678901234567 -o submission.pdf
+//	jotform forms apply --dry-run intake.json
+//
+// The API key is read from the --api-key flag, the JOTFORM_API_KEY environment variable,
+// or the "api_key" of a JSON config file, in that order.
+// The config file is --config, JOTFORM_CONFIG, or jotform/config.json in the user's config directory.
+//
+// Results are printed as --output json, table or csv.
+// Failed API calls exit with a status describing the failure:
+//
+//	1  any other error
+//	2  invalid usage
+//	3  unauthorized or forbidden
+//	4  not found
+//	5  rate limited, or out of quota
+//	6  JotForm server error
+//	7  bad request
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+
+	jotform "github.com/jotform/jotform-api-go/v2"
+)
+
+// Exit statuses.
+const (
+	exitOK           = 0
+	exitError        = 1
+	exitUsage        = 2
+	exitUnauthorized = 3
+	exitNotFound     = 4
+	exitRateLimited  = 5
+	exitServerError  = 6
+	exitBadRequest   = 7
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	os.Exit(run(ctx, os.Args[1:], os.Getenv, os.Stdout, os.Stderr))
+}
+
+// run runs the command named by args, returning the exit status.
+func run(ctx context.Context, args []string, getenv func(string) string, stdout, stderr io.Writer) int {
+	cmd, args := findCommand(args)
+	if cmd == nil {
+		printUsage(stderr)
+		return exitUsage
+	}
+
+	fs := flag.NewFlagSet("jotform "+cmd.name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: jotform %s [flags] %s\n\n%s\n\nflags:\n", cmd.name, cmd.args, cmd.summary)
+		fs.PrintDefaults()
+	}
+
+	var globals globalFlags
+	globals.register(fs)
+	action := cmd.setup(fs)
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	cli, err := newCLI(globals, getenv, stdout, stderr)
+	if err != nil {
+		printError(stderr, err)
+		return exitUsage
+	}
+
+	if err := action(ctx, cli, positional); err != nil {
+		var usage usageError
+		if errors.As(err, &usage) {
+			printError(stderr, err)
+			fs.Usage()
+			return exitUsage
+		}
+		printError(stderr, err)
+		return exitStatus(err)
+	}
+	return exitOK
+}
+
+func printError(w io.Writer, err error) {
+	message := err.Error()
+	if !strings.HasPrefix(message, "jotform: ") {
+		message = "jotform: " + message
+	}
+	fmt.Fprintln(w, message)
+}
+
+// exitStatus maps an error to the exit status describing it.
+func exitStatus(err error) int {
+	switch {
+	case errors.Is(err, jotform.ErrUnauthorized), errors.Is(err, jotform.ErrForbidden):
+		return exitUnauthorized
+	case errors.Is(err, jotform.ErrNotFound):
+		return exitNotFound
+	case errors.Is(err, jotform.ErrRateLimited), errors.Is(err, jotform.ErrQuotaExhausted):
+		return exitRateLimited
+	case errors.Is(err, jotform.ErrServerError):
+		return exitServerError
+	case errors.Is(err, jotform.ErrBadRequest):
+		return exitBadRequest
+	}
+	return exitError
+}
+
+// usageError is returned by a command given the wrong arguments.
+type usageError struct {
+	message string
+}
+
+func (e usageError) Error() string {
+	return e.message
+}
+
+func usagef(format string, args ...interface{}) error {
+	return usageError{message: fmt.Sprintf(format, args...)}
+}
+
+// parseInterspersed parses flags given before, between and after the positional arguments,
+// returning the positional arguments.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		if args[0] == "--" {
+			return append(positional, args[1:]...), nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// findCommand returns the command named by the first one or two args, and the remaining args.
+func findCommand(args []string) (*command, []string) {
+	if len(args) >= 2 {
+		if cmd, ok := commands[args[0]+" "+args[1]]; ok {
+			return cmd, args[2:]
+		}
+	}
+	if len(args) >= 1 {
+		if cmd, ok := commands[args[0]]; ok {
+			return cmd, args[1:]
+		}
+	}
+	return nil, nil
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "usage: jotform <command> [flags] [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-22s %s\n", name, commands[name].summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `Run "jotform <command> -h" for the flags of a command.`)
+}
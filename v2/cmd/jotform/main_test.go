@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	jotform "github.com/jotform/jotform-api-go/v2"
+	"github.com/jotform/jotform-api-go/v2/jotformtest"
+	"github.com/stretchr/testify/assert"
+)
+
+type result struct {
+	status int
+	stdout string
+	stderr string
+}
+
+func runWith(env map[string]string, args ...string) result {
+	var stdout, stderr bytes.Buffer
+	status := run(context.Background(), args, func(key string) string { return env[key] }, &stdout, &stderr)
+	return result{status: status, stdout: stdout.String(), stderr: stderr.String()}
+}
+
+func TestRun(t *testing.T) {
+	server := jotformtest.NewServer()
+	defer server.Close()
+	server.APIKey = "api-key"
+
+	form := server.AddForm(jotform.Form{Title: "Contact"},
+		jotform.Question{Type: "control_textbox", Text: "Name"})
+	created := jotform.Time{Time: time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)}
+	submission := server.AddSubmission(jotform.Submission{FormID: form.ID, CreatedAt: created, Answers: map[string]jotform.Answer{
+		"1": {Type: "control_textbox", Answer: json.RawMessage(`"Ada"`)},
+	}})
+	formID := formatInt(form.ID)
+	submissionID := formatInt(submission.ID)
+
+	// An empty config file, so the user's own config isn't read.
+	emptyConfig := filepath.Join(t.TempDir(), "config.json")
+	assert.Nil(t, os.WriteFile(emptyConfig, []byte("{}"), 0o600))
+	env := map[string]string{
+		"JOTFORM_API_KEY":  "api-key",
+		"JOTFORM_BASE_URL": server.URL,
+		"JOTFORM_CONFIG":   emptyConfig,
+	}
+
+	t.Run("happy - forms list as a table", func(t *testing.T) {
+		r := runWith(env, "forms", "list")
+		assert.Equal(t, exitOK, r.status, r.stderr)
+		assert.Contains(t, r.stdout, "ID")
+		assert.Contains(t, r.stdout, "Contact")
+	})
+
+	t.Run("happy - forms get as json", func(t *testing.T) {
+		r := runWith(env, "forms", "get", formID, "--output", "json")
+		assert.Equal(t, exitOK, r.status, r.stderr)
+
+		var got jotform.Form
+		assert.Nil(t, json.Unmarshal([]byte(r.stdout), &got))
+		assert.Equal(t, "Contact", got.Title)
+	})
+
+	t.Run("happy - submissions list as csv with --since", func(t *testing.T) {
+		r := runWith(env, "submissions", "list", "--form", formID, "--since", "2024-04-30", "--output", "csv")
+		assert.Equal(t, exitOK, r.status, r.stderr)
+		assert.Equal(t, "Submission ID,Submission Date,Last Update Date,Status,IP,Name\n"+
+			submissionID+",2024-05-01 09:00:00,,ACTIVE,,Ada\n", r.stdout)
+
+		r = runWith(env, "submissions", "list", "--form", formID, "--since", "2024-05-02", "--output", "csv")
+		assert.Equal(t, exitOK, r.status, r.stderr)
+		assert.Equal(t, "Submission ID,Submission Date,Last Update Date,Status,IP,Name\n", r.stdout)
+	})
+
+	t.Run("happy - pdf download", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "out.pdf")
+		r := runWith(env, "pdf", "download", formID, submissionID, "-o", path)
+		assert.Equal(t, exitOK, r.status, r.stderr)
+
+		pdf, err := os.ReadFile(path)
+		assert.Nil(t, err)
+		assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF")))
+	})
+
+	t.Run("happy - submissions edit, get and delete", func(t *testing.T) {
+		r := runWith(env, "submissions", "edit", submissionID, "1=Grace")
+		assert.Equal(t, exitOK, r.status, r.stderr)
+
+		r = runWith(env, "submissions", "get", submissionID)
+		assert.Equal(t, exitOK, r.status, r.stderr)
+		assert.Contains(t, r.stdout, "Grace")
+
+		r = runWith(env, "submissions", "delete", submissionID)
+		assert.Equal(t, exitOK, r.status, r.stderr)
+		assert.Equal(t, "deleted submission "+submissionID+"\n", r.stdout)
+	})
+
+	t.Run("happy - webhooks add, list and rm", func(t *testing.T) {
+		r := runWith(env, "webhooks", "add", formID, "https://example.com/hook", "--output", "csv")
+		assert.Equal(t, exitOK, r.status, r.stderr)
+		assert.Contains(t, r.stdout, "https://example.com/hook")
+
+		webhooks := server.Webhooks(int64(form.ID))
+		assert.Len(t, webhooks, 1)
+
+		r = runWith(env, "webhooks", "rm", formID, formatInt(webhooks[0].ID))
+		assert.Equal(t, exitOK, r.status, r.stderr)
+		assert.Empty(t, server.Webhooks(int64(form.ID)))
+	})
+
+	t.Run("happy - forms plan and apply", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "spec.json")
+		spec := `{"title": "Survey", "questions": [{"name": "rating", "type": "control_scale", "text": "Rating"}]}`
+		assert.Nil(t, os.WriteFile(path, []byte(spec), 0o600))
+
+		r := runWith(env, "forms", "apply", path, "--dry-run")
+		assert.Equal(t, exitOK, r.status, r.stderr)
+		assert.Contains(t, r.stdout, `+ question "rating"`)
+		assert.NotContains(t, r.stdout, "created form")
+
+		r = runWith(env, "forms", "apply", path)
+		assert.Equal(t, exitOK, r.status, r.stderr)
+		assert.Contains(t, r.stdout, "created form")
+
+		var id int64
+		_, err := fmt.Sscanf(r.stdout[strings.Index(r.stdout, "created form"):], "created form %d", &id)
+		assert.Nil(t, err)
+		spec = fmt.Sprintf(`{"id": %d, "title": "Survey", "questions": [{"name": "rating", "type": "control_scale", "text": "Rating"}]}`, id)
+		assert.Nil(t, os.WriteFile(path, []byte(spec), 0o600))
+
+		r = runWith(env, "forms", "plan", path)
+		assert.Equal(t, exitOK, r.status, r.stderr)
+		assert.Contains(t, r.stdout, "no changes")
+	})
+
+	t.Run("happy - api key from the config file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.json")
+		config := `{"api_key": "api-key", "base_url": "` + server.URL + `", "output": "json"}`
+		assert.Nil(t, os.WriteFile(path, []byte(config), 0o600))
+
+		r := runWith(map[string]string{"JOTFORM_CONFIG": path}, "usage")
+		assert.Equal(t, exitOK, r.status, r.stderr)
+		assert.True(t, strings.HasPrefix(r.stdout, "{"))
+	})
+
+	t.Run("sad - exit statuses", func(t *testing.T) {
+		r := runWith(env, "submissions", "get", "1")
+		assert.Equal(t, exitNotFound, r.status)
+		assert.Contains(t, r.stderr, "not found")
+
+		r = runWith(env, "usage", "--api-key", "wrong")
+		assert.Equal(t, exitUnauthorized, r.status)
+
+		r = runWith(env, "forms", "get", "abc")
+		assert.Equal(t, exitUsage, r.status)
+
+		r = runWith(env, "usage", "--region", "mars")
+		assert.Equal(t, exitUsage, r.status)
+		assert.Contains(t, r.stderr, "unknown region")
+
+		r = runWith(env, "nonsense")
+		assert.Equal(t, exitUsage, r.status)
+		assert.Contains(t, r.stderr, "forms list")
+
+		r = runWith(map[string]string{"JOTFORM_CONFIG": filepath.Join(t.TempDir(), "missing.json")}, "usage")
+		assert.Equal(t, exitUsage, r.status)
+	})
+
+	t.Run("happy - parse since", func(t *testing.T) {
+		now := time.Date(2024, 5, 2, 12, 0, 0, 0, time.UTC)
+
+		since, err := parseSince("36h", now)
+		assert.Nil(t, err)
+		assert.Equal(t, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), since)
+
+		since, err = parseSince("2024-05-01 08:30:00", now)
+		assert.Nil(t, err)
+		assert.Equal(t, time.Date(2024, 5, 1, 8, 30, 0, 0, time.UTC), since)
+
+		_, err = parseSince("yesterday", now)
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// printer prints results in the chosen output format.
+type printer struct {
+	format string
+	w      io.Writer
+}
+
+// print prints value as json, or its rows as a table or csv.
+func (p printer) print(value interface{}, headers []string, rows [][]string) error {
+	switch p.format {
+	case "json":
+		data, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(p.w, "%s\n", data)
+		return err
+
+	case "csv":
+		w := csv.NewWriter(p.w)
+		w.Write(headers)
+		w.WriteAll(rows)
+		return w.Error()
+	}
+
+	w := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, strings.ToUpper(strings.Join(headers, "\t")))
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			// Keep each row on one line.
+			cells[i] = strings.NewReplacer("\t", " ", "\n", " ", "\r", "").Replace(cell)
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	return w.Flush()
+}
+
+// printRaw prints the raw json content of a response.
+// Table and csv output print message instead.
+func (p printer) printRaw(content []byte, message string) error {
+	if p.format == "json" {
+		var value interface{}
+		if err := json.Unmarshal(content, &value); err != nil {
+			return err
+		}
+		return p.print(value, nil, nil)
+	}
+	_, err := fmt.Fprintln(p.w, message)
+	return err
+}
@@ -0,0 +1,72 @@
+package jotform_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	jotform "github.com/jotform/jotform-api-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type ctxKey struct{}
+
+// blockingClient waits for the request's context to finish, like a stalled server.
+func blockingClient() *jotform.MockHttpClient {
+	return &jotform.MockHttpClient{DoFunc: func(req *http.Request) (*http.Response, error) {
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	}}
+}
+
+func TestContext(t *testing.T) {
+	t.Run("happy - context reaches the HttpClient", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+
+		var got interface{}
+		client := jotform.NewTestClient(&jotform.MockHttpClient{DoFunc: func(req *http.Request) (*http.Response, error) {
+			got = req.Context().Value(ctxKey{})
+			return jotform.NewMockResponse(req, 200, `{"responseCode":200,"content":[]}`), nil
+		}})
+
+		_, err := client.GetFormSubmissionsContext(ctx, 123, "", "", nil, "")
+		assert.Nil(t, err)
+		assert.Equal(t, "value", got)
+	})
+
+	t.Run("sad - cancellation aborts the request", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		client := jotform.NewTestClient(blockingClient())
+
+		time.AfterFunc(10*time.Millisecond, cancel)
+		_, err := client.GetFormSubmissionsContext(ctx, 123, "0", "1000", nil, "")
+		assert.True(t, errors.Is(err, context.Canceled))
+	})
+
+	t.Run("sad - deadline aborts a PDF download", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		var hasDeadline bool
+		client := jotform.NewTestClient(&jotform.MockHttpClient{DoFunc: func(req *http.Request) (*http.Response, error) {
+			_, hasDeadline = req.Context().Deadline()
+			<-req.Context().Done()
+			return nil, req.Context().Err()
+		}})
+
+		_, err := client.DownloadSimplePDFSubmissionContext(ctx, "123", "456", "")
+		assert.True(t, hasDeadline)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	})
+
+	t.Run("sad - typed calls honour cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		client := jotform.NewTestClient(blockingClient())
+
+		time.AfterFunc(10*time.Millisecond, cancel)
+		_, err := client.GetSubmissionTyped(ctx, 123)
+		assert.True(t, errors.Is(err, context.Canceled))
+	})
+}
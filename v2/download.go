@@ -1,47 +1,82 @@
 package jotform
 
 import (
+	"bufio"
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
 )
 
 var ErrNotImplemented = errors.New("Not Implemented")
 
+// ErrNotPDF is returned by the streaming downloads when JotForm responds with something other than a PDF,
+// such as an HTML error page.
+var ErrNotPDF = errors.New("jotform: response is not a PDF")
+
 // DownloadRichPDFSubmission returns a PDF
 // for the provided submissionID and formID
 // that was specifically formatted for that formID,
 // ie. the form was created in Jotform from a PDF.
-func (client jotformAPIClient) DownloadRichPDFSubmission(formID, submissionID string) ([]byte, error) {
-	resp, err := client.HttpClient.Do(client.newRequest(
+func (client Client) DownloadRichPDFSubmission(formID, submissionID string) ([]byte, error) {
+	return client.DownloadRichPDFSubmissionContext(context.Background(), formID, submissionID)
+}
+
+// DownloadRichPDFSubmissionContext is DownloadRichPDFSubmission with a context.
+func (client Client) DownloadRichPDFSubmissionContext(ctx context.Context, formID, submissionID string) ([]byte, error) {
+	resp, err := client.openRichPDF(ctx, formID, submissionID)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	contents, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return contents, nil
+}
+
+// openRichPDF requests the rich PDF of a submission, returning the response if it succeeded.
+func (client Client) openRichPDF(ctx context.Context, formID, submissionID string) (*http.Response, error) {
+	req, err := client.newRequest(
+		ctx,
 		fmt.Sprintf("pdf-converter/%s/fill-pdf", formID),
 		map[string]string{
 			"submissionID": submissionID,
 		},
 		"GET",
-	))
+	)
+	if err != nil {
+		return nil, err
+	}
 
+	resp, err := client.do(req)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
 	if resp.StatusCode == 400 {
+		resp.Body.Close()
 		// This is a response like:
 		// {"responseCode":400,"message":"draw-pdf-answers Request Failed","content":"","duration":"98.08ms","info":"https:\/\/api.jotform.com\/docs#pdf-converter-id-fill-pdf"}
 		return nil, fmt.Errorf("Jotform form %s does not have an associated PDF: %w", formID, ErrNotImplemented)
 	}
 
 	if resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("Jotform API request for '%s' failed: %s", resp.Request.URL, resp.Status)
-	}
-
-	contents, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+		defer resp.Body.Close()
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, newAPIError(resp, body)
 	}
 
-	return contents, nil
+	return resp, nil
 }
 
 // DownloadSimplePDFSubmission returns a PDF
@@ -50,7 +85,28 @@ func (client jotformAPIClient) DownloadRichPDFSubmission(formID, submissionID st
 // If no reportID is provided or the provided ID does not exist,
 // this will default to the first PDF listed on the PDF Editor.
 // If no PDFs exist on the PDF editor, this will generate one.
-func (client jotformAPIClient) DownloadSimplePDFSubmission(formID, submissionID, reportID string) ([]byte, error) {
+func (client Client) DownloadSimplePDFSubmission(formID, submissionID, reportID string) ([]byte, error) {
+	return client.DownloadSimplePDFSubmissionContext(context.Background(), formID, submissionID, reportID)
+}
+
+// DownloadSimplePDFSubmissionContext is DownloadSimplePDFSubmission with a context.
+func (client Client) DownloadSimplePDFSubmissionContext(ctx context.Context, formID, submissionID, reportID string) ([]byte, error) {
+	resp, err := client.openSimplePDF(ctx, formID, submissionID, reportID)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	contents, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return contents, nil
+}
+
+// openSimplePDF requests the simple PDF of a submission, returning the response if it succeeded.
+func (client Client) openSimplePDF(ctx context.Context, formID, submissionID, reportID string) (*http.Response, error) {
 	query := map[string]string{
 		"formid":       formID,
 		"submissionid": submissionID,
@@ -59,25 +115,125 @@ func (client jotformAPIClient) DownloadSimplePDFSubmission(formID, submissionID,
 	if reportID != "" {
 		query["reportid"] = reportID
 	}
-	resp, err := client.HttpClient.Do(client.newRequest(
-		"generatePDF",
-		query,
-		"GET",
-	))
+	req, err := client.newRequest(ctx, "generatePDF", query, "GET")
+	if err != nil {
+		return nil, err
+	}
 
+	resp, err := client.do(req)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
 	if resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("Jotform API request for '%s' failed: %s", resp.Request.URL, resp.Status)
+		defer resp.Body.Close()
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, newAPIError(resp, body)
 	}
 
-	contents, err := ioutil.ReadAll(resp.Body)
+	return resp, nil
+}
+
+// WriteRichPDFSubmission is DownloadRichPDFSubmission, streaming the PDF to w
+// rather than reading it into memory.
+// It returns the number of bytes written, and fails with ErrNotPDF
+// if the response is not a PDF.
+func (client Client) WriteRichPDFSubmission(ctx context.Context, w io.Writer, formID, submissionID string) (int64, error) {
+	resp, err := client.openRichPDF(ctx, formID, submissionID)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
+	defer resp.Body.Close()
 
-	return contents, nil
+	return copyPDF(w, resp)
+}
+
+// WriteSimplePDFSubmission is DownloadSimplePDFSubmission, streaming the PDF to w
+// rather than reading it into memory.
+// It returns the number of bytes written, and fails with ErrNotPDF
+// if the response is not a PDF.
+func (client Client) WriteSimplePDFSubmission(ctx context.Context, w io.Writer, formID, submissionID, reportID string) (int64, error) {
+	resp, err := client.openSimplePDF(ctx, formID, submissionID, reportID)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	return copyPDF(w, resp)
+}
+
+// SaveRichPDFSubmission writes the rich PDF of a submission to the file at path,
+// creating its directory if needed.
+// The file is replaced only once the whole PDF has been downloaded.
+func (client Client) SaveRichPDFSubmission(ctx context.Context, path string, formID, submissionID string) (int64, error) {
+	return writeFileAtomic(path, func(w io.Writer) (int64, error) {
+		return client.WriteRichPDFSubmission(ctx, w, formID, submissionID)
+	})
+}
+
+// SaveSimplePDFSubmission writes the simple PDF of a submission to the file at path,
+// creating its directory if needed.
+// The file is replaced only once the whole PDF has been downloaded.
+func (client Client) SaveSimplePDFSubmission(ctx context.Context, path string, formID, submissionID, reportID string) (int64, error) {
+	return writeFileAtomic(path, func(w io.Writer) (int64, error) {
+		return client.WriteSimplePDFSubmission(ctx, w, formID, submissionID, reportID)
+	})
+}
+
+// pdfMagicWindow is how far into a file the PDF header may be.
+const pdfMagicWindow = 1024
+
+// copyPDF copies a PDF response body to w, after checking it is a PDF.
+func copyPDF(w io.Writer, resp *http.Response) (int64, error) {
+	if contentType := resp.Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil || (mediaType != "application/pdf" && mediaType != "application/octet-stream") {
+			return 0, fmt.Errorf("%w: Content-Type is %q", ErrNotPDF, contentType)
+		}
+	}
+
+	body := bufio.NewReaderSize(resp.Body, pdfMagicWindow)
+	head, err := body.Peek(pdfMagicWindow)
+	if err != nil && err != io.EOF {
+		return 0, err
+	}
+	if !bytes.Contains(head, []byte("%PDF-")) {
+		return 0, fmt.Errorf("%w: no %%PDF header", ErrNotPDF)
+	}
+
+	return io.Copy(w, body)
+}
+
+// writeFileAtomic writes a file by writing a temporary file in the same directory
+// and renaming it over path, so that path never holds a partial file.
+func writeFileAtomic(path string, write func(w io.Writer) (int64, error)) (int64, error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return 0, err
+	}
+
+	tmp, err := ioutil.TempFile(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+
+	n, err := write(tmp)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0o644)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return 0, err
+	}
+	return n, nil
 }
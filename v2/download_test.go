@@ -2,13 +2,19 @@ package jotform_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
 
 	jotform "github.com/jotform/jotform-api-go/v2"
+	"github.com/jotform/jotform-api-go/v2/jotformtest"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -151,3 +157,199 @@ func TestDownloadSimplePDFSubmission(t *testing.T) {
 		assert.Contains(t, err.Error(), "401")
 	})
 }
+
+func TestWritePDFSubmission(t *testing.T) {
+	ctx := context.Background()
+
+	respond := func(contentType string, body string) *jotform.MockHttpClient {
+		return &jotform.MockHttpClient{DoFunc: func(req *http.Request) (*http.Response, error) {
+			header := make(http.Header)
+			if contentType != "" {
+				header.Set("Content-Type", contentType)
+			}
+			return &http.Response{
+				Request:    req,
+				StatusCode: 200,
+				Status:     "200 OK",
+				Header:     header,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body))}, nil
+		}}
+	}
+
+	t.Run("happy - streams the PDF", func(t *testing.T) {
+		pdf := "%PDF-1.7\n" + strings.Repeat("x", 5000)
+		client := jotform.NewTestClient(respond("application/pdf", pdf))
+
+		var out bytes.Buffer
+		n, err := client.WriteSimplePDFSubmission(ctx, &out, "123", "456", "")
+		assert.Nil(t, err)
+		assert.Equal(t, int64(len(pdf)), n)
+		assert.Equal(t, pdf, out.String())
+
+		n, err = client.WriteRichPDFSubmission(ctx, &out, "123", "456")
+		assert.Nil(t, err)
+		assert.Equal(t, int64(len(pdf)), n)
+	})
+
+	t.Run("sad - not a PDF", func(t *testing.T) {
+		client := jotform.NewTestClient(respond("text/html; charset=utf-8", "<html>Please log in</html>"))
+		var out bytes.Buffer
+		_, err := client.WriteSimplePDFSubmission(ctx, &out, "123", "456", "")
+		assert.True(t, errors.Is(err, jotform.ErrNotPDF))
+		assert.Empty(t, out.String())
+
+		client = jotform.NewTestClient(respond("", "Pretend this is a PDF"))
+		_, err = client.WriteSimplePDFSubmission(ctx, &out, "123", "456", "")
+		assert.True(t, errors.Is(err, jotform.ErrNotPDF))
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("happy - saves the file atomically", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "pdfs", "456.pdf")
+
+		client := jotform.NewTestClient(respond("application/pdf", "%PDF-1.7 first"))
+		_, err := client.SaveSimplePDFSubmission(ctx, path, "123", "456", "")
+		assert.Nil(t, err)
+
+		client = jotform.NewTestClient(respond("text/html", "<html>Error</html>"))
+		_, err = client.SaveSimplePDFSubmission(ctx, path, "123", "456", "")
+		assert.True(t, errors.Is(err, jotform.ErrNotPDF))
+
+		saved, err := ioutil.ReadFile(path)
+		assert.Nil(t, err)
+		assert.Equal(t, "%PDF-1.7 first", string(saved))
+
+		entries, err := ioutil.ReadDir(filepath.Dir(path))
+		assert.Nil(t, err)
+		assert.Len(t, entries, 1)
+	})
+}
+
+func TestDownloadPDFs(t *testing.T) {
+	ctx := context.Background()
+
+	server := jotformtest.NewServer()
+	defer server.Close()
+	client := jotform.NewJotFormAPIClient("api-key", "json", false)
+	client.BaseURL = server.URL
+	client.Retry = nil
+
+	form := server.AddForm(jotform.Form{Title: "Orders"})
+	for i := 0; i < 5; i++ {
+		server.AddSubmission(jotform.Submission{FormID: form.ID})
+	}
+
+	t.Run("happy - downloads, then skips existing files", func(t *testing.T) {
+		dir := t.TempDir()
+
+		var completed int32
+		results, err := client.DownloadPDFs(ctx, int64(form.ID), &jotform.BatchPDFOptions{
+			ListOptions: jotform.ListOptions{Limit: 2},
+			Dir:         dir,
+			Concurrency: 3,
+			OnResult:    func(jotform.PDFResult) { atomic.AddInt32(&completed, 1) },
+		})
+		assert.Nil(t, err)
+		assert.Len(t, results, 5)
+		assert.Equal(t, int32(5), completed)
+		for _, result := range results {
+			assert.Nil(t, result.Err)
+			assert.False(t, result.Skipped)
+			assert.Equal(t, filepath.Join(dir, strconv.FormatInt(int64(form.ID), 10), strconv.FormatInt(result.SubmissionID, 10)+".pdf"), result.Path)
+
+			pdf, err := ioutil.ReadFile(result.Path)
+			assert.Nil(t, err)
+			assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF-")))
+		}
+
+		results, err = client.DownloadPDFs(ctx, int64(form.ID), &jotform.BatchPDFOptions{Dir: dir})
+		assert.Nil(t, err)
+		for _, result := range results {
+			assert.True(t, result.Skipped)
+		}
+	})
+
+	t.Run("sad - reports each failure", func(t *testing.T) {
+		server.InjectFault(jotformtest.Fault{Path: "generatePDF", StatusCode: 500, Times: 1})
+
+		results, err := client.DownloadPDFs(ctx, int64(form.ID), &jotform.BatchPDFOptions{
+			Dir:          t.TempDir(),
+			NameTemplate: "{submissionID}-{createdAt}.pdf",
+			Concurrency:  1,
+		})
+		assert.Nil(t, err)
+		assert.Len(t, results, 5)
+
+		var failed int
+		for _, result := range results {
+			if result.Err != nil {
+				failed++
+				assert.True(t, errors.Is(result.Err, jotform.ErrServerError))
+			}
+		}
+		assert.Equal(t, 1, failed)
+	})
+
+	t.Run("sad - cancelled partway", func(t *testing.T) {
+		// The batch is cancelled once the submissions are listed, with idle workers waiting,
+		// so a job may be handed out or not; it is repeated to see both.
+		for i := 0; i < 20; i++ {
+			ctx, cancel := context.WithCancel(ctx)
+			cancelling := *client
+			cancelling.Use(func(next jotform.Doer) jotform.Doer {
+				return jotform.DoerFunc(func(req *http.Request) (*http.Response, error) {
+					resp, err := next.Do(req)
+					if err == nil && strings.HasSuffix(req.URL.Path, "/submissions") {
+						body, _ := ioutil.ReadAll(resp.Body)
+						resp.Body.Close()
+						resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+						cancel()
+					}
+					return resp, err
+				})
+			})
+
+			results, err := cancelling.DownloadPDFs(ctx, int64(form.ID), &jotform.BatchPDFOptions{
+				Dir:         t.TempDir(),
+				Concurrency: 8,
+			})
+			cancel()
+			assert.True(t, errors.Is(err, context.Canceled))
+			assert.NotEmpty(t, results)
+			assert.LessOrEqual(t, len(results), 5)
+			for _, result := range results {
+				assert.NotZero(t, result.SubmissionID)
+				assert.True(t, errors.Is(result.Err, context.Canceled))
+			}
+		}
+	})
+
+	t.Run("happy - xml output", func(t *testing.T) {
+		xmlClient := jotform.NewJotFormAPIClient("api-key", "xml", false)
+		xmlClient.BaseURL = server.URL
+		xmlClient.Retry = nil
+
+		results, err := xmlClient.DownloadPDFs(ctx, int64(form.ID), &jotform.BatchPDFOptions{Dir: t.TempDir()})
+		assert.Nil(t, err)
+		assert.Len(t, results, 5)
+		for _, result := range results {
+			assert.Nil(t, result.Err)
+		}
+
+		server.SetFormPDF(int64(form.ID), []byte("%PDF-1.7 rich"))
+		var out bytes.Buffer
+		_, err = xmlClient.WriteRichPDFSubmission(ctx, &out, strconv.FormatInt(int64(form.ID), 10), strconv.FormatInt(results[0].SubmissionID, 10))
+		assert.Nil(t, err)
+		assert.Equal(t, "%PDF-1.7 rich", out.String())
+	})
+
+	t.Run("sad - unknown placeholder", func(t *testing.T) {
+		_, err := client.DownloadPDFs(ctx, int64(form.ID), &jotform.BatchPDFOptions{NameTemplate: "{formId}.pdf"})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("sad - listing fails", func(t *testing.T) {
+		_, err := client.DownloadPDFs(ctx, 42, &jotform.BatchPDFOptions{Dir: t.TempDir()})
+		assert.True(t, errors.Is(err, jotform.ErrNotFound))
+	})
+}
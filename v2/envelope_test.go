@@ -0,0 +1,168 @@
+package jotform_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	jotform "github.com/jotform/jotform-api-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvelope(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("happy - content is returned as sent", func(t *testing.T) {
+		content := `{"title": "Contact",  "id":"1", "answers": {"2": {}, "10": {}}}`
+		client := jotform.NewTestClient(newJSONClient(200, `{"responseCode":200,"content":`+content+`,"duration":"1ms"}`, nil))
+
+		raw, err := client.GetForm(1)
+		assert.Nil(t, err)
+		assert.Equal(t, content, string(raw))
+	})
+
+	t.Run("happy - missing content is null", func(t *testing.T) {
+		client := jotform.NewTestClient(newJSONClient(200, `{"responseCode":200}`, nil))
+
+		raw, err := client.GetForm(1)
+		assert.Nil(t, err)
+		assert.Equal(t, "null", string(raw))
+	})
+
+	t.Run("happy - typed content before the responseCode", func(t *testing.T) {
+		body := `{"content":{"id":"1","title":"Contact"},"resultSet":{"offset":0,"limit":1,"count":1},"responseCode":200,"limit-left":"42"}`
+		client := jotform.NewTestClient(newJSONClient(200, body, nil))
+
+		form, err := client.GetFormTyped(ctx, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, "Contact", form.Title)
+		remaining, _ := client.QuotaRemaining()
+		assert.Equal(t, 42, remaining)
+	})
+
+	t.Run("sad - failed envelope is an APIError, not a decoding error", func(t *testing.T) {
+		for _, body := range []string{
+			`{"responseCode":404,"message":"Form not found","content":"not a form"}`,
+			`{"content":"not a form","message":"Form not found","responseCode":404}`,
+		} {
+			client := jotform.NewTestClient(newJSONClient(200, body, nil))
+
+			_, err := client.GetFormTyped(ctx, 1)
+			var apiErr *jotform.APIError
+			if assert.True(t, errors.As(err, &apiErr), body) {
+				assert.Equal(t, "Form not found", apiErr.Message)
+				assert.True(t, errors.Is(err, jotform.ErrNotFound))
+			}
+		}
+	})
+
+	t.Run("sad - content of the wrong type", func(t *testing.T) {
+		client := jotform.NewTestClient(newJSONClient(200, `{"responseCode":200,"content":["not","a","form"]}`, nil))
+
+		_, err := client.GetFormTyped(ctx, 1)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("sad - not json", func(t *testing.T) {
+		client := jotform.NewTestClient(newJSONClient(200, `<html>`, nil))
+
+		_, err := client.GetFormTyped(ctx, 1)
+		assert.NotNil(t, err)
+		_, err = client.GetForm(1)
+		assert.NotNil(t, err)
+	})
+}
+
+// submissionPage returns a json response of n submissions, as JotForm sends for GetFormSubmissions.
+func submissionPage(n int) []byte {
+	var submissions []string
+	for i := 1; i <= n; i++ {
+		submissions = append(submissions, fmt.Sprintf(`{"id":"%d","form_id":"31751954731962","ip":"123.123.123.123","created_at":"2013-06-25 03:38:00","updated_at":null,"status":"ACTIVE","new":"1","flag":"0","notes":"","answers":{`+
+			`"1":{"name":"name","order":"1","text":"Name","type":"control_fullname","answer":{"first":"Ada","last":"Lovelace %d"},"prettyFormat":"Ada Lovelace %d"},`+
+			`"2":{"name":"email","order":"2","text":"Email","type":"control_email","answer":"ada%d@example.com"},`+
+			`"3":{"name":"files","order":"3","text":"Files","type":"control_fileupload","answer":["https://www.jotform.com/uploads/ada/31751954731962/%d/notes.pdf"]},`+
+			`"4":{"name":"comments","order":"4","text":"Comments","type":"control_textarea","answer":"Pleased to meet you, with a comment long enough to look like a real one."}}}`,
+			i, i, i, i, i))
+	}
+	return []byte(fmt.Sprintf(`{"responseCode":200,"message":"success","content":[%s],"duration":"250ms","resultSet":{"offset":0,"limit":%d,"count":%d},"limit-left":9000}`,
+		strings.Join(submissions, ","), n, n))
+}
+
+// pageClient returns a client that responds to every request with body.
+func pageClient(body []byte) *jotform.Client {
+	client := jotform.NewTestClient(&jotform.MockHttpClient{DoFunc: func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			Request:    req,
+			StatusCode: 200,
+			Header:     make(http.Header),
+			Body:       ioutil.NopCloser(bytes.NewReader(body)),
+		}, nil
+	}})
+	client.Retry = nil
+	return client
+}
+
+// BenchmarkSubmissionPage decodes a page of 1000 submissions.
+// "reencode" is how responses were decoded before envelope content was kept as json.RawMessage:
+// into interface{}, then encoded and decoded again.
+func BenchmarkSubmissionPage(b *testing.B) {
+	body := submissionPage(1000)
+	client := pageClient(body)
+	ctx := context.Background()
+
+	b.Run("reencode", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(body)))
+		for i := 0; i < b.N; i++ {
+			var env struct {
+				Content interface{} `json:"content"`
+			}
+			if err := json.Unmarshal(body, &env); err != nil {
+				b.Fatal(err)
+			}
+			content, err := json.Marshal(env.Content)
+			if err != nil {
+				b.Fatal(err)
+			}
+			var submissions []jotform.Submission
+			if err := json.Unmarshal(content, &submissions); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("raw", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(body)))
+		for i := 0; i < b.N; i++ {
+			content, err := client.GetFormSubmissionsContext(ctx, 31751954731962, "", "", nil, "")
+			if err != nil {
+				b.Fatal(err)
+			}
+			var submissions []jotform.Submission
+			if err := json.Unmarshal(content, &submissions); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("typed", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(body)))
+		for i := 0; i < b.N; i++ {
+			submissions, err := client.GetFormSubmissionsTyped(ctx, 31751954731962, nil)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if len(submissions) != 1000 {
+				b.Fatalf("got %d submissions", len(submissions))
+			}
+		}
+	})
+}
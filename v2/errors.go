@@ -0,0 +1,121 @@
+package jotform
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Sentinel errors an *APIError can be matched against with errors.Is.
+var (
+	ErrBadRequest   = errors.New("jotform: bad request")
+	ErrUnauthorized = errors.New("jotform: unauthorized")
+	ErrForbidden    = errors.New("jotform: forbidden")
+	ErrNotFound     = errors.New("jotform: not found")
+	ErrRateLimited  = errors.New("jotform: rate limited")
+	ErrServerError  = errors.New("jotform: server error")
+)
+
+// APIError is returned when JotForm answers with a non-2xx HTTP status
+// or an envelope whose responseCode is not 200.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// ResponseCode is the responseCode from JotForm's response envelope,
+	// or zero if the response did not include one.
+	ResponseCode int
+	// Message is the message from JotForm's response envelope,
+	// or the HTTP status if the response did not include one.
+	Message string
+	// Info is a link to the documentation for the endpoint.
+	Info string
+	// Method and URL identify the request that failed.
+	Method string
+	URL    string
+
+	limitLeft *int
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("jotform: %s %s failed: %d %s", e.Method, e.URL, e.Code(), e.Message)
+}
+
+// Code returns JotForm's responseCode if it reports a failure,
+// and the HTTP status code otherwise.
+func (e *APIError) Code() int {
+	if e.ResponseCode != 0 && e.ResponseCode != http.StatusOK {
+		return e.ResponseCode
+	}
+	return e.StatusCode
+}
+
+// Is reports whether the error matches one of the sentinel errors,
+// eg. errors.Is(err, ErrNotFound).
+func (e *APIError) Is(target error) bool {
+	code := e.Code()
+	switch target {
+	case ErrBadRequest:
+		return code == http.StatusBadRequest
+	case ErrUnauthorized:
+		return code == http.StatusUnauthorized
+	case ErrForbidden:
+		return code == http.StatusForbidden
+	case ErrNotFound:
+		return code == http.StatusNotFound
+	case ErrRateLimited:
+		return code == http.StatusTooManyRequests
+	case ErrServerError:
+		return code >= 500
+	}
+	return false
+}
+
+// envelope is the wrapper JotForm puts around every json response.
+// Content is kept as it was sent, to be returned or decoded without encoding it again.
+type envelope struct {
+	ResponseCode Int             `json:"responseCode"`
+	Message      interface{}     `json:"message"`
+	Content      json.RawMessage `json:"content"`
+	Duration     string          `json:"duration"`
+	Info         string          `json:"info"`
+	LimitLeft    *Int            `json:"limit-left"`
+	ResultSet    *ResultSet      `json:"resultSet"`
+}
+
+func (env envelope) failed() bool {
+	return env.ResponseCode != 0 && env.ResponseCode != http.StatusOK
+}
+
+// newAPIError builds an *APIError for response,
+// filling in the envelope fields if body is a JotForm json envelope.
+func newAPIError(response *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: response.StatusCode,
+		Message:    response.Status,
+	}
+
+	if response.Request != nil {
+		apiErr.Method = response.Request.Method
+		apiErr.URL = response.Request.URL.String()
+	}
+
+	var env envelope
+	if json.Unmarshal(body, &env) == nil {
+		apiErr.fromEnvelope(env)
+	}
+
+	return apiErr
+}
+
+func (e *APIError) fromEnvelope(env envelope) {
+	e.ResponseCode = int(env.ResponseCode)
+	e.Info = env.Info
+	if env.LimitLeft != nil {
+		limitLeft := int(*env.LimitLeft)
+		e.limitLeft = &limitLeft
+	}
+	if message, ok := env.Message.(string); ok && message != "" {
+		e.Message = message
+	}
+}
@@ -0,0 +1,82 @@
+package jotform_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	jotform "github.com/jotform/jotform-api-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIError(t *testing.T) {
+	t.Run("sad - non-2xx status returns APIError", func(t *testing.T) {
+		body := `{"responseCode":401,"message":"You're not authorized to use (\/user-submission-id) ","content":"","duration":"10.5ms","info":"https:\/\/api.jotform.com\/docs#user-submission-id"}`
+		client := jotform.NewTestClient(newJSONClient(401, body, nil))
+
+		_, err := client.GetSubmission(123)
+		assert.True(t, errors.Is(err, jotform.ErrUnauthorized))
+		assert.False(t, errors.Is(err, jotform.ErrNotFound))
+
+		var apiErr *jotform.APIError
+		assert.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, 401, apiErr.StatusCode)
+		assert.Equal(t, 401, apiErr.ResponseCode)
+		assert.Equal(t, "You're not authorized to use (/user-submission-id) ", apiErr.Message)
+		assert.Equal(t, "https://api.jotform.com/docs#user-submission-id", apiErr.Info)
+		assert.Equal(t, "GET", apiErr.Method)
+		assert.Equal(t, "https://api.jotform.com/v1/user/submission/123", apiErr.URL)
+	})
+
+	t.Run("sad - responseCode in a 200 response returns APIError", func(t *testing.T) {
+		body := `{"responseCode":404,"message":"Form not found","content":""}`
+		client := jotform.NewTestClient(newJSONClient(200, body, nil))
+
+		_, err := client.GetFormTyped(context.Background(), 1)
+		assert.True(t, errors.Is(err, jotform.ErrNotFound))
+
+		var apiErr *jotform.APIError
+		assert.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, 200, apiErr.StatusCode)
+		assert.Equal(t, 404, apiErr.Code())
+	})
+
+	t.Run("sad - rate limited", func(t *testing.T) {
+		body := `{"responseCode":429,"message":"You have reached your daily limit","content":""}`
+		client := jotform.NewTestClient(newJSONClient(429, body, nil))
+		client.Retry = nil
+
+		_, err := client.GetUser()
+		assert.True(t, errors.Is(err, jotform.ErrRateLimited))
+	})
+
+	t.Run("sad - non-json error body", func(t *testing.T) {
+		client := jotform.NewTestClient(newJSONClient(502, "<html>Bad Gateway</html>", nil))
+		client.Retry = nil
+
+		_, err := client.GetUser()
+		assert.True(t, errors.Is(err, jotform.ErrServerError))
+
+		var apiErr *jotform.APIError
+		assert.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, "502 Bad Gateway", apiErr.Message)
+		assert.Equal(t, 0, apiErr.ResponseCode)
+	})
+
+	t.Run("sad - download failures return APIError", func(t *testing.T) {
+		body := `{"responseCode":401,"message":"Authorization error for user()-form(123)-token()!","content":""}`
+		client := jotform.NewTestClient(newJSONClient(401, body, nil))
+
+		_, err := client.DownloadSimplePDFSubmission("123", "456", "")
+		assert.True(t, errors.Is(err, jotform.ErrUnauthorized))
+	})
+
+	t.Run("happy - success envelope returns content", func(t *testing.T) {
+		body := `{"responseCode":200,"message":"success","content":{"username":"johnsmith"}}`
+		client := jotform.NewTestClient(newJSONClient(200, body, nil))
+
+		content, err := client.GetUser()
+		assert.Nil(t, err)
+		assert.Equal(t, `{"username":"johnsmith"}`, string(content))
+	})
+}
@@ -0,0 +1,510 @@
+package jotform
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Keys of the submission columns written before the answers by a CSVWriter.
+const (
+	ColumnID        = "id"
+	ColumnCreatedAt = "created_at"
+	ColumnUpdatedAt = "updated_at"
+	ColumnStatus    = "status"
+	ColumnIP        = "ip"
+)
+
+// CSVOptions configures the columns of a CSVWriter.
+//
+// Columns are identified by key: one of the Column constants for the submission's own fields,
+// QuestionField(qid) for an answer, and QuestionField(qid) + "." + part
+// for the sub-columns of a composite answer, eg. "q3.first" or "q5.Cheese".
+// The column of checkbox selections that are not among the options is
+// QuestionField(qid) + ".|other", as no option can contain "|".
+// Where a key names a question, it stands for all of that question's columns.
+type CSVOptions struct {
+	// Include lists the columns to write. If empty, every column is written.
+	Include []string
+	// Exclude lists columns not to write.
+	Exclude []string
+	// Headers renames columns, by their exact key.
+	Headers map[string]string
+	// Registry decodes the answers. Defaults to DefaultAnswerRegistry.
+	Registry *AnswerRegistry
+	// Comma is the field delimiter. Defaults to ','.
+	Comma rune
+}
+
+// CSVColumn is a column written by a CSVWriter.
+type CSVColumn struct {
+	Key    string
+	Header string
+
+	value func(submission Submission, answers map[string]DecodedAnswer) string
+}
+
+// CSVWriter writes submissions as CSV rows,
+// with a column for each submission field and each question of the form.
+//
+// Columns follow the order of the questions on the form,
+// so the output for the same submissions is the same on every run.
+// Composite answers are split into sub-columns:
+//   - full names by prefix, first, middle, last and suffix
+//   - addresses by line1, line2, city, state, postal and country
+//   - matrices by row, listing the columns selected in each row
+//   - checkbox lists by option, holding the option if it was selected,
+//     with an "Other" column for the selections that are not among the options
+//
+// Questions that take no answer, such as headings and page breaks, have no column.
+type CSVWriter struct {
+	csv           *csv.Writer
+	columns       []CSVColumn
+	questions     []Question
+	registry      *AnswerRegistry
+	headerWritten bool
+}
+
+// NewCSVWriter returns a CSVWriter for submissions to a form with questions,
+// as returned by GetFormQuestionsTyped.
+// It fails if opts names a column the form doesn't have.
+func NewCSVWriter(w io.Writer, questions []Question, opts *CSVOptions) (*CSVWriter, error) {
+	if opts == nil {
+		opts = &CSVOptions{}
+	}
+
+	questions = append([]Question(nil), questions...)
+	sort.SliceStable(questions, func(i, j int) bool {
+		if questions[i].Order != questions[j].Order {
+			return questions[i].Order < questions[j].Order
+		}
+		return questions[i].QID < questions[j].QID
+	})
+
+	all := submissionColumns()
+	for _, question := range questions {
+		all = append(all, questionColumns(question)...)
+	}
+
+	for _, keys := range [][]string{opts.Include, opts.Exclude} {
+		for _, key := range keys {
+			if !matchesAnyColumn(all, key) {
+				return nil, fmt.Errorf("jotform: no CSV column %q", key)
+			}
+		}
+	}
+	for key := range opts.Headers {
+		if !hasColumn(all, key) {
+			return nil, fmt.Errorf("jotform: no CSV column %q to rename", key)
+		}
+	}
+
+	var columns []CSVColumn
+	for _, column := range all {
+		if len(opts.Include) > 0 && !matchesAnyKey(column.Key, opts.Include) {
+			continue
+		}
+		if matchesAnyKey(column.Key, opts.Exclude) {
+			continue
+		}
+		if header, ok := opts.Headers[column.Key]; ok {
+			column.Header = header
+		}
+		columns = append(columns, column)
+	}
+
+	writer := &CSVWriter{
+		csv:       csv.NewWriter(w),
+		columns:   columns,
+		questions: questions,
+		registry:  opts.Registry,
+	}
+	if opts.Comma != 0 {
+		writer.csv.Comma = opts.Comma
+	}
+	if writer.registry == nil {
+		writer.registry = DefaultAnswerRegistry
+	}
+	return writer, nil
+}
+
+// Columns returns the columns written, in order.
+func (w *CSVWriter) Columns() []CSVColumn {
+	return w.columns
+}
+
+// WriteHeader writes the header row, if it hasn't been written yet.
+func (w *CSVWriter) WriteHeader() error {
+	if w.headerWritten {
+		return nil
+	}
+
+	headers := make([]string, len(w.columns))
+	for i, column := range w.columns {
+		headers[i] = column.Header
+	}
+	if err := w.csv.Write(headers); err != nil {
+		return err
+	}
+	w.headerWritten = true
+	return nil
+}
+
+// Write writes a submission as a row, after the header row if it hasn't been written yet.
+func (w *CSVWriter) Write(submission Submission) error {
+	if err := w.WriteHeader(); err != nil {
+		return err
+	}
+
+	answers, err := w.registry.DecodeAnswers(submission, w.questions)
+	if err != nil {
+		return fmt.Errorf("jotform: submission %d: %w", submission.ID, err)
+	}
+
+	row := make([]string, len(w.columns))
+	for i, column := range w.columns {
+		row[i] = column.value(submission, answers)
+	}
+	return w.csv.Write(row)
+}
+
+// Flush writes any buffered rows to the underlying io.Writer.
+func (w *CSVWriter) Flush() error {
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+// ExportCSV writes the submissions of a form to w as CSV, a page at a time.
+// listOpts selects the submissions, as for GetFormSubmissionsTyped;
+// its Limit is the page size, defaulting to DefaultPageSize.
+// Unless listOpts orders them, submissions are written oldest first, by ID,
+// so submissions arriving during the export don't shift the pages.
+// The header row is written even if there are no submissions.
+func (client Client) ExportCSV(ctx context.Context, w io.Writer, formID int64, listOpts *ListOptions, opts *CSVOptions) error {
+	questions, err := client.GetFormQuestionsTyped(ctx, formID)
+	if err != nil {
+		return err
+	}
+
+	writer, err := NewCSVWriter(w, questions, opts)
+	if err != nil {
+		return err
+	}
+	if err := writer.WriteHeader(); err != nil {
+		return err
+	}
+
+	page := ListOptions{}
+	if listOpts != nil {
+		page = *listOpts
+	}
+	if page.Limit <= 0 {
+		page.Limit = DefaultPageSize
+	}
+	if page.OrderBy == "" && (page.Where == nil || page.Where.orderBy == "") {
+		page.OrderBy = "id,ASC"
+	}
+	for {
+		submissions, err := client.GetFormSubmissionsTyped(ctx, formID, &page)
+		if err != nil {
+			return err
+		}
+		for _, submission := range submissions {
+			if err := writer.Write(submission); err != nil {
+				return err
+			}
+		}
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+		if len(submissions) < page.Limit {
+			return nil
+		}
+		page.Offset += len(submissions)
+	}
+}
+
+func submissionColumns() []CSVColumn {
+	return []CSVColumn{
+		{Key: ColumnID, Header: "Submission ID", value: func(s Submission, _ map[string]DecodedAnswer) string {
+			return strconv.FormatInt(int64(s.ID), 10)
+		}},
+		{Key: ColumnCreatedAt, Header: "Submission Date", value: func(s Submission, _ map[string]DecodedAnswer) string {
+			return formatCSVTime(s.CreatedAt)
+		}},
+		{Key: ColumnUpdatedAt, Header: "Last Update Date", value: func(s Submission, _ map[string]DecodedAnswer) string {
+			return formatCSVTime(s.UpdatedAt)
+		}},
+		{Key: ColumnStatus, Header: "Status", value: func(s Submission, _ map[string]DecodedAnswer) string {
+			return s.Status
+		}},
+		{Key: ColumnIP, Header: "IP", value: func(s Submission, _ map[string]DecodedAnswer) string {
+			return s.IP
+		}},
+	}
+}
+
+func formatCSVTime(t Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.In(TimeLocation).Format(TimeLayout)
+}
+
+// unansweredTypes are the question types that take no answer.
+var unansweredTypes = map[string]bool{
+	"control_head":      true,
+	"control_text":      true,
+	"control_button":    true,
+	"control_pagebreak": true,
+	"control_collapse":  true,
+	"control_divider":   true,
+	"control_image":     true,
+	"control_captcha":   true,
+}
+
+func questionColumns(question Question) []CSVColumn {
+	if unansweredTypes[question.Type] {
+		return nil
+	}
+
+	qid := strconv.FormatInt(int64(question.QID), 10)
+	key := QuestionField(int(question.QID))
+	header := question.Text
+	if header == "" {
+		header = question.Name
+	}
+	answerOf := func(answers map[string]DecodedAnswer) interface{} {
+		return answers[qid].Value
+	}
+
+	sub := func(part string, label string, value func(interface{}) string) CSVColumn {
+		return CSVColumn{
+			Key:    key + "." + part,
+			Header: header + " - " + label,
+			value: func(_ Submission, answers map[string]DecodedAnswer) string {
+				return value(answerOf(answers))
+			},
+		}
+	}
+
+	var columns []CSVColumn
+	switch question.Type {
+	case "control_fullname":
+		parts := []struct{ part, label string }{
+			{"prefix", "Prefix"}, {"first", "First Name"}, {"middle", "Middle Name"},
+			{"last", "Last Name"}, {"suffix", "Suffix"},
+		}
+		labels := sublabels(question)
+		for _, p := range parts {
+			// Prefix, middle name and suffix are only asked for when enabled.
+			if (p.part == "prefix" || p.part == "middle" || p.part == "suffix") && question.Property(p.part) != "Yes" {
+				continue
+			}
+			part := p.part
+			columns = append(columns, sub(part, labelOr(labels, part, p.label), func(v interface{}) string {
+				name, _ := v.(FullName)
+				return map[string]string{
+					"prefix": name.Prefix, "first": name.First, "middle": name.Middle,
+					"last": name.Last, "suffix": name.Suffix,
+				}[part]
+			}))
+		}
+		return columns
+
+	case "control_address":
+		parts := []struct{ part, sublabel, label string }{
+			{"line1", "addr_line1", "Street Address"}, {"line2", "addr_line2", "Street Address Line 2"},
+			{"city", "city", "City"}, {"state", "state", "State / Province"},
+			{"postal", "postal", "Postal / Zip Code"}, {"country", "country", "Country"},
+		}
+		labels := sublabels(question)
+		for _, p := range parts {
+			part := p.part
+			columns = append(columns, sub(part, labelOr(labels, p.sublabel, p.label), func(v interface{}) string {
+				address, _ := v.(Address)
+				return map[string]string{
+					"line1": address.Line1, "line2": address.Line2, "city": address.City,
+					"state": address.State, "postal": address.Postal, "country": address.Country,
+				}[part]
+			}))
+		}
+		return columns
+
+	case "control_matrix":
+		rows := splitOptions(question.Property("mrows"))
+		if len(rows) == 0 {
+			break
+		}
+		for _, row := range rows {
+			row := row
+			columns = append(columns, sub(row, row, func(v interface{}) string {
+				matrix, _ := v.(Matrix)
+				return strings.Join(matrix[row], ", ")
+			}))
+		}
+		return columns
+
+	case "control_checkbox":
+		options := splitOptions(question.Property("options"))
+		if len(options) == 0 {
+			break
+		}
+		isOption := make(map[string]bool, len(options))
+		for _, option := range options {
+			option := option
+			isOption[option] = true
+			columns = append(columns, sub(option, option, func(v interface{}) string {
+				selected, _ := v.([]string)
+				for _, s := range selected {
+					if s == option {
+						return option
+					}
+				}
+				return ""
+			}))
+		}
+		// JotForm separates the options with "|", so no option can have this key.
+		columns = append(columns, sub("|other", "Other", func(v interface{}) string {
+			selected, _ := v.([]string)
+			var other []string
+			for _, s := range selected {
+				if !isOption[s] {
+					other = append(other, s)
+				}
+			}
+			return strings.Join(other, ", ")
+		}))
+		return columns
+	}
+
+	return []CSVColumn{{
+		Key:    key,
+		Header: header,
+		value: func(_ Submission, answers map[string]DecodedAnswer) string {
+			return FormatAnswer(answerOf(answers))
+		},
+	}}
+}
+
+// FormatAnswer formats the Value of a DecodedAnswer as text,
+// as a CSVWriter writes answers that have a single column.
+func FormatAnswer(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, ", ")
+	case FullName:
+		return v.String()
+	case Address:
+		return v.String()
+	case DateTime:
+		if v.Time.IsZero() {
+			return joinNonEmpty("-", v.Year, v.Month, v.Day)
+		}
+		if v.Hour == "" && v.Minute == "" {
+			return v.Time.Format("2006-01-02")
+		}
+		return v.Time.Format("2006-01-02 15:04")
+	case Matrix:
+		rows := mapKeys(v)
+		sort.Strings(rows)
+		formatted := make([]string, len(rows))
+		for i, row := range rows {
+			formatted[i] = row + ": " + strings.Join(v[row], ", ")
+		}
+		return strings.Join(formatted, "; ")
+	case PaymentItems:
+		formatted := strings.Join(v.Products, "; ")
+		if v.Total != "" {
+			formatted = joinNonEmpty(" ", formatted, "Total:", v.Total, v.Currency)
+		}
+		return formatted
+	case json.RawMessage:
+		return string(v)
+	case fmt.Stringer:
+		return v.String()
+	}
+	return fmt.Sprint(value)
+}
+
+// sublabels returns the labels of a composite question's parts, keyed by part.
+func sublabels(question Question) map[string]string {
+	var labels map[string]string
+	if raw, ok := question.Properties["sublabels"]; ok {
+		if err := json.Unmarshal(raw, &labels); err != nil {
+			// Some forms store the sublabels as JSON encoded within a string.
+			var encoded string
+			if json.Unmarshal(raw, &encoded) == nil {
+				json.Unmarshal([]byte(encoded), &labels)
+			}
+		}
+	}
+	return labels
+}
+
+func labelOr(labels map[string]string, part string, fallback string) string {
+	if label := labels[part]; label != "" {
+		return label
+	}
+	return fallback
+}
+
+// splitOptions splits a question's options, which JotForm separates with "|".
+func splitOptions(options string) []string {
+	var split []string
+	for _, option := range strings.Split(options, "|") {
+		if option = strings.TrimSpace(option); option != "" {
+			split = append(split, option)
+		}
+	}
+	return split
+}
+
+func matchesAnyColumn(columns []CSVColumn, key string) bool {
+	for _, column := range columns {
+		if matchesKey(column.Key, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasColumn(columns []CSVColumn, key string) bool {
+	for _, column := range columns {
+		if column.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAnyKey(columnKey string, keys []string) bool {
+	for _, key := range keys {
+		if matchesKey(columnKey, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesKey reports whether key names the column, or the question it belongs to.
+func matchesKey(columnKey string, key string) bool {
+	return columnKey == key || strings.HasPrefix(columnKey, key+".")
+}
+
+func mapKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,161 @@
+package jotform_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	jotform "github.com/jotform/jotform-api-go/v2"
+	"github.com/jotform/jotform-api-go/v2/jotformtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportCSV(t *testing.T) {
+	ctx := context.Background()
+
+	server := jotformtest.NewServer()
+	defer server.Close()
+	client := jotform.NewJotFormAPIClient("api-key", "json", false)
+	client.BaseURL = server.URL
+	client.Retry = nil
+
+	form := server.AddForm(jotform.Form{Title: "Orders"},
+		jotform.Question{Type: "control_head", Text: "Place an order"},
+		jotform.Question{Type: "control_fullname", Text: "Name", Properties: map[string]json.RawMessage{
+			"middle":    json.RawMessage(`"Yes"`),
+			"sublabels": json.RawMessage(`{"first":"Given","last":"Family"}`),
+		}},
+		jotform.Question{Type: "control_address", Text: "Address"},
+		jotform.Question{Type: "control_matrix", Text: "Rating", Properties: map[string]json.RawMessage{
+			"mrows": json.RawMessage(`"Taste|Price"`),
+		}},
+		jotform.Question{Type: "control_checkbox", Text: "Toppings", Properties: map[string]json.RawMessage{
+			"options": json.RawMessage(`"Cheese|Olives"`),
+		}},
+		jotform.Question{Type: "control_textarea", Text: "Notes"},
+	)
+
+	created := jotform.Time{Time: time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)}
+	server.AddSubmission(jotform.Submission{ID: 1, FormID: form.ID, IP: "10.0.0.1", CreatedAt: created, Answers: map[string]jotform.Answer{
+		"2": {Type: "control_fullname", Answer: json.RawMessage(`{"first":"Ada","middle":"K","last":"Lovelace"}`)},
+		"3": {Type: "control_address", Answer: json.RawMessage(`{"addr_line1":"1 Main St","city":"London","country":"UK"}`)},
+		"4": {Type: "control_matrix", Answer: json.RawMessage(`{"Taste":"Good","Price":["Fair","Low"]}`)},
+		"5": {Type: "control_checkbox", Answer: json.RawMessage(`["Olives","Anchovies"]`)},
+		"6": {Type: "control_textarea", Answer: json.RawMessage(`"Ring twice, \"please\""`)},
+	}})
+	server.AddSubmission(jotform.Submission{ID: 2, FormID: form.ID, CreatedAt: created, Answers: map[string]jotform.Answer{
+		"5": {Type: "control_checkbox", Answer: json.RawMessage(`"Cheese"`)},
+	}})
+
+	t.Run("happy - every column", func(t *testing.T) {
+		var out bytes.Buffer
+		err := client.ExportCSV(ctx, &out, int64(form.ID), &jotform.ListOptions{Limit: 1}, nil)
+		assert.Nil(t, err)
+
+		expected := "Submission ID,Submission Date,Last Update Date,Status,IP," +
+			"Name - Given,Name - Middle Name,Name - Family," +
+			"Address - Street Address,Address - Street Address Line 2,Address - City,Address - State / Province,Address - Postal / Zip Code,Address - Country," +
+			"Rating - Taste,Rating - Price,Toppings - Cheese,Toppings - Olives,Toppings - Other,Notes\n" +
+			`1,2024-05-01 09:30:00,,ACTIVE,10.0.0.1,Ada,K,Lovelace,1 Main St,,London,,,UK,Good,"Fair, Low",,Olives,Anchovies,"Ring twice, ""please"""` + "\n" +
+			"2,2024-05-01 09:30:00,,ACTIVE,,,,,,,,,,,,,Cheese,,,\n"
+		assert.Equal(t, expected, out.String())
+
+		var again bytes.Buffer
+		err = client.ExportCSV(ctx, &again, int64(form.ID), nil, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, out.String(), again.String())
+	})
+
+	t.Run("happy - include, exclude and rename", func(t *testing.T) {
+		var out bytes.Buffer
+		err := client.ExportCSV(ctx, &out, int64(form.ID), &jotform.ListOptions{OrderBy: "id,ASC"}, &jotform.CSVOptions{
+			Include: []string{jotform.ColumnID, "q2", "q5"},
+			Exclude: []string{"q2.middle", "q5.|other"},
+			Headers: map[string]string{jotform.ColumnID: "ID", "q2.last": "Surname"},
+			Comma:   ';',
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, "ID;Name - Given;Surname;Toppings - Cheese;Toppings - Olives\n"+
+			"1;Ada;Lovelace;;Olives\n"+
+			"2;;;Cheese;\n", out.String())
+	})
+
+	t.Run("happy - submissions arriving during the export are written last", func(t *testing.T) {
+		server := jotformtest.NewServer()
+		defer server.Close()
+		client := jotform.NewJotFormAPIClient("api-key", "json", false)
+		client.BaseURL = server.URL
+		client.Retry = nil
+
+		form := server.AddForm(jotform.Form{Title: "Orders"})
+		for id := 1; id <= 3; id++ {
+			server.AddSubmission(jotform.Submission{ID: jotform.Int(id), FormID: form.ID, CreatedAt: jotform.Time{Time: time.Date(2024, 5, id, 0, 0, 0, 0, time.UTC)}})
+		}
+
+		out := &arrivingWriter{arrive: func() {
+			server.AddSubmission(jotform.Submission{ID: 4, FormID: form.ID, CreatedAt: jotform.Time{Time: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)}})
+		}}
+		err := client.ExportCSV(ctx, out, int64(form.ID), &jotform.ListOptions{Limit: 2}, &jotform.CSVOptions{Include: []string{jotform.ColumnID}})
+		assert.Nil(t, err)
+		assert.Equal(t, "Submission ID\n1\n2\n3\n4\n", out.String())
+		for _, req := range server.Requests()[1:] {
+			assert.Equal(t, "id,ASC", req.Query.Get("orderby"))
+		}
+	})
+
+	t.Run("happy - a checkbox option named other", func(t *testing.T) {
+		form := server.AddForm(jotform.Form{Title: "Survey"},
+			jotform.Question{Type: "control_checkbox", Text: "Pets", Properties: map[string]json.RawMessage{
+				"options": json.RawMessage(`"Cat|other"`),
+			}},
+		)
+		server.AddSubmission(jotform.Submission{ID: 10, FormID: form.ID, Answers: map[string]jotform.Answer{
+			"1": {Type: "control_checkbox", Answer: json.RawMessage(`["other","Parrot"]`)},
+		}})
+
+		var out bytes.Buffer
+		err := client.ExportCSV(ctx, &out, int64(form.ID), nil, &jotform.CSVOptions{
+			Include: []string{"q1"},
+			Headers: map[string]string{"q1.other": "Other pets", "q1.|other": "Something else"},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, "Pets - Cat,Other pets,Something else\n,other,Parrot\n", out.String())
+	})
+
+	t.Run("sad - unknown column", func(t *testing.T) {
+		var out bytes.Buffer
+		err := client.ExportCSV(ctx, &out, int64(form.ID), nil, &jotform.CSVOptions{Exclude: []string{"q99"}})
+		assert.NotNil(t, err)
+		assert.Empty(t, out.String())
+
+		err = client.ExportCSV(ctx, &out, int64(form.ID), nil, &jotform.CSVOptions{Headers: map[string]string{"q2": "Name"}})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("happy - header only", func(t *testing.T) {
+		var out bytes.Buffer
+		writer, err := jotform.NewCSVWriter(&out, nil, nil)
+		assert.Nil(t, err)
+		assert.Len(t, writer.Columns(), 5)
+		assert.Nil(t, writer.WriteHeader())
+		assert.Nil(t, writer.Flush())
+		assert.Equal(t, "Submission ID,Submission Date,Last Update Date,Status,IP\n", out.String())
+	})
+}
+
+// arrivingWriter calls arrive once, after the first page of rows is written.
+type arrivingWriter struct {
+	bytes.Buffer
+	arrive func()
+}
+
+func (w *arrivingWriter) Write(p []byte) (int, error) {
+	n, err := w.Buffer.Write(p)
+	if w.arrive != nil && bytes.Count(w.Bytes(), []byte("\n")) > 1 {
+		w.arrive()
+		w.arrive = nil
+	}
+	return n, err
+}
@@ -0,0 +1,287 @@
+package jotform
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrSizeMismatch is returned when a downloaded file is not the size JotForm listed it as.
+var ErrSizeMismatch = errors.New("jotform: downloaded file has the wrong size")
+
+// partialSuffix is added to the path of a file while it is being downloaded.
+const partialSuffix = ".part"
+
+// SubmissionFiles returns the files uploaded with a submission,
+// as listed in the answers to its file upload questions.
+// Only Name, FormID, SubmissionID and URL are set.
+func SubmissionFiles(submission Submission) []File {
+	qids := make([]string, 0, len(submission.Answers))
+	for qid, answer := range submission.Answers {
+		if answer.Type == "control_fileupload" {
+			qids = append(qids, qid)
+		}
+	}
+	sort.Slice(qids, func(i, j int) bool {
+		a, _ := strconv.Atoi(qids[i])
+		b, _ := strconv.Atoi(qids[j])
+		return a < b
+	})
+
+	var files []File
+	for _, qid := range qids {
+		answer := submission.Answers[qid]
+		if isEmptyAnswer(answer.Answer) {
+			continue
+		}
+		urls, err := decodeStrings(answer.Answer)
+		if err != nil {
+			continue
+		}
+		for _, u := range urls.([]string) {
+			files = append(files, File{
+				Name:         fileNameOf(u),
+				FormID:       submission.FormID,
+				SubmissionID: submission.ID,
+				URL:          u,
+			})
+		}
+	}
+	return files
+}
+
+// GetSubmissionFiles returns the files uploaded with a submission.
+// GetFormFilesTyped lists the files uploaded to a whole form.
+func (client Client) GetSubmissionFiles(ctx context.Context, submissionID int64) ([]File, error) {
+	submission, err := client.GetSubmissionTyped(ctx, submissionID)
+	if err != nil {
+		return nil, err
+	}
+	return SubmissionFiles(*submission), nil
+}
+
+// DownloadFile streams an uploaded file to w, returning the number of bytes written.
+// Files hosted by JotForm are requested with the client's API key,
+// so that uploads which require logging in can be read.
+// If the file's Size is known, the download fails with ErrSizeMismatch if it differs.
+func (client Client) DownloadFile(ctx context.Context, w io.Writer, file File) (int64, error) {
+	resp, err := client.openFile(ctx, file.URL, 0)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	n, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return n, err
+	}
+	if file.Size > 0 && n != int64(file.Size) {
+		return n, fmt.Errorf("%w: got %d bytes of %s, want %d", ErrSizeMismatch, n, file.URL, file.Size)
+	}
+	return n, nil
+}
+
+// FilePath returns the local path of an uploaded file within dir:
+// dir/<formID>/<submissionID>/<name>.
+// The path of a file is the same every time, so files can be downloaded once and kept.
+func FilePath(dir string, file File) string {
+	name := file.Name
+	if name == "" {
+		name = fileNameOf(file.URL)
+	}
+	// Don't let the name escape the submission's directory.
+	name = strings.NewReplacer("/", "_", "\\", "_").Replace(name)
+	if name == "" || name == "." || name == ".." {
+		name = "file"
+	}
+
+	return filepath.Join(dir,
+		strconv.FormatInt(int64(file.FormID), 10),
+		strconv.FormatInt(int64(file.SubmissionID), 10),
+		name)
+}
+
+// SaveFile downloads an uploaded file to its FilePath within dir, returning the path.
+// If the file has already been downloaded, it is not downloaded again.
+//
+// The download is written beside the file with a ".part" suffix,
+// and only moved into place once complete.
+// If a download is interrupted, calling SaveFile again resumes it where it stopped,
+// where the server supports Range requests.
+func (client Client) SaveFile(ctx context.Context, dir string, file File) (string, error) {
+	path := FilePath(dir, file)
+	if info, err := os.Stat(path); err == nil && (file.Size == 0 || info.Size() == int64(file.Size)) {
+		return path, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	partial, err := os.OpenFile(path+partialSuffix, os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return "", err
+	}
+	defer partial.Close()
+
+	offset, err := partial.Seek(0, io.SeekEnd)
+	if err != nil {
+		return "", err
+	}
+	if file.Size > 0 && offset >= int64(file.Size) {
+		// The partial file is complete, or not of this file; start again.
+		offset = 0
+	}
+
+	size, err := client.resumeFile(ctx, partial, file.URL, offset)
+	if err != nil {
+		// Keep what was downloaded, to resume from next time.
+		partial.Sync()
+		return "", err
+	}
+	if file.Size > 0 && size != int64(file.Size) {
+		partial.Close()
+		os.Remove(partial.Name())
+		return "", fmt.Errorf("%w: got %d bytes of %s, want %d", ErrSizeMismatch, size, file.URL, file.Size)
+	}
+
+	if err := partial.Sync(); err != nil {
+		return "", err
+	}
+	if err := partial.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Rename(partial.Name(), path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// resumeFile writes the rest of the file at u to partial, which holds its first offset bytes,
+// returning the size of the whole file.
+func (client Client) resumeFile(ctx context.Context, partial *os.File, u string, offset int64) (int64, error) {
+	resp, err := client.openFile(ctx, u, offset)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// The server sends the whole file if it ignores the Range,
+	// or if the partial file no longer matches it.
+	if resp.StatusCode != http.StatusPartialContent || contentRangeStart(resp) != offset {
+		offset = 0
+	}
+	if err := partial.Truncate(offset); err != nil {
+		return 0, err
+	}
+	if _, err := partial.Seek(offset, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	n, err := io.Copy(partial, resp.Body)
+	return offset + n, err
+}
+
+// openFile requests the file at u, from offset onwards if offset is not zero.
+func (client Client) openFile(ctx context.Context, u string, offset int64) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if client.isJotFormHost(request.URL) {
+		request.Header.Add("apiKey", client.apiKey)
+	}
+	if offset > 0 {
+		request.Header.Set("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+	}
+
+	resp, err := client.keepingKeyOnJotForm().do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && offset > 0 {
+		// The partial file is longer than the file; download it all again.
+		resp.Body.Close()
+		return client.openFile(ctx, u, 0)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, newAPIError(resp, body)
+	}
+	return resp, nil
+}
+
+// keepingKeyOnJotForm returns a copy of the client whose *http.Client drops the API key
+// when a redirect leaves JotForm, as net/http only drops standard credentials such as Authorization.
+// Other HttpClients are kept as they are, and must not follow redirects with it themselves.
+func (client Client) keepingKeyOnJotForm() Client {
+	httpClient, ok := client.HttpClient.(*http.Client)
+	if !ok {
+		return client
+	}
+
+	copied := *httpClient
+	copied.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if !client.isJotFormHost(req.URL) {
+			req.Header.Del("apiKey")
+		}
+		if httpClient.CheckRedirect != nil {
+			return httpClient.CheckRedirect(req, via)
+		}
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+	client.HttpClient = &copied
+	return client
+}
+
+// isJotFormHost reports whether u is hosted by JotForm, by the API the client uses,
+// or by its region's Host, so that the API key can be sent with requests for it.
+func (client Client) isJotFormHost(u *url.URL) bool {
+	host := u.Hostname()
+	if host == "jotform.com" || strings.HasSuffix(host, ".jotform.com") {
+		return true
+	}
+	if client.fileHost != "" && strings.EqualFold(client.fileHost, u.Host) {
+		return true
+	}
+	base, err := url.Parse(client.BaseURL)
+	return err == nil && base.Host == u.Host
+}
+
+// contentRangeStart returns the first byte of a partial response, or -1 if it is not known.
+func contentRangeStart(resp *http.Response) int64 {
+	// eg. "bytes 100-199/200"
+	contentRange := strings.TrimPrefix(resp.Header.Get("Content-Range"), "bytes ")
+	start, _, ok := strings.Cut(contentRange, "-")
+	if !ok {
+		return -1
+	}
+	n, err := strconv.ParseInt(start, 10, 64)
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+// fileNameOf returns the name of the file at an upload URL.
+func fileNameOf(u string) string {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return path.Base(u)
+	}
+	return path.Base(parsed.Path)
+}
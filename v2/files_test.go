@@ -0,0 +1,177 @@
+package jotform_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	jotform "github.com/jotform/jotform-api-go/v2"
+	"github.com/jotform/jotform-api-go/v2/jotformtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFiles(t *testing.T) {
+	ctx := context.Background()
+
+	server := jotformtest.NewServer()
+	defer server.Close()
+	server.APIKey = "api-key"
+	client := jotform.NewJotFormAPIClient("api-key", "json", false)
+	client.BaseURL = server.URL
+	client.Retry = nil
+
+	form := server.AddForm(jotform.Form{Title: "Claims"},
+		jotform.Question{Type: "control_fileupload", Text: "Receipts"})
+	submission := server.AddSubmission(jotform.Submission{FormID: form.ID})
+	content := []byte(strings.Repeat("receipt ", 1000))
+	upload := server.AddUpload(int64(form.ID), int64(submission.ID), "my receipt.jpg", content)
+
+	answer, _ := json.Marshal([]string{upload.URL})
+	submission.Answers = map[string]jotform.Answer{
+		"1": {Type: "control_fileupload", Answer: answer},
+		"2": {Type: "control_textbox", Answer: json.RawMessage(`"not a file"`)},
+	}
+
+	t.Run("happy - lists files per form and per submission", func(t *testing.T) {
+		files, err := client.GetFormFilesTyped(ctx, int64(form.ID))
+		assert.Nil(t, err)
+		assert.Equal(t, []jotform.File{upload}, files)
+
+		files = jotform.SubmissionFiles(submission)
+		assert.Len(t, files, 1)
+		assert.Equal(t, "my receipt.jpg", files[0].Name)
+		assert.Equal(t, upload.URL, files[0].URL)
+		assert.Equal(t, submission.ID, files[0].SubmissionID)
+	})
+
+	t.Run("happy - downloads with the API key", func(t *testing.T) {
+		var out bytes.Buffer
+		n, err := client.DownloadFile(ctx, &out, upload)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(len(content)), n)
+		assert.Equal(t, content, out.Bytes())
+	})
+
+	t.Run("happy - saves once to a stable path", func(t *testing.T) {
+		dir := t.TempDir()
+		path, err := client.SaveFile(ctx, dir, upload)
+		assert.Nil(t, err)
+		assert.Equal(t, jotform.FilePath(dir, upload), path)
+		assert.Equal(t, filepath.Join(dir, "1001", "1002", "my receipt.jpg"), path)
+
+		saved, err := ioutil.ReadFile(path)
+		assert.Nil(t, err)
+		assert.Equal(t, content, saved)
+
+		requests := len(server.Requests())
+		again, err := client.SaveFile(ctx, dir, upload)
+		assert.Nil(t, err)
+		assert.Equal(t, path, again)
+		assert.Equal(t, requests, len(server.Requests()))
+	})
+
+	t.Run("happy - resumes a partial download", func(t *testing.T) {
+		dir := t.TempDir()
+		path := jotform.FilePath(dir, upload)
+		assert.Nil(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.Nil(t, ioutil.WriteFile(path+".part", content[:100], 0o644))
+
+		_, err := client.SaveFile(ctx, dir, upload)
+		assert.Nil(t, err)
+
+		requests := server.Requests()
+		assert.Equal(t, "bytes=100-", requests[len(requests)-1].Range)
+
+		saved, err := ioutil.ReadFile(path)
+		assert.Nil(t, err)
+		assert.Equal(t, content, saved)
+		_, err = os.Stat(path + ".part")
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("sad - wrong size", func(t *testing.T) {
+		wrong := upload
+		wrong.Size = 10
+
+		_, err := client.DownloadFile(ctx, ioutil.Discard, wrong)
+		assert.True(t, errors.Is(err, jotform.ErrSizeMismatch))
+
+		dir := t.TempDir()
+		_, err = client.SaveFile(ctx, dir, wrong)
+		assert.True(t, errors.Is(err, jotform.ErrSizeMismatch))
+		_, err = os.Stat(jotform.FilePath(dir, wrong))
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("sad - missing file", func(t *testing.T) {
+		missing := upload
+		missing.URL = server.URL + "/uploads/jotformtest/1/2/missing.jpg"
+
+		_, err := client.DownloadFile(ctx, ioutil.Discard, missing)
+		assert.True(t, errors.Is(err, jotform.ErrNotFound))
+	})
+
+	t.Run("happy - API key is not sent to other hosts", func(t *testing.T) {
+		var apiKey string
+		other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			apiKey = r.Header.Get("apiKey")
+			w.Write([]byte("elsewhere"))
+		}))
+		defer other.Close()
+
+		var out bytes.Buffer
+		_, err := client.DownloadFile(ctx, &out, jotform.File{URL: other.URL + "/file.txt"})
+		assert.Nil(t, err)
+		assert.Equal(t, "elsewhere", out.String())
+		assert.Empty(t, apiKey)
+	})
+
+	t.Run("happy - API key is dropped when redirected to other hosts", func(t *testing.T) {
+		keys := make(map[string]string)
+		other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			keys["other"+r.URL.Path] = r.Header.Get("apiKey")
+			w.Write([]byte("elsewhere"))
+		}))
+		defer other.Close()
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			keys["api"+r.URL.Path] = r.Header.Get("apiKey")
+			switch r.URL.Path {
+			case "/uploads/away.txt":
+				http.Redirect(w, r, other.URL+"/away.txt", http.StatusFound)
+			case "/uploads/moved.txt":
+				http.Redirect(w, r, "/uploads/here.txt", http.StatusFound)
+			default:
+				w.Write([]byte("here"))
+			}
+		}))
+		defer api.Close()
+		client := jotform.NewJotFormAPIClient("api-key", "json", false)
+		client.BaseURL = api.URL
+		client.Retry = nil
+
+		var out bytes.Buffer
+		_, err := client.DownloadFile(ctx, &out, jotform.File{URL: api.URL + "/uploads/away.txt"})
+		assert.Nil(t, err)
+		assert.Equal(t, "elsewhere", out.String())
+
+		out.Reset()
+		_, err = client.DownloadFile(ctx, &out, jotform.File{URL: api.URL + "/uploads/moved.txt"})
+		assert.Nil(t, err)
+		assert.Equal(t, "here", out.String())
+
+		assert.Equal(t, map[string]string{
+			"api/uploads/away.txt":  "api-key",
+			"other/away.txt":        "",
+			"api/uploads/moved.txt": "api-key",
+			"api/uploads/here.txt":  "api-key",
+		}, keys)
+	})
+}
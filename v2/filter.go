@@ -0,0 +1,222 @@
+package jotform
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Filter builds the filter and orderby parameters of the list endpoints.
+// Unlike a map, a Filter keeps its conditions in order
+// and can hold several conditions on the same field, with different operators.
+// JotForm keeps only the last condition with the same field and operator,
+// so a Filter repeating both reports an error instead.
+//
+//	filter := jotform.Where("created_at").After(since).And("status").Ne("DELETED")
+type Filter struct {
+	conditions []filterCondition
+	orderBy    string
+	err        error
+}
+
+type filterCondition struct {
+	key   string
+	value string
+}
+
+// FilterField is a field of a Filter awaiting its comparison.
+type FilterField struct {
+	filter *Filter
+	field  string
+}
+
+// filterOperators are the comparisons JotForm supports, by suffix.
+// Equality has no suffix.
+var filterOperators = map[string]bool{
+	"":        true,
+	"ne":      true,
+	"gt":      true,
+	"gte":     true,
+	"lt":      true,
+	"lte":     true,
+	"matches": true,
+}
+
+// Where starts a Filter with a condition on field.
+func Where(field string) *FilterField {
+	return (&Filter{}).And(field)
+}
+
+// QuestionField returns the name to filter on the answer to question qid.
+func QuestionField(qid int) string {
+	return "q" + strconv.Itoa(qid)
+}
+
+// FullText starts a Filter matching text anywhere in a submission.
+func FullText(text string) *Filter {
+	return (&Filter{}).FullText(text)
+}
+
+// And adds another condition on field.
+func (f *Filter) And(field string) *FilterField {
+	return &FilterField{filter: f, field: field}
+}
+
+// FullText adds a condition matching text anywhere in a submission.
+func (f *Filter) FullText(text string) *Filter {
+	if f.err != nil {
+		return f
+	}
+	return f.add(filterCondition{key: "fullText", value: text})
+}
+
+// OrderBy orders the results by field, eg. "created_at".
+func (f *Filter) OrderBy(field string) *Filter {
+	f.orderBy = field
+	return f
+}
+
+// Err returns the first error made building the filter, if any.
+func (f *Filter) Err() error {
+	return f.err
+}
+
+// Encode returns the filter as the JSON object JotForm expects.
+func (f *Filter) Encode() (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+
+	var b strings.Builder
+	b.WriteString("{")
+	for i, condition := range f.conditions {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		key, _ := json.Marshal(condition.key)
+		value, _ := json.Marshal(condition.value)
+		b.Write(key)
+		b.WriteString(":")
+		b.Write(value)
+	}
+	b.WriteString("}")
+	return b.String(), nil
+}
+
+// Params returns the filter and orderby query parameters.
+func (f *Filter) Params() (map[string]string, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	params := make(map[string]string)
+	if len(f.conditions) > 0 {
+		params["filter"], _ = f.Encode()
+	}
+	if f.orderBy != "" {
+		params["orderby"] = f.orderBy
+	}
+	return params, nil
+}
+
+// Eq matches values equal to value.
+func (field *FilterField) Eq(value interface{}) *Filter { return field.Op("", value) }
+
+// Ne matches values not equal to value.
+func (field *FilterField) Ne(value interface{}) *Filter { return field.Op("ne", value) }
+
+// Gt matches values greater than value.
+func (field *FilterField) Gt(value interface{}) *Filter { return field.Op("gt", value) }
+
+// Gte matches values greater than or equal to value.
+func (field *FilterField) Gte(value interface{}) *Filter { return field.Op("gte", value) }
+
+// Lt matches values less than value.
+func (field *FilterField) Lt(value interface{}) *Filter { return field.Op("lt", value) }
+
+// Lte matches values less than or equal to value.
+func (field *FilterField) Lte(value interface{}) *Filter { return field.Op("lte", value) }
+
+// Matches matches values containing pattern.
+func (field *FilterField) Matches(pattern string) *Filter { return field.Op("matches", pattern) }
+
+// After matches times after t.
+func (field *FilterField) After(t time.Time) *Filter { return field.Op("gt", t) }
+
+// Before matches times before t.
+func (field *FilterField) Before(t time.Time) *Filter { return field.Op("lt", t) }
+
+// Op adds a condition using one of JotForm's operators by name:
+// "" or "eq" for equality, "ne", "gt", "gte", "lt", "lte" or "matches".
+// An unknown operator is reported by Err and Encode.
+func (field *FilterField) Op(op string, value interface{}) *Filter {
+	f := field.filter
+	if f.err != nil {
+		return f
+	}
+
+	if op == "eq" {
+		op = ""
+	}
+	if !filterOperators[op] {
+		f.err = fmt.Errorf("jotform: unknown filter operator %q on %q", op, field.field)
+		return f
+	}
+	if field.field == "" || strings.Contains(field.field, ":") {
+		f.err = fmt.Errorf("jotform: invalid filter field %q", field.field)
+		return f
+	}
+
+	formatted, err := formatFilterValue(value)
+	if err != nil {
+		f.err = fmt.Errorf("jotform: filter on %q: %w", field.field, err)
+		return f
+	}
+
+	key := field.field
+	if op != "" {
+		key += ":" + op
+	}
+	return f.add(filterCondition{key: key, value: formatted})
+}
+
+// add appends condition, unless the filter already has a condition with its key.
+func (f *Filter) add(condition filterCondition) *Filter {
+	for _, c := range f.conditions {
+		if c.key == condition.key {
+			f.err = fmt.Errorf("jotform: repeated filter condition %q", condition.key)
+			return f
+		}
+	}
+	f.conditions = append(f.conditions, condition)
+	return f
+}
+
+func formatFilterValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case time.Time:
+		return v.In(TimeLocation).Format(TimeLayout), nil
+	case Time:
+		return v.In(TimeLocation).Format(TimeLayout), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case Int:
+		return strconv.FormatInt(int64(v), 10), nil
+	case bool:
+		if v {
+			return "1", nil
+		}
+		return "0", nil
+	case Bool:
+		return formatFilterValue(bool(v))
+	case fmt.Stringer:
+		return v.String(), nil
+	}
+	return "", fmt.Errorf("unsupported value type %T", value)
+}
@@ -0,0 +1,127 @@
+package jotform_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	jotform "github.com/jotform/jotform-api-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilter(t *testing.T) {
+	since := time.Date(2013, 1, 1, 9, 30, 0, 0, time.UTC)
+
+	t.Run("happy - encodes conditions in order", func(t *testing.T) {
+		filter := jotform.Where("created_at").After(since).And("status").Ne("DELETED")
+
+		encoded, err := filter.Encode()
+		assert.Nil(t, err)
+		assert.Equal(t, `{"created_at:gt":"2013-01-01 09:30:00","status:ne":"DELETED"}`, encoded)
+	})
+
+	t.Run("happy - allows repeated fields", func(t *testing.T) {
+		filter := jotform.Where("id").Gt(int64(100)).And("id").Lte(200)
+
+		encoded, err := filter.Encode()
+		assert.Nil(t, err)
+		assert.Equal(t, `{"id:gt":"100","id:lte":"200"}`, encoded)
+
+		var decoded map[string]string
+		assert.Nil(t, json.Unmarshal([]byte(encoded), &decoded))
+		assert.Equal(t, map[string]string{"id:gt": "100", "id:lte": "200"}, decoded)
+	})
+
+	t.Run("sad - rejects a repeated field and operator", func(t *testing.T) {
+		filter := jotform.Where("status").Ne("DELETED").And("status").Ne("ARCHIVED")
+		assert.NotNil(t, filter.Err())
+		_, err := filter.Encode()
+		assert.NotNil(t, err)
+
+		filter = jotform.Where("status").Eq("ACTIVE").And("status").Op("eq", "ACTIVE")
+		assert.NotNil(t, filter.Err())
+
+		filter = jotform.FullText("Acme").FullText("Smith")
+		assert.NotNil(t, filter.Err())
+	})
+
+	t.Run("happy - question fields and full text", func(t *testing.T) {
+		filter := jotform.Where(jotform.QuestionField(3)).Matches("Smith").FullText("Acme")
+
+		encoded, err := filter.Encode()
+		assert.Nil(t, err)
+		assert.Equal(t, `{"q3:matches":"Smith","fullText":"Acme"}`, encoded)
+	})
+
+	t.Run("happy - params include orderby", func(t *testing.T) {
+		params, err := jotform.Where("status").Eq("ACTIVE").OrderBy("created_at").Params()
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{
+			"filter":  `{"status":"ACTIVE"}`,
+			"orderby": "created_at",
+		}, params)
+	})
+
+	t.Run("sad - rejects unknown operators", func(t *testing.T) {
+		filter := jotform.Where("created_at").Op("since", since).And("status").Ne("DELETED")
+
+		assert.NotNil(t, filter.Err())
+		_, err := filter.Encode()
+		assert.NotNil(t, err)
+	})
+
+	t.Run("sad - rejects fields with an operator", func(t *testing.T) {
+		filter := jotform.Where("created_at:gt").Eq("2013-01-01")
+
+		assert.NotNil(t, filter.Err())
+	})
+
+	t.Run("sad - rejects unsupported values", func(t *testing.T) {
+		filter := jotform.Where("id").Eq([]string{"1"})
+
+		assert.NotNil(t, filter.Err())
+	})
+}
+
+func TestListOptionsWhere(t *testing.T) {
+	t.Run("happy - sends filter and orderby", func(t *testing.T) {
+		var reqURL string
+		client := jotform.NewTestClient(newJSONClient(200, `{"responseCode":200,"content":[]}`, &reqURL))
+
+		_, err := client.GetFormSubmissionsTyped(context.Background(), 123, &jotform.ListOptions{
+			Limit: 10,
+			Where: jotform.Where("status").Ne("DELETED").OrderBy("created_at"),
+		})
+		assert.Nil(t, err)
+
+		parsed, _ := url.Parse(reqURL)
+		assert.Equal(t, url.Values{
+			"filter":  {`{"status:ne":"DELETED"}`},
+			"orderby": {"created_at"},
+			"limit":   {"10"},
+		}, parsed.Query())
+	})
+
+	t.Run("sad - invalid filter is not sent", func(t *testing.T) {
+		var reqURL string
+		client := jotform.NewTestClient(newJSONClient(200, `{"responseCode":200,"content":[]}`, &reqURL))
+
+		_, err := client.GetSubmissionsTyped(context.Background(), &jotform.ListOptions{
+			Where: jotform.Where("status").Op("like", "DEL"),
+		})
+		assert.NotNil(t, err)
+		assert.Equal(t, "", reqURL)
+	})
+
+	t.Run("sad - Filter and Where are exclusive", func(t *testing.T) {
+		client := jotform.NewTestClient(newJSONClient(200, `{"responseCode":200,"content":[]}`, nil))
+
+		_, err := client.GetFormsTyped(context.Background(), &jotform.ListOptions{
+			Filter: map[string]string{"status": "ENABLED"},
+			Where:  jotform.Where("status").Eq("ENABLED"),
+		})
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,234 @@
+package jotform
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SkipFolder is returned by a FolderTree.Walk function
+// to skip the subfolders of the current folder.
+var SkipFolder = errors.New("jotform: skip this folder")
+
+// FolderPathSeparator separates the folder names of a path, eg. "Clients/Acme/Intake".
+const FolderPathSeparator = "/"
+
+// CreateFolderTyped creates a folder named name within the folder parentID,
+// or within the root folder if parentID is empty.
+// color is a CSS color such as "#FF9900", or empty for the default.
+func (client Client) CreateFolderTyped(ctx context.Context, name string, color string, parentID string) (*Folder, error) {
+	if name == "" {
+		return nil, errors.New("jotform: folder name is required")
+	}
+
+	params := map[string]string{"name": name}
+	if color != "" {
+		params["color"] = color
+	}
+	if parentID != "" {
+		params["parent"] = parentID
+	}
+
+	var folder Folder
+	if err := client.decodeContent(ctx, "folder", params, "POST", &folder); err != nil {
+		return nil, err
+	}
+	return &folder, nil
+}
+
+// RenameFolder changes the name of a folder.
+func (client Client) RenameFolder(ctx context.Context, folderID string, name string) (*Folder, error) {
+	if name == "" {
+		return nil, errors.New("jotform: folder name is required")
+	}
+	return client.updateFolder(ctx, folderID, map[string]interface{}{"name": name})
+}
+
+// RecolorFolder changes the color of a folder.
+func (client Client) RecolorFolder(ctx context.Context, folderID string, color string) (*Folder, error) {
+	return client.updateFolder(ctx, folderID, map[string]interface{}{"color": color})
+}
+
+// MoveFolder moves a folder, along with its forms and subfolders, into the folder parentID.
+func (client Client) MoveFolder(ctx context.Context, folderID string, parentID string) (*Folder, error) {
+	if parentID == "" {
+		return nil, errors.New("jotform: parent folder is required")
+	}
+	if parentID == folderID {
+		return nil, fmt.Errorf("jotform: cannot move folder %s into itself", folderID)
+	}
+	return client.updateFolder(ctx, folderID, map[string]interface{}{"parent": parentID})
+}
+
+// DeleteFolderTyped deletes a folder and its subfolders.
+// Their forms are not deleted.
+func (client Client) DeleteFolderTyped(ctx context.Context, folderID string) error {
+	_, err := client.executeTyped(ctx, "folder/"+folderID, nil, "DELETE")
+	return err
+}
+
+// AddFormsToFolderTyped moves forms into a folder,
+// taking them out of any folder they were in.
+func (client Client) AddFormsToFolderTyped(ctx context.Context, folderID string, formIDs ...int64) (*Folder, error) {
+	return client.updateFolder(ctx, folderID, map[string]interface{}{"forms": formatIDs(formIDs)})
+}
+
+// RemoveFormsFromFolder takes forms out of a folder.
+// JotForm keeps every form in a folder, so they are moved to the root folder.
+func (client Client) RemoveFormsFromFolder(ctx context.Context, folderID string, formIDs ...int64) error {
+	root, err := client.GetFoldersTyped(ctx)
+	if err != nil {
+		return err
+	}
+	if root.ID == folderID {
+		return errors.New("jotform: cannot remove forms from the root folder")
+	}
+
+	_, err = client.updateFolder(ctx, root.ID, map[string]interface{}{"forms": formatIDs(formIDs)})
+	return err
+}
+
+func (client Client) updateFolder(ctx context.Context, folderID string, properties map[string]interface{}) (*Folder, error) {
+	if folderID == "" {
+		return nil, errors.New("jotform: folder ID is required")
+	}
+
+	body, err := json.Marshal(properties)
+	if err != nil {
+		return nil, err
+	}
+
+	var folder Folder
+	if err := client.decodeContent(ctx, "folder/"+folderID, body, "PUT", &folder); err != nil {
+		return nil, err
+	}
+	return &folder, nil
+}
+
+func formatIDs(ids []int64) []string {
+	formatted := make([]string, len(ids))
+	for i, id := range ids {
+		formatted[i] = strconv.FormatInt(id, 10)
+	}
+	return formatted
+}
+
+// FolderTree is the account's folders, from the root folder down,
+// addressed by paths of folder names such as "Clients/Acme/Intake".
+// The root folder's path is "".
+type FolderTree struct {
+	Root   *Folder
+	client Client
+}
+
+// GetFolderTree fetches the account's folders.
+func (client Client) GetFolderTree(ctx context.Context) (*FolderTree, error) {
+	root, err := client.GetFoldersTyped(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &FolderTree{Root: root, client: client}, nil
+}
+
+// Walk calls fn for every folder in the tree, parents before their subfolders.
+// If fn returns SkipFolder, the subfolders of that folder are skipped;
+// any other error stops the walk and is returned.
+func (t *FolderTree) Walk(fn func(path string, folder *Folder) error) error {
+	err := walkFolder("", t.Root, fn)
+	if err == SkipFolder {
+		return nil
+	}
+	return err
+}
+
+func walkFolder(path string, folder *Folder, fn func(path string, folder *Folder) error) error {
+	if err := fn(path, folder); err != nil {
+		return err
+	}
+
+	for _, subfolder := range folder.Subfolders {
+		err := walkFolder(joinFolderPath(path, subfolder.Name), subfolder, fn)
+		if err != nil && err != SkipFolder {
+			return err
+		}
+	}
+	return nil
+}
+
+// Find returns the folder at path, and false if there is none.
+func (t *FolderTree) Find(path string) (*Folder, bool) {
+	folder := t.Root
+	for _, name := range splitFolderPath(path) {
+		folder = subfolderNamed(folder, name)
+		if folder == nil {
+			return nil, false
+		}
+	}
+	return folder, true
+}
+
+// Path returns the path of the folder folderID, and false if it is not in the tree.
+func (t *FolderTree) Path(folderID string) (string, bool) {
+	var found string
+	var ok bool
+	t.Walk(func(path string, folder *Folder) error {
+		if folder.ID == folderID {
+			found, ok = path, true
+			return errStopWalk
+		}
+		return nil
+	})
+	return found, ok
+}
+
+var errStopWalk = errors.New("stop walk")
+
+// EnsurePath returns the folder at path,
+// first creating any folders on the path that don't exist yet.
+// New folders are added to the tree.
+func (t *FolderTree) EnsurePath(ctx context.Context, path string) (*Folder, error) {
+	folder := t.Root
+	for _, name := range splitFolderPath(path) {
+		if subfolder := subfolderNamed(folder, name); subfolder != nil {
+			folder = subfolder
+			continue
+		}
+
+		created, err := t.client.CreateFolderTyped(ctx, name, "", folder.ID)
+		if err != nil {
+			return nil, fmt.Errorf("jotform: creating folder %q: %w", name, err)
+		}
+		folder.Subfolders = append(folder.Subfolders, created)
+		folder = created
+	}
+	return folder, nil
+}
+
+func subfolderNamed(folder *Folder, name string) *Folder {
+	for _, subfolder := range folder.Subfolders {
+		if subfolder.Name == name {
+			return subfolder
+		}
+	}
+	return nil
+}
+
+func splitFolderPath(path string) []string {
+	var names []string
+	for _, name := range strings.Split(path, FolderPathSeparator) {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func joinFolderPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + FolderPathSeparator + name
+}
@@ -0,0 +1,171 @@
+package jotform_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	jotform "github.com/jotform/jotform-api-go/v2"
+	"github.com/jotform/jotform-api-go/v2/jotformtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFolders(t *testing.T) {
+	ctx := context.Background()
+
+	server := jotformtest.NewServer()
+	defer server.Close()
+	client := jotform.NewJotFormAPIClient("api-key", "json", false)
+	client.BaseURL = server.URL
+
+	intake := server.AddForm(jotform.Form{Title: "Intake"})
+	survey := server.AddForm(jotform.Form{Title: "Survey"})
+
+	t.Run("happy - create, rename, recolor and move", func(t *testing.T) {
+		clients, err := client.CreateFolderTyped(ctx, "Clients", "#FF9900", "")
+		assert.Nil(t, err)
+		assert.Equal(t, "Clients", clients.Name)
+		assert.Equal(t, "#FF9900", clients.Color)
+
+		archive, err := client.CreateFolderTyped(ctx, "Archive", "", "")
+		assert.Nil(t, err)
+
+		renamed, err := client.RenameFolder(ctx, clients.ID, "Customers")
+		assert.Nil(t, err)
+		assert.Equal(t, "Customers", renamed.Name)
+
+		recolored, err := client.RecolorFolder(ctx, clients.ID, "#000000")
+		assert.Nil(t, err)
+		assert.Equal(t, "#000000", recolored.Color)
+
+		moved, err := client.MoveFolder(ctx, clients.ID, archive.ID)
+		assert.Nil(t, err)
+		assert.Equal(t, archive.ID, moved.Parent)
+
+		tree, err := client.GetFolderTree(ctx)
+		assert.Nil(t, err)
+		found, ok := tree.Find("Archive/Customers")
+		assert.True(t, ok)
+		assert.Equal(t, clients.ID, found.ID)
+
+		assert.Nil(t, client.DeleteFolderTyped(ctx, archive.ID))
+		_, err = client.GetFolderTyped(ctx, clients.ID)
+		assert.True(t, errors.Is(err, jotform.ErrNotFound))
+	})
+
+	t.Run("happy - add and remove forms", func(t *testing.T) {
+		folder, err := client.CreateFolderTyped(ctx, "Forms", "", "")
+		assert.Nil(t, err)
+
+		folder, err = client.AddFormsToFolderTyped(ctx, folder.ID, int64(intake.ID), int64(survey.ID))
+		assert.Nil(t, err)
+		assert.Len(t, folder.Forms, 2)
+
+		err = client.RemoveFormsFromFolder(ctx, folder.ID, int64(survey.ID))
+		assert.Nil(t, err)
+
+		folder, err = client.GetFolderTyped(ctx, folder.ID)
+		assert.Nil(t, err)
+		assert.Len(t, folder.Forms, 1)
+		assert.Equal(t, intake.ID, folder.Forms[0].ID)
+
+		root, err := client.GetFoldersTyped(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, survey.ID, root.Forms[0].ID)
+	})
+
+	t.Run("sad - invalid moves", func(t *testing.T) {
+		parent, _ := client.CreateFolderTyped(ctx, "Parent", "", "")
+		child, _ := client.CreateFolderTyped(ctx, "Child", "", parent.ID)
+
+		_, err := client.MoveFolder(ctx, parent.ID, parent.ID)
+		assert.NotNil(t, err)
+
+		_, err = client.MoveFolder(ctx, parent.ID, child.ID)
+		assert.True(t, errors.Is(err, jotform.ErrBadRequest))
+
+		_, err = client.CreateFolderTyped(ctx, "", "", "")
+		assert.NotNil(t, err)
+	})
+}
+
+func TestFolderTree(t *testing.T) {
+	ctx := context.Background()
+
+	server := jotformtest.NewServer()
+	defer server.Close()
+	client := jotform.NewJotFormAPIClient("api-key", "json", false)
+	client.BaseURL = server.URL
+
+	t.Run("happy - ensure path creates missing folders once", func(t *testing.T) {
+		tree, err := client.GetFolderTree(ctx)
+		assert.Nil(t, err)
+
+		intake, err := tree.EnsurePath(ctx, "Clients/Acme/Intake")
+		assert.Nil(t, err)
+		assert.Equal(t, "Intake", intake.Name)
+
+		again, err := tree.EnsurePath(ctx, "/Clients/Acme/Intake/")
+		assert.Nil(t, err)
+		assert.Equal(t, intake.ID, again.ID)
+
+		other, err := tree.EnsurePath(ctx, "Clients/Other")
+		assert.Nil(t, err)
+
+		fresh, err := client.GetFolderTree(ctx)
+		assert.Nil(t, err)
+		found, ok := fresh.Find("Clients/Acme/Intake")
+		assert.True(t, ok)
+		assert.Equal(t, intake.ID, found.ID)
+
+		path, ok := fresh.Path(other.ID)
+		assert.True(t, ok)
+		assert.Equal(t, "Clients/Other", path)
+
+		_, ok = fresh.Find("Clients/Missing")
+		assert.False(t, ok)
+	})
+
+	t.Run("happy - folders stay valid as subfolders are added around them", func(t *testing.T) {
+		tree, err := client.GetFolderTree(ctx)
+		assert.Nil(t, err)
+
+		first, err := tree.EnsurePath(ctx, "Regions/North")
+		assert.Nil(t, err)
+		for _, name := range []string{"East", "South", "West", "Central"} {
+			_, err := tree.EnsurePath(ctx, "Regions/"+name)
+			assert.Nil(t, err)
+		}
+
+		leads, err := tree.EnsurePath(ctx, "Regions/North/Leads")
+		assert.Nil(t, err)
+		found, ok := tree.Find("Regions/North")
+		assert.True(t, ok)
+		assert.Same(t, first, found)
+		if assert.Equal(t, 1, len(first.Subfolders)) {
+			assert.Same(t, leads, first.Subfolders[0])
+		}
+	})
+
+	t.Run("happy - walk visits parents first and can skip", func(t *testing.T) {
+		tree := &jotform.FolderTree{Root: &jotform.Folder{ID: "root", Subfolders: []*jotform.Folder{
+			{ID: "a", Name: "A", Subfolders: []*jotform.Folder{{ID: "a1", Name: "A1"}}},
+			{ID: "b", Name: "B", Subfolders: []*jotform.Folder{{ID: "b1", Name: "B1"}}},
+		}}}
+
+		var paths []string
+		err := tree.Walk(func(path string, folder *jotform.Folder) error {
+			paths = append(paths, path)
+			if folder.ID == "b" {
+				return jotform.SkipFolder
+			}
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"", "A", "A/A1", "B"}, paths)
+
+		stop := errors.New("stop")
+		err = tree.Walk(func(path string, folder *jotform.Folder) error { return stop })
+		assert.Equal(t, stop, err)
+	})
+}
@@ -0,0 +1,72 @@
+package formspec
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	jotform "github.com/jotform/jotform-api-go/v2"
+)
+
+// Apply makes the changes of a plan, in order.
+// If the plan creates the form, the new form's ID is set on plan.Spec.
+// Apply stops at the first change that fails; planning again shows what is left to do.
+func Apply(ctx context.Context, client Client, plan *FormPlan) error {
+	formID := plan.Spec.ID
+
+	for _, change := range plan.Changes {
+		var err error
+		switch change.Kind {
+		case CreateForm:
+			formID, err = createForm(ctx, client, change.properties)
+			if err == nil {
+				plan.Spec.ID = formID
+			}
+		case SetProperties:
+			_, err = client.SetFormPropertiesContext(ctx, formID, change.properties)
+		case CreateQuestion:
+			_, err = client.CreateFormQuestionContext(ctx, formID, change.properties)
+		case UpdateQuestion:
+			_, err = client.EditFormQuestionContext(ctx, formID, change.QID, change.properties)
+		case ReplaceQuestion:
+			_, err = client.DeleteFormQuestionContext(ctx, formID, change.QID)
+			if err == nil {
+				_, err = client.CreateFormQuestionContext(ctx, formID, change.properties)
+			}
+		case DeleteQuestion:
+			_, err = client.DeleteFormQuestionContext(ctx, formID, change.QID)
+		case AddWebhook:
+			_, err = client.CreateFormWebhookTyped(ctx, formID, change.Name)
+		case RemoveWebhook:
+			_, err = client.DeleteFormWebhookTyped(ctx, formID, change.WebhookID)
+		default:
+			err = fmt.Errorf("unknown change %q", change.Kind)
+		}
+
+		if err != nil {
+			if change.Name != "" {
+				return fmt.Errorf("formspec: %s %q: %w", change.Kind, change.Name, err)
+			}
+			return fmt.Errorf("formspec: %s: %w", change.Kind, err)
+		}
+	}
+	return nil
+}
+
+// createForm creates a form with properties, returning its ID.
+// Its questions are created by the changes that follow.
+func createForm(ctx context.Context, client Client, properties map[string]string) (int64, error) {
+	content, err := client.CreateFormContext(ctx, map[string]interface{}{"properties": properties})
+	if err != nil {
+		return 0, err
+	}
+
+	var form jotform.Form
+	if err := json.Unmarshal(content, &form); err != nil {
+		return 0, err
+	}
+	if form.ID == 0 {
+		return 0, fmt.Errorf("no form ID in response: %s", content)
+	}
+	return int64(form.ID), nil
+}
@@ -0,0 +1,209 @@
+package formspec_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	jotform "github.com/jotform/jotform-api-go/v2"
+	"github.com/jotform/jotform-api-go/v2/formspec"
+	"github.com/jotform/jotform-api-go/v2/jotformtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("happy - load a spec", func(t *testing.T) {
+		spec, err := formspec.Load("testdata/intake.json")
+		assert.NoError(t, err)
+		assert.Equal(t, "Patient intake", spec.Title)
+		assert.Len(t, spec.Questions, 3)
+		assert.True(t, spec.Questions[0].Required)
+		assert.Equal(t, "Check-up|Illness|Injury", spec.Questions[2].Properties["options"])
+	})
+
+	for name, data := range map[string]string{
+		"unknown field":       `{"title": "A", "question": []}`,
+		"no title":            `{"questions": []}`,
+		"no question name":    `{"title": "A", "questions": [{"type": "control_textbox"}]}`,
+		"no question type":    `{"title": "A", "questions": [{"name": "a"}]}`,
+		"duplicate question":  `{"title": "A", "questions": [{"name": "a", "type": "control_textbox"}, {"name": "a", "type": "control_email"}]}`,
+		"reserved property":   `{"title": "A", "questions": [{"name": "a", "type": "control_textbox", "properties": {"order": "3"}}]}`,
+		"not a json document": `title: A`,
+	} {
+		t.Run("sad - "+name, func(t *testing.T) {
+			_, err := formspec.Parse([]byte(data))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPlanAndApply(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("happy - create a form", func(t *testing.T) {
+		server := jotformtest.NewServer()
+		defer server.Close()
+		client := jotform.NewJotFormAPIClient("api-key", "json", false)
+		client.BaseURL = server.URL
+
+		spec, err := formspec.Load("testdata/intake.json")
+		assert.NoError(t, err)
+
+		plan, err := formspec.Plan(ctx, client, spec)
+		assert.NoError(t, err)
+		assert.Equal(t, formspec.CreateForm, plan.Changes[0].Kind)
+		assert.Contains(t, plan.String(), `form "Patient intake" (new)`)
+		assert.Contains(t, plan.String(), `+ question "reason"`)
+		assert.Contains(t, plan.String(), "5 to add, 0 to change, 0 to remove")
+
+		assert.NoError(t, formspec.Apply(ctx, client, plan))
+		assert.NotZero(t, spec.ID)
+
+		questions, err := client.GetFormQuestionsTyped(ctx, spec.ID)
+		assert.NoError(t, err)
+		assert.Len(t, questions, 3)
+		webhooks, err := client.GetFormWebhooksTyped(ctx, spec.ID)
+		assert.NoError(t, err)
+		assert.Len(t, webhooks, 1)
+
+		// Applying again changes nothing.
+		plan, err = formspec.Plan(ctx, client, spec)
+		assert.NoError(t, err)
+		assert.True(t, plan.Empty(), plan.String())
+	})
+
+	t.Run("happy - update a form", func(t *testing.T) {
+		server := jotformtest.NewServer()
+		defer server.Close()
+		client := jotform.NewJotFormAPIClient("api-key", "json", false)
+		client.BaseURL = server.URL
+
+		form := server.AddForm(jotform.Form{Title: "Intake"},
+			jotform.Question{Name: "fullName", Type: "control_fullname", Text: "Name", Order: 1},
+			jotform.Question{Name: "email", Type: "control_textbox", Text: "Email", Order: 2},
+			jotform.Question{Name: "fax", Type: "control_textbox", Text: "Fax", Order: 3},
+		)
+		webhooks, err := client.CreateFormWebhookTyped(ctx, int64(form.ID), "https://example.com/old")
+		assert.NoError(t, err)
+
+		spec, err := formspec.Load("testdata/intake.json")
+		assert.NoError(t, err)
+		spec.ID = int64(form.ID)
+
+		plan, err := formspec.Plan(ctx, client, spec)
+		assert.NoError(t, err)
+
+		kinds := make(map[formspec.ChangeKind][]string)
+		for _, change := range plan.Changes {
+			kinds[change.Kind] = append(kinds[change.Kind], change.Name)
+			if change.Kind == formspec.RemoveWebhook {
+				assert.Equal(t, int64(webhooks[0].ID), change.WebhookID)
+				assert.Zero(t, change.QID)
+			}
+		}
+		assert.Equal(t, map[formspec.ChangeKind][]string{
+			formspec.SetProperties:   {""},
+			formspec.DeleteQuestion:  {"fax"},
+			formspec.UpdateQuestion:  {"fullName"},
+			formspec.ReplaceQuestion: {"email"},
+			formspec.CreateQuestion:  {"reason"},
+			formspec.RemoveWebhook:   {"https://example.com/old"},
+			formspec.AddWebhook:      {"https://example.com/hooks/intake"},
+		}, kinds)
+		assert.Contains(t, plan.String(), `title: "Intake" => "Patient intake"`)
+		assert.Contains(t, plan.String(), `-/+ question "email"`)
+		assert.Contains(t, plan.String(), `- question "fax"`)
+
+		assert.NoError(t, formspec.Apply(ctx, client, plan))
+
+		plan, err = formspec.Plan(ctx, client, spec)
+		assert.NoError(t, err)
+		assert.True(t, plan.Empty(), plan.String())
+
+		updated, err := client.GetFormTyped(ctx, spec.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "Patient intake", updated.Title)
+	})
+
+	t.Run("happy - unmanaged webhooks and properties are left alone", func(t *testing.T) {
+		server := jotformtest.NewServer()
+		defer server.Close()
+		client := jotform.NewJotFormAPIClient("api-key", "json", false)
+		client.BaseURL = server.URL
+
+		form := server.AddForm(jotform.Form{Title: "Intake"},
+			jotform.Question{Name: "email", Type: "control_email", Text: "Email", Order: 1,
+				Properties: map[string]json.RawMessage{"hint": []byte(`"you@example.com"`)}},
+			jotform.Question{Name: "submit2", Type: "control_button", Text: "Submit", Order: 2},
+		)
+		_, err := client.CreateFormWebhookTyped(ctx, int64(form.ID), "https://example.com/old")
+		assert.NoError(t, err)
+
+		spec := &formspec.Spec{
+			ID:        int64(form.ID),
+			Title:     "Intake",
+			Questions: []formspec.Question{{Name: "email", Type: "control_email", Text: "Email"}},
+		}
+		plan, err := formspec.Plan(ctx, client, spec)
+		assert.NoError(t, err)
+		assert.True(t, plan.Empty(), plan.String())
+		assert.Contains(t, plan.String(), "no changes")
+	})
+
+	t.Run("happy - a declared submit button replaces the form's own", func(t *testing.T) {
+		server := jotformtest.NewServer()
+		defer server.Close()
+		client := jotform.NewJotFormAPIClient("api-key", "json", false)
+		client.BaseURL = server.URL
+
+		form := server.AddForm(jotform.Form{Title: "Intake"},
+			jotform.Question{Name: "submit2", Type: "control_button", Text: "Submit", Order: 1},
+		)
+		spec := &formspec.Spec{
+			ID:        int64(form.ID),
+			Title:     "Intake",
+			Questions: []formspec.Question{{Name: "send", Type: "control_button", Text: "Send"}},
+		}
+		plan, err := formspec.Plan(ctx, client, spec)
+		assert.NoError(t, err)
+		if assert.Len(t, plan.Changes, 2) {
+			assert.Equal(t, formspec.Change{Kind: formspec.DeleteQuestion, Name: "submit2", QID: 1}, plan.Changes[0])
+			assert.Equal(t, formspec.CreateQuestion, plan.Changes[1].Kind)
+		}
+	})
+
+	t.Run("sad - form not found", func(t *testing.T) {
+		server := jotformtest.NewServer()
+		defer server.Close()
+		client := jotform.NewJotFormAPIClient("api-key", "json", false)
+		client.BaseURL = server.URL
+
+		_, err := formspec.Plan(ctx, client, &formspec.Spec{ID: 404, Title: "Missing"})
+		assert.ErrorIs(t, err, jotform.ErrNotFound)
+	})
+
+	t.Run("sad - apply stops at the first failure", func(t *testing.T) {
+		server := jotformtest.NewServer()
+		defer server.Close()
+		client := jotform.NewJotFormAPIClient("api-key", "json", false)
+		client.BaseURL = server.URL
+		client.Retry = nil
+
+		form := server.AddForm(jotform.Form{Title: "Intake"})
+		spec := &formspec.Spec{
+			ID:        int64(form.ID),
+			Title:     "Intake",
+			Questions: []formspec.Question{{Name: "email", Type: "control_email", Text: "Email"}},
+		}
+		plan, err := formspec.Plan(ctx, client, spec)
+		assert.NoError(t, err)
+
+		server.InjectFault(jotformtest.Fault{Method: "POST", Path: fmt.Sprintf("form/%d/questions", form.ID), StatusCode: 500})
+		err = formspec.Apply(ctx, client, plan)
+		assert.ErrorIs(t, err, jotform.ErrServerError)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), `create question "email"`)
+		}
+	})
+}
@@ -0,0 +1,336 @@
+package formspec
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	jotform "github.com/jotform/jotform-api-go/v2"
+)
+
+// Client is the part of the jotform client used to plan and apply specs.
+type Client interface {
+	GetFormTyped(ctx context.Context, formID int64) (*jotform.Form, error)
+	GetFormQuestionsTyped(ctx context.Context, formID int64) ([]jotform.Question, error)
+	GetFormPropertiesContext(ctx context.Context, formID int64) ([]byte, error)
+	GetFormWebhooksTyped(ctx context.Context, formID int64) ([]jotform.Webhook, error)
+	CreateFormContext(ctx context.Context, form map[string]interface{}) ([]byte, error)
+	CreateFormQuestionContext(ctx context.Context, formID int64, questionProperties map[string]string) ([]byte, error)
+	EditFormQuestionContext(ctx context.Context, formID int64, qid int, questionProperties map[string]string) ([]byte, error)
+	DeleteFormQuestionContext(ctx context.Context, formID int64, qid int) ([]byte, error)
+	SetFormPropertiesContext(ctx context.Context, formID int64, formProperties map[string]string) ([]byte, error)
+	CreateFormWebhookTyped(ctx context.Context, formID int64, webhookURL string) ([]jotform.Webhook, error)
+	DeleteFormWebhookTyped(ctx context.Context, formID int64, webhookID int64) ([]jotform.Webhook, error)
+}
+
+// ChangeKind is the kind of a Change.
+type ChangeKind string
+
+// The kinds of change a plan can make.
+const (
+	CreateForm     ChangeKind = "create form"
+	SetProperties  ChangeKind = "set properties"
+	CreateQuestion ChangeKind = "create question"
+	UpdateQuestion ChangeKind = "update question"
+	// ReplaceQuestion deletes and recreates a question whose type has changed,
+	// as JotForm can't change the type of a question.
+	ReplaceQuestion ChangeKind = "replace question"
+	DeleteQuestion  ChangeKind = "delete question"
+	AddWebhook      ChangeKind = "add webhook"
+	RemoveWebhook   ChangeKind = "remove webhook"
+)
+
+// Change is one change a plan makes to a form.
+type Change struct {
+	Kind ChangeKind
+	// Name is the name of the question, or the URL of the webhook, changed.
+	Name string
+	// QID is the question changed, on the existing form.
+	QID int
+	// WebhookID is the webhook removed, on the existing form.
+	WebhookID int64
+	// Diffs are the properties changed, in key order.
+	Diffs []Diff
+
+	properties map[string]string
+}
+
+// Diff is a property changed from Old to New.
+// Old is empty for a property being set for the first time.
+type Diff struct {
+	Key string
+	Old string
+	New string
+}
+
+// FormPlan is the list of changes that makes a form match its spec.
+type FormPlan struct {
+	Spec    *Spec
+	Changes []Change
+}
+
+// Empty reports whether the form already matches its spec.
+func (p *FormPlan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// String describes the changes, one per line, with "+" for additions,
+// "-" for removals, "~" for changes and "-/+" for replacements.
+func (p *FormPlan) String() string {
+	var b strings.Builder
+	if p.Spec.ID == 0 {
+		fmt.Fprintf(&b, "form %q (new)\n", p.Spec.Title)
+	} else {
+		fmt.Fprintf(&b, "form %d %q\n", p.Spec.ID, p.Spec.Title)
+	}
+	if p.Empty() {
+		b.WriteString("  no changes\n")
+		return b.String()
+	}
+
+	for _, change := range p.Changes {
+		switch change.Kind {
+		case CreateForm:
+			b.WriteString("  + create form\n")
+		case SetProperties:
+			b.WriteString("  ~ properties\n")
+		case CreateQuestion:
+			fmt.Fprintf(&b, "  + question %q\n", change.Name)
+		case UpdateQuestion:
+			fmt.Fprintf(&b, "  ~ question %q (qid %d)\n", change.Name, change.QID)
+		case ReplaceQuestion:
+			fmt.Fprintf(&b, "-/+ question %q (qid %d)\n", change.Name, change.QID)
+		case DeleteQuestion:
+			fmt.Fprintf(&b, "  - question %q (qid %d)\n", change.Name, change.QID)
+		case AddWebhook:
+			fmt.Fprintf(&b, "  + webhook %s\n", change.Name)
+		case RemoveWebhook:
+			fmt.Fprintf(&b, "  - webhook %s\n", change.Name)
+		}
+
+		for _, diff := range change.Diffs {
+			if diff.Old == "" {
+				fmt.Fprintf(&b, "      %s: %q\n", diff.Key, diff.New)
+			} else {
+				fmt.Fprintf(&b, "      %s: %q => %q\n", diff.Key, diff.Old, diff.New)
+			}
+		}
+	}
+	fmt.Fprintf(&b, "%d to add, %d to change, %d to remove\n", p.count(CreateQuestion, AddWebhook, CreateForm),
+		p.count(UpdateQuestion, ReplaceQuestion, SetProperties), p.count(DeleteQuestion, RemoveWebhook))
+	return b.String()
+}
+
+func (p *FormPlan) count(kinds ...ChangeKind) int {
+	n := 0
+	for _, change := range p.Changes {
+		for _, kind := range kinds {
+			if change.Kind == kind {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// Plan compares a spec with its form, returning the changes that make the form match it.
+// Nothing is changed, so printing the plan is a dry run of Apply.
+func Plan(ctx context.Context, client Client, spec *Spec) (*FormPlan, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	plan := &FormPlan{Spec: spec}
+	if spec.ID == 0 {
+		plan.planNewForm()
+		return plan, nil
+	}
+
+	form, err := client.GetFormTyped(ctx, spec.ID)
+	if err != nil {
+		return nil, fmt.Errorf("formspec: getting form %d: %w", spec.ID, err)
+	}
+	properties, err := formProperties(ctx, client, spec.ID)
+	if err != nil {
+		return nil, err
+	}
+	questions, err := client.GetFormQuestionsTyped(ctx, spec.ID)
+	if err != nil {
+		return nil, fmt.Errorf("formspec: getting questions of form %d: %w", spec.ID, err)
+	}
+
+	// Properties, including the title.
+	declared := map[string]string{"title": spec.Title}
+	for key, value := range spec.Properties {
+		declared[key] = value
+	}
+	properties["title"] = form.Title
+	if diffs := diff(declared, properties); len(diffs) > 0 {
+		plan.Changes = append(plan.Changes, Change{Kind: SetProperties, Diffs: diffs, properties: changed(diffs)})
+	}
+
+	// Questions, matched by name.
+	byName := make(map[string]jotform.Question, len(questions))
+	for _, question := range questions {
+		byName[question.Name] = question
+	}
+	var creates, updates []Change
+	for i, question := range spec.Questions {
+		declared := spec.questionProperties(i)
+		existing, ok := byName[question.Name]
+		delete(byName, question.Name)
+
+		switch {
+		case !ok:
+			creates = append(creates, Change{Kind: CreateQuestion, Name: question.Name, Diffs: diff(declared, nil), properties: declared})
+		case existing.Type != question.Type:
+			updates = append(updates, Change{
+				Kind: ReplaceQuestion, Name: question.Name, QID: int(existing.QID),
+				Diffs: diff(declared, questionProperties(existing)), properties: declared,
+			})
+		default:
+			if diffs := diff(declared, questionProperties(existing)); len(diffs) > 0 {
+				updates = append(updates, Change{
+					Kind: UpdateQuestion, Name: question.Name, QID: int(existing.QID),
+					Diffs: diffs, properties: changed(diffs),
+				})
+			}
+		}
+	}
+	// Deletions come first, so that the names they free can be reused.
+	// The submit button is kept unless the spec declares its own.
+	keepButton := !spec.hasType("control_button")
+	for _, question := range questions {
+		if keepButton && question.Type == "control_button" {
+			continue
+		}
+		if _, ok := byName[question.Name]; ok {
+			plan.Changes = append(plan.Changes, Change{Kind: DeleteQuestion, Name: question.Name, QID: int(question.QID)})
+		}
+	}
+	plan.Changes = append(plan.Changes, updates...)
+	plan.Changes = append(plan.Changes, creates...)
+
+	// Webhooks, matched by URL.
+	if spec.Webhooks != nil {
+		webhooks, err := client.GetFormWebhooksTyped(ctx, spec.ID)
+		if err != nil {
+			return nil, fmt.Errorf("formspec: getting webhooks of form %d: %w", spec.ID, err)
+		}
+		existing := make(map[string]bool, len(webhooks))
+		for _, webhook := range webhooks {
+			existing[webhook.URL] = true
+		}
+		declared := make(map[string]bool, len(spec.Webhooks))
+		for _, url := range spec.Webhooks {
+			declared[url] = true
+		}
+
+		for _, webhook := range webhooks {
+			if !declared[webhook.URL] {
+				plan.Changes = append(plan.Changes, Change{Kind: RemoveWebhook, Name: webhook.URL, WebhookID: int64(webhook.ID)})
+			}
+		}
+		for _, url := range spec.Webhooks {
+			if !existing[url] {
+				plan.Changes = append(plan.Changes, Change{Kind: AddWebhook, Name: url})
+				existing[url] = true
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+// planNewForm plans the creation of a form that doesn't exist yet.
+func (p *FormPlan) planNewForm() {
+	properties := map[string]string{"title": p.Spec.Title}
+	for key, value := range p.Spec.Properties {
+		properties[key] = value
+	}
+	p.Changes = append(p.Changes, Change{Kind: CreateForm, Name: p.Spec.Title, Diffs: diff(properties, nil), properties: properties})
+
+	for i, question := range p.Spec.Questions {
+		declared := p.Spec.questionProperties(i)
+		p.Changes = append(p.Changes, Change{Kind: CreateQuestion, Name: question.Name, Diffs: diff(declared, nil), properties: declared})
+	}
+	seen := make(map[string]bool)
+	for _, url := range p.Spec.Webhooks {
+		if !seen[url] {
+			p.Changes = append(p.Changes, Change{Kind: AddWebhook, Name: url})
+			seen[url] = true
+		}
+	}
+}
+
+// formProperties returns a form's properties as strings.
+func formProperties(ctx context.Context, client Client, formID int64) (map[string]string, error) {
+	content, err := client.GetFormPropertiesContext(ctx, formID)
+	if err != nil {
+		return nil, fmt.Errorf("formspec: getting properties of form %d: %w", formID, err)
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(content, &raw); err != nil {
+		return nil, fmt.Errorf("formspec: decoding properties of form %d: %w", formID, err)
+	}
+	properties := make(map[string]string, len(raw))
+	for key, value := range raw {
+		properties[key] = stringValue(value)
+	}
+	return properties, nil
+}
+
+// questionProperties returns the properties of a question as strings.
+func questionProperties(question jotform.Question) map[string]string {
+	properties := make(map[string]string, len(question.Properties))
+	for key := range question.Properties {
+		properties[key] = question.Property(key)
+	}
+	// Normalise the fields JotForm may send in other forms.
+	properties["order"] = strconv.FormatInt(int64(question.Order), 10)
+	if question.Required {
+		properties["required"] = "Yes"
+	} else {
+		properties["required"] = "No"
+	}
+	return properties
+}
+
+// stringValue returns a JSON string's value, or other JSON as it is.
+func stringValue(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	return string(raw)
+}
+
+// diff returns the declared properties whose values differ from the existing ones, in key order.
+func diff(declared map[string]string, existing map[string]string) []Diff {
+	keys := make([]string, 0, len(declared))
+	for key := range declared {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var diffs []Diff
+	for _, key := range keys {
+		if old := existing[key]; old != declared[key] {
+			diffs = append(diffs, Diff{Key: key, Old: old, New: declared[key]})
+		}
+	}
+	return diffs
+}
+
+// changed returns the new values of diffs.
+func changed(diffs []Diff) map[string]string {
+	properties := make(map[string]string, len(diffs))
+	for _, d := range diffs {
+		properties[d.Key] = d.New
+	}
+	return properties
+}
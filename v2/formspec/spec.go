@@ -0,0 +1,133 @@
+// Package formspec manages forms as code.
+// A Spec declares a form's title, properties, questions and webhooks in JSON;
+// Plan compares it with the form on JotForm, and Apply makes the changes the plan lists.
+//
+//	spec, err := formspec.Load("intake.json")
+//	plan, err := formspec.Plan(ctx, client, spec)
+//	fmt.Print(plan) // review the changes, as in a dry run
+//	err = formspec.Apply(ctx, client, plan)
+package formspec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+)
+
+// Spec is the declared state of a form.
+type Spec struct {
+	// ID is the form the spec manages. If zero, Apply creates a new form and sets ID.
+	ID    int64  `json:"id,omitempty"`
+	Title string `json:"title"`
+	// Properties are form properties such as "thankYouText".
+	// Properties not listed are left as they are.
+	Properties map[string]string `json:"properties,omitempty"`
+	// Questions are the form's questions, in order.
+	// Questions on the form but not in the spec are deleted,
+	// except for submit buttons (control_button) if the spec declares none.
+	Questions []Question `json:"questions"`
+	// Webhooks are the URLs the form posts submissions to.
+	// If nil, webhooks are left as they are; otherwise webhooks not listed are removed.
+	Webhooks []string `json:"webhooks,omitempty"`
+}
+
+// Question is the declared state of a question.
+type Question struct {
+	// Name identifies the question on the form, and must be unique within the spec.
+	Name string `json:"name"`
+	// Type is the question type, eg. "control_textbox".
+	Type     string `json:"type"`
+	Text     string `json:"text"`
+	Required bool   `json:"required,omitempty"`
+	// Properties are other question properties, such as "options" or "hint".
+	// Properties not listed are left as they are.
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// Load reads a Spec from a JSON file.
+func Load(path string) (*Spec, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return spec, nil
+}
+
+// Parse decodes and validates a Spec. Unknown fields are an error, to catch typos.
+func Parse(data []byte) (*Spec, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var spec Spec
+	if err := decoder.Decode(&spec); err != nil {
+		return nil, fmt.Errorf("formspec: %w", err)
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// Validate checks the spec is complete and its question names are unique.
+func (s *Spec) Validate() error {
+	if s.Title == "" {
+		return fmt.Errorf("formspec: title is required")
+	}
+
+	names := make(map[string]bool, len(s.Questions))
+	for i, question := range s.Questions {
+		if question.Name == "" {
+			return fmt.Errorf("formspec: question %d has no name", i+1)
+		}
+		if question.Type == "" {
+			return fmt.Errorf("formspec: question %q has no type", question.Name)
+		}
+		if names[question.Name] {
+			return fmt.Errorf("formspec: question name %q is used twice", question.Name)
+		}
+		names[question.Name] = true
+
+		for _, key := range []string{"name", "type", "text", "required", "order", "qid"} {
+			if _, ok := question.Properties[key]; ok {
+				return fmt.Errorf("formspec: question %q: set %s with its own field, not in properties", question.Name, key)
+			}
+		}
+	}
+	return nil
+}
+
+// questionProperties returns the question properties the spec declares for question i.
+func (s *Spec) questionProperties(i int) map[string]string {
+	question := s.Questions[i]
+
+	properties := make(map[string]string, len(question.Properties)+5)
+	for key, value := range question.Properties {
+		properties[key] = value
+	}
+	properties["name"] = question.Name
+	properties["type"] = question.Type
+	properties["text"] = question.Text
+	properties["order"] = strconv.Itoa(i + 1)
+	if question.Required {
+		properties["required"] = "Yes"
+	} else {
+		properties["required"] = "No"
+	}
+	return properties
+}
+
+// hasType reports whether the spec declares a question of type typ.
+func (s *Spec) hasType(typ string) bool {
+	for _, question := range s.Questions {
+		if question.Type == typ {
+			return true
+		}
+	}
+	return false
+}
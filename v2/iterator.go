@@ -0,0 +1,244 @@
+package jotform
+
+import (
+	"context"
+	"iter"
+	"strconv"
+)
+
+// DefaultPageSize is the page size iterators use when IterOptions.Limit is zero.
+const DefaultPageSize = 100
+
+// MaxPageSize is the most results JotForm returns in a page.
+// Iterators fetch larger limits a page of MaxPageSize at a time.
+const MaxPageSize = 1000
+
+// ResultSet describes a page of results, as reported by JotForm.
+type ResultSet struct {
+	Offset Int `json:"offset"`
+	Limit  Int `json:"limit"`
+	Count  Int `json:"count"`
+}
+
+// IterOptions configures an iterator.
+// Offset is where iteration starts, and Limit is the page size.
+type IterOptions struct {
+	ListOptions
+	// Prefetch fetches the next page in the background
+	// while the current page is being consumed.
+	Prefetch bool
+}
+
+// fetchPage fetches the page selected by opts.
+type fetchPage[T any] func(ctx context.Context, opts *ListOptions) ([]T, *ResultSet, error)
+
+type pageResult[T any] struct {
+	items     []T
+	resultSet ResultSet
+	err       error
+}
+
+// pager lazily walks the pages of a list endpoint.
+type pager[T any] struct {
+	ctx      context.Context
+	fetch    fetchPage[T]
+	opts     ListOptions
+	prefetch bool
+	// unpaged endpoints return everything in their first page.
+	unpaged bool
+
+	page      []T
+	index     int
+	current   T
+	resultSet ResultSet
+	last      bool
+	pending   chan pageResult[T]
+	err       error
+}
+
+func newPager[T any](ctx context.Context, fetch fetchPage[T], opts *IterOptions) *pager[T] {
+	p := &pager[T]{ctx: ctx, fetch: fetch}
+	if opts != nil {
+		p.opts = opts.ListOptions
+		p.prefetch = opts.Prefetch
+	}
+	if p.opts.Limit <= 0 {
+		p.opts.Limit = DefaultPageSize
+	}
+	if p.opts.Limit > MaxPageSize {
+		p.opts.Limit = MaxPageSize
+	}
+	return p
+}
+
+// Next advances to the next item, fetching the next page if needed.
+// It returns false when there are no more items or an error occurred.
+func (p *pager[T]) Next() bool {
+	for p.index >= len(p.page) {
+		if p.err != nil || p.last {
+			return false
+		}
+
+		var result pageResult[T]
+		if p.pending != nil {
+			result = <-p.pending
+			p.pending = nil
+		} else {
+			result = p.fetchAt(p.opts)
+		}
+		p.receive(result)
+	}
+
+	p.current = p.page[p.index]
+	p.index++
+	return true
+}
+
+func (p *pager[T]) fetchAt(opts ListOptions) pageResult[T] {
+	items, resultSet, err := p.fetch(p.ctx, &opts)
+	result := pageResult[T]{items: items, err: err}
+	if resultSet != nil {
+		result.resultSet = *resultSet
+	} else {
+		result.resultSet = ResultSet{Offset: Int(opts.Offset), Limit: Int(opts.Limit), Count: Int(len(items))}
+	}
+	return result
+}
+
+func (p *pager[T]) receive(result pageResult[T]) {
+	if result.err != nil {
+		p.err = result.err
+		return
+	}
+
+	p.page = result.items
+	p.index = 0
+	p.resultSet = result.resultSet
+	p.opts.Offset += len(result.items)
+	// A short page is the last one, measured against the limit the server used,
+	// which may be lower than the one asked for.
+	limit := p.opts.Limit
+	if served := int(result.resultSet.Limit); served > 0 && served < limit {
+		limit = served
+	}
+	p.last = p.unpaged || len(result.items) < limit
+
+	if p.prefetch && !p.last {
+		p.pending = make(chan pageResult[T], 1)
+		go func(opts ListOptions, pending chan<- pageResult[T]) {
+			pending <- p.fetchAt(opts)
+		}(p.opts, p.pending)
+	}
+}
+
+// Err returns the error that stopped iteration, if any.
+func (p *pager[T]) Err() error {
+	return p.err
+}
+
+// ResultSet returns the paging metadata of the page holding the current item.
+func (p *pager[T]) ResultSet() ResultSet {
+	return p.resultSet
+}
+
+// All returns the remaining items as a sequence for use with range.
+// An error ends the sequence, and is yielded with the zero value.
+func (p *pager[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for p.Next() {
+			if !yield(p.current, nil) {
+				return
+			}
+		}
+		if err := p.Err(); err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+// SubmissionIterator is a cursor over submissions, fetched a page at a time.
+//
+//	it := client.IterFormSubmissions(ctx, formID, nil)
+//	for it.Next() {
+//		submission := it.Submission()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type SubmissionIterator struct {
+	*pager[Submission]
+}
+
+// Submission returns the current submission.
+func (it *SubmissionIterator) Submission() Submission {
+	return it.current
+}
+
+// FormIterator is a cursor over forms, fetched a page at a time.
+type FormIterator struct {
+	*pager[Form]
+}
+
+// Form returns the current form.
+func (it *FormIterator) Form() Form {
+	return it.current
+}
+
+// HistoryIterator is a cursor over the account activity log.
+type HistoryIterator struct {
+	*pager[HistoryEntry]
+}
+
+// Entry returns the current history entry.
+func (it *HistoryIterator) Entry() HistoryEntry {
+	return it.current
+}
+
+// IterForms iterates over the forms of the account.
+func (client Client) IterForms(ctx context.Context, opts *IterOptions) *FormIterator {
+	return &FormIterator{newPager(ctx, func(ctx context.Context, opts *ListOptions) ([]Form, *ResultSet, error) {
+		params, err := opts.params()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var forms []Form
+		resultSet, err := client.decodePage(ctx, "user/forms", params, "GET", &forms)
+		return forms, resultSet, err
+	}, opts)}
+}
+
+// IterSubmissions iterates over the submissions of every form in the account.
+func (client Client) IterSubmissions(ctx context.Context, opts *IterOptions) *SubmissionIterator {
+	return client.iterSubmissions(ctx, "user/submissions", opts)
+}
+
+// IterFormSubmissions iterates over the submissions of a form.
+func (client Client) IterFormSubmissions(ctx context.Context, formID int64, opts *IterOptions) *SubmissionIterator {
+	return client.iterSubmissions(ctx, "form/"+strconv.FormatInt(formID, 10)+"/submissions", opts)
+}
+
+func (client Client) iterSubmissions(ctx context.Context, requestPath string, opts *IterOptions) *SubmissionIterator {
+	return &SubmissionIterator{newPager(ctx, func(ctx context.Context, opts *ListOptions) ([]Submission, *ResultSet, error) {
+		params, err := opts.params()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var submissions []Submission
+		resultSet, err := client.decodePage(ctx, requestPath, params, "GET", &submissions)
+		return submissions, resultSet, err
+	}, opts)}
+}
+
+// IterHistory iterates over the account activity log.
+// The arguments are those of GetHistory.
+// JotForm does not page the activity log, so it is fetched in a single request.
+func (client Client) IterHistory(ctx context.Context, action string, date string, sortBy string, startDate string, endDate string) *HistoryIterator {
+	it := &HistoryIterator{newPager(ctx, func(ctx context.Context, opts *ListOptions) ([]HistoryEntry, *ResultSet, error) {
+		history, err := client.GetHistoryTyped(ctx, action, date, sortBy, startDate, endDate)
+		return history, &ResultSet{Count: Int(len(history))}, err
+	}, nil)}
+	it.unpaged = true
+	return it
+}
@@ -0,0 +1,215 @@
+package jotform_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+
+	jotform "github.com/jotform/jotform-api-go/v2"
+	"github.com/jotform/jotform-api-go/v2/jotformtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// pagedClient serves total submissions, honouring the offset and limit parameters,
+// and fails any request for an offset listed in failAt.
+func pagedClient(total int, offsets *[]string, failAt ...int) *jotform.MockHttpClient {
+	var mu sync.Mutex
+	return &jotform.MockHttpClient{DoFunc: func(req *http.Request) (*http.Response, error) {
+		query := req.URL.Query()
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		limit, _ := strconv.Atoi(query.Get("limit"))
+
+		mu.Lock()
+		*offsets = append(*offsets, query.Get("offset"))
+		mu.Unlock()
+
+		for _, fail := range failAt {
+			if offset == fail {
+				return jotform.NewMockResponse(req, 404, `{"responseCode":404,"message":"Not found","content":""}`), nil
+			}
+		}
+
+		page := []map[string]string{}
+		for i := offset; i < offset+limit && i < total; i++ {
+			page = append(page, map[string]string{"id": strconv.Itoa(i + 1)})
+		}
+		content, _ := json.Marshal(page)
+		body := fmt.Sprintf(`{"responseCode":200,"content":%s,"resultSet":{"offset":%d,"limit":%d,"count":%d}}`, content, offset, limit, len(page))
+		return jotform.NewMockResponse(req, 200, body), nil
+	}}
+}
+
+func collectIDs(it *jotform.SubmissionIterator) []int64 {
+	var ids []int64
+	for it.Next() {
+		ids = append(ids, int64(it.Submission().ID))
+	}
+	return ids
+}
+
+func TestIterFormSubmissions(t *testing.T) {
+	t.Run("happy - fetches pages lazily until a short page", func(t *testing.T) {
+		var offsets []string
+		client := jotform.NewTestClient(pagedClient(5, &offsets))
+
+		it := client.IterFormSubmissions(context.Background(), 123, &jotform.IterOptions{
+			ListOptions: jotform.ListOptions{Limit: 2},
+		})
+		assert.Equal(t, 0, len(offsets))
+
+		assert.True(t, it.Next())
+		assert.Equal(t, []string{""}, offsets)
+		assert.Equal(t, jotform.ResultSet{Offset: 0, Limit: 2, Count: 2}, it.ResultSet())
+
+		ids := append([]int64{int64(it.Submission().ID)}, collectIDs(it)...)
+		assert.Nil(t, it.Err())
+		assert.Equal(t, []int64{1, 2, 3, 4, 5}, ids)
+		assert.Equal(t, []string{"", "2", "4"}, offsets)
+		assert.Equal(t, jotform.ResultSet{Offset: 4, Limit: 2, Count: 1}, it.ResultSet())
+	})
+
+	t.Run("happy - full last page needs one more request", func(t *testing.T) {
+		var offsets []string
+		client := jotform.NewTestClient(pagedClient(4, &offsets))
+
+		it := client.IterFormSubmissions(context.Background(), 123, &jotform.IterOptions{
+			ListOptions: jotform.ListOptions{Limit: 2},
+		})
+		assert.Equal(t, []int64{1, 2, 3, 4}, collectIDs(it))
+		assert.Equal(t, []string{"", "2", "4"}, offsets)
+	})
+
+	t.Run("happy - limits above the largest page are fetched a page at a time", func(t *testing.T) {
+		server := jotformtest.NewServer()
+		defer server.Close()
+		client := jotform.NewJotFormAPIClient("api-key", "json", false)
+		client.BaseURL = server.URL
+
+		form := server.AddForm(jotform.Form{Title: "Orders"})
+		for i := 0; i < 2500; i++ {
+			server.AddSubmission(jotform.Submission{FormID: form.ID})
+		}
+		before := len(server.Requests())
+
+		it := client.IterFormSubmissions(context.Background(), int64(form.ID), &jotform.IterOptions{
+			ListOptions: jotform.ListOptions{Limit: 5000},
+		})
+		assert.Len(t, collectIDs(it), 2500)
+		assert.Nil(t, it.Err())
+
+		requests := server.Requests()[before:]
+		assert.Len(t, requests, 3)
+		for _, req := range requests {
+			assert.Equal(t, "1000", req.Query.Get("limit"))
+		}
+	})
+
+	t.Run("happy - a page capped by the server isn't the last", func(t *testing.T) {
+		var offsets []string
+		mock := pagedClient(5, &offsets)
+		serve := mock.DoFunc
+		mock.DoFunc = func(req *http.Request) (*http.Response, error) {
+			query := req.URL.Query()
+			query.Set("limit", "2")
+			req.URL.RawQuery = query.Encode()
+			return serve(req)
+		}
+		client := jotform.NewTestClient(mock)
+
+		it := client.IterFormSubmissions(context.Background(), 123, &jotform.IterOptions{
+			ListOptions: jotform.ListOptions{Limit: 3},
+		})
+		assert.Equal(t, []int64{1, 2, 3, 4, 5}, collectIDs(it))
+		assert.Nil(t, it.Err())
+	})
+
+	t.Run("happy - starts at the given offset", func(t *testing.T) {
+		var offsets []string
+		client := jotform.NewTestClient(pagedClient(5, &offsets))
+
+		it := client.IterFormSubmissions(context.Background(), 123, &jotform.IterOptions{
+			ListOptions: jotform.ListOptions{Offset: 3, Limit: 2},
+		})
+		assert.Equal(t, []int64{4, 5}, collectIDs(it))
+	})
+
+	t.Run("happy - prefetches the next page", func(t *testing.T) {
+		var offsets []string
+		client := jotform.NewTestClient(pagedClient(5, &offsets))
+
+		it := client.IterFormSubmissions(context.Background(), 123, &jotform.IterOptions{
+			ListOptions: jotform.ListOptions{Limit: 2},
+			Prefetch:    true,
+		})
+		assert.Equal(t, []int64{1, 2, 3, 4, 5}, collectIDs(it))
+		assert.Nil(t, it.Err())
+		assert.Equal(t, []string{"", "2", "4"}, offsets)
+	})
+
+	t.Run("sad - error stops iteration", func(t *testing.T) {
+		var offsets []string
+		client := jotform.NewTestClient(pagedClient(5, &offsets, 2))
+
+		it := client.IterFormSubmissions(context.Background(), 123, &jotform.IterOptions{
+			ListOptions: jotform.ListOptions{Limit: 2},
+		})
+		assert.Equal(t, []int64{1, 2}, collectIDs(it))
+		assert.NotNil(t, it.Err())
+		assert.False(t, it.Next())
+	})
+
+	t.Run("happy - range over All", func(t *testing.T) {
+		var offsets []string
+		client := jotform.NewTestClient(pagedClient(5, &offsets))
+
+		var ids []int64
+		for submission, err := range client.IterFormSubmissions(context.Background(), 123, &jotform.IterOptions{
+			ListOptions: jotform.ListOptions{Limit: 2},
+		}).All() {
+			assert.Nil(t, err)
+			ids = append(ids, int64(submission.ID))
+			if len(ids) == 3 {
+				break
+			}
+		}
+		assert.Equal(t, []int64{1, 2, 3}, ids)
+		assert.Equal(t, []string{"", "2"}, offsets)
+	})
+
+	t.Run("sad - range over All yields the error", func(t *testing.T) {
+		var offsets []string
+		client := jotform.NewTestClient(pagedClient(5, &offsets, 2))
+
+		var errs []error
+		for _, err := range client.IterFormSubmissions(context.Background(), 123, &jotform.IterOptions{
+			ListOptions: jotform.ListOptions{Limit: 2},
+		}).All() {
+			errs = append(errs, err)
+		}
+		assert.Equal(t, 3, len(errs))
+		assert.NotNil(t, errs[2])
+	})
+}
+
+func TestIterHistory(t *testing.T) {
+	t.Run("happy - single request", func(t *testing.T) {
+		calls := 0
+		client := jotform.NewTestClient(&jotform.MockHttpClient{DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			return jotform.NewMockResponse(req, 200, `{"responseCode":200,"content":[{"type":"formCreation","formID":"1","timestamp":1369146620},{"type":"userLogin","timestamp":1369146621}]}`), nil
+		}})
+
+		it := client.IterHistory(context.Background(), "", "", "", "", "")
+		var types []string
+		for it.Next() {
+			types = append(types, it.Entry().Type)
+		}
+		assert.Nil(t, it.Err())
+		assert.Equal(t, []string{"formCreation", "userLogin"}, types)
+		assert.Equal(t, 1, calls)
+	})
+}
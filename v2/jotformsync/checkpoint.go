@@ -0,0 +1,92 @@
+package jotformsync
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// Checkpoint records how far a form's submissions have been synced.
+type Checkpoint struct {
+	FormID int64 `json:"form_id"`
+	// Since is the latest created_at or updated_at time of the submissions synced.
+	Since time.Time `json:"since"`
+	// IDs are the submissions that existed at the last sync, in ascending order.
+	IDs []int64 `json:"ids"`
+	// SyncedAt is when the last sync finished.
+	SyncedAt time.Time `json:"synced_at"`
+}
+
+// CheckpointStore persists checkpoints between syncs.
+type CheckpointStore interface {
+	// Load returns the checkpoint of a form, or nil if it has never been synced.
+	Load(ctx context.Context, formID int64) (*Checkpoint, error)
+	// Save stores the checkpoint of a form, replacing any previous one.
+	Save(ctx context.Context, checkpoint *Checkpoint) error
+}
+
+// FileStore is a CheckpointStore keeping each form's checkpoint
+// in a JSON file in Dir, named form-<id>.json.
+type FileStore struct {
+	Dir string
+}
+
+// NewFileStore returns a FileStore keeping checkpoints in dir.
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{Dir: dir}
+}
+
+func (s *FileStore) path(formID int64) string {
+	return filepath.Join(s.Dir, "form-"+strconv.FormatInt(formID, 10)+".json")
+}
+
+func (s *FileStore) Load(ctx context.Context, formID int64) (*Checkpoint, error) {
+	data, err := ioutil.ReadFile(s.path(formID))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var checkpoint Checkpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, err
+	}
+	return &checkpoint, nil
+}
+
+// Save writes the checkpoint to a temporary file and renames it into place,
+// so that a crash never leaves a partly written checkpoint.
+func (s *FileStore) Save(ctx context.Context, checkpoint *Checkpoint) error {
+	data, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(s.Dir, 0755); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(s.Dir, ".form-*.json.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path(checkpoint.FormID))
+}
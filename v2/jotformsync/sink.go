@@ -0,0 +1,37 @@
+package jotformsync
+
+import (
+	"context"
+
+	jotform "github.com/jotform/jotform-api-go/v2"
+)
+
+// Sink receives the changes found by a sync.
+// Submissions may be upserted more than once, so Upsert must be idempotent.
+type Sink interface {
+	// Upsert is called with every submission created or updated since the last sync.
+	Upsert(ctx context.Context, submission jotform.Submission) error
+	// Delete is called with every submission deleted since the last sync.
+	Delete(ctx context.Context, formID int64, submissionID int64) error
+}
+
+// SinkFuncs is a Sink calling the functions it holds.
+// A nil function ignores its changes.
+type SinkFuncs struct {
+	UpsertFunc func(ctx context.Context, submission jotform.Submission) error
+	DeleteFunc func(ctx context.Context, formID int64, submissionID int64) error
+}
+
+func (f SinkFuncs) Upsert(ctx context.Context, submission jotform.Submission) error {
+	if f.UpsertFunc == nil {
+		return nil
+	}
+	return f.UpsertFunc(ctx, submission)
+}
+
+func (f SinkFuncs) Delete(ctx context.Context, formID int64, submissionID int64) error {
+	if f.DeleteFunc == nil {
+		return nil
+	}
+	return f.DeleteFunc(ctx, formID, submissionID)
+}
@@ -0,0 +1,268 @@
+// Package jotformsync incrementally copies a form's submissions to a Sink,
+// fetching only the submissions created or updated since the last sync.
+//
+//	syncer := jotformsync.NewSyncer(client, jotformsync.NewFileStore("checkpoints"), sink)
+//	result, err := syncer.SyncForm(ctx, formID)
+package jotformsync
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	jotform "github.com/jotform/jotform-api-go/v2"
+)
+
+// DefaultOverlap is how far before its checkpoint a sync looks for changes by default.
+const DefaultOverlap = 5 * time.Minute
+
+// Source fetches submissions, and is satisfied by the jotform client.
+type Source interface {
+	GetFormSubmissionsTyped(ctx context.Context, formID int64, opts *jotform.ListOptions) ([]jotform.Submission, error)
+}
+
+// Syncer copies the submissions of forms from a Source to a Sink,
+// recording its progress in a CheckpointStore.
+type Syncer struct {
+	Source Source
+	Store  CheckpointStore
+	Sink   Sink
+	// Overlap is how far before the checkpoint to look for changes,
+	// to catch submissions saved with slightly earlier timestamps
+	// while the last sync was running.
+	// Submissions within the overlap are sent to the Sink again.
+	Overlap time.Duration
+	// PageSize is the number of submissions fetched per request.
+	PageSize int
+	// DetectDeletes lists every submission on each sync, rather than only those changed,
+	// to find the submissions deleted since the last one by comparing their IDs with the checkpoint's.
+	// JotForm can't list only the IDs, so each sync costs as much as the first.
+	// Without it, only submissions whose status is DELETED are reported as deleted.
+	DetectDeletes bool
+	// Now returns the time recorded as a checkpoint's SyncedAt. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// Result summarises a sync of a form.
+type Result struct {
+	FormID     int64
+	Upserted   int
+	Deleted    int
+	Checkpoint Checkpoint
+}
+
+// NewSyncer returns a Syncer with the default overlap and page size,
+// which detects deleted submissions.
+// Clear DetectDeletes to fetch only the submissions changed since the checkpoint.
+func NewSyncer(source Source, store CheckpointStore, sink Sink) *Syncer {
+	return &Syncer{
+		Source:        source,
+		Store:         store,
+		Sink:          sink,
+		Overlap:       DefaultOverlap,
+		PageSize:      jotform.DefaultPageSize,
+		DetectDeletes: true,
+	}
+}
+
+// SyncForms syncs each form in turn, stopping at the first error.
+func (s *Syncer) SyncForms(ctx context.Context, formIDs ...int64) ([]Result, error) {
+	results := make([]Result, 0, len(formIDs))
+	for _, formID := range formIDs {
+		result, err := s.SyncForm(ctx, formID)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, *result)
+	}
+	return results, nil
+}
+
+// SyncForm sends the changes to a form's submissions since its checkpoint to the Sink,
+// then saves a new checkpoint. The first sync of a form sends every submission.
+// If the Sink fails, the checkpoint is left as it was, so the next sync tries again.
+func (s *Syncer) SyncForm(ctx context.Context, formID int64) (*Result, error) {
+	previous, err := s.Store.Load(ctx, formID)
+	if err != nil {
+		return nil, fmt.Errorf("jotformsync: loading checkpoint of form %d: %w", formID, err)
+	}
+
+	changed := make(map[int64]jotform.Submission)
+	var all map[int64]bool
+	if previous == nil || s.DetectDeletes {
+		// Every submission is listed, so changes are picked out of the full listing.
+		all = make(map[int64]bool)
+		err := s.list(ctx, formID, "created_at", time.Time{}, func(submission jotform.Submission) {
+			if submission.Status != "DELETED" {
+				all[int64(submission.ID)] = true
+			}
+			if previous == nil || changedSince(submission, s.since(previous)) {
+				changed[int64(submission.ID)] = submission
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		since := s.since(previous)
+		// JotForm filters can't be combined with "or", so new and updated submissions are fetched separately.
+		for _, field := range []string{"created_at", "updated_at"} {
+			err := s.list(ctx, formID, field, since, func(submission jotform.Submission) {
+				changed[int64(submission.ID)] = submission
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	result := &Result{FormID: formID}
+	checkpoint := Checkpoint{FormID: formID}
+	ids := make(map[int64]bool)
+	if previous != nil {
+		checkpoint.Since = previous.Since
+		for _, id := range previous.IDs {
+			ids[id] = true
+		}
+	}
+
+	var deleted []int64
+	for _, id := range sortedIDs(changed) {
+		submission := changed[id]
+		if t := latest(submission); t.After(checkpoint.Since) {
+			checkpoint.Since = t
+		}
+		if submission.Status == "DELETED" {
+			if ids[id] {
+				deleted = append(deleted, id)
+			}
+			delete(ids, id)
+			continue
+		}
+
+		if err := s.Sink.Upsert(ctx, submission); err != nil {
+			return nil, fmt.Errorf("jotformsync: upserting submission %d: %w", id, err)
+		}
+		result.Upserted++
+		ids[id] = true
+	}
+
+	if all != nil {
+		for id := range ids {
+			if !all[id] {
+				deleted = append(deleted, id)
+				delete(ids, id)
+			}
+		}
+	}
+	sort.Slice(deleted, func(i, j int) bool { return deleted[i] < deleted[j] })
+	for _, id := range deleted {
+		if err := s.Sink.Delete(ctx, formID, id); err != nil {
+			return nil, fmt.Errorf("jotformsync: deleting submission %d: %w", id, err)
+		}
+		result.Deleted++
+	}
+
+	checkpoint.IDs = sortedIDs(ids)
+	checkpoint.SyncedAt = s.now()
+	if err := s.Store.Save(ctx, &checkpoint); err != nil {
+		return nil, fmt.Errorf("jotformsync: saving checkpoint of form %d: %w", formID, err)
+	}
+	result.Checkpoint = checkpoint
+	return result, nil
+}
+
+// list calls fn with every submission of the form whose field, created_at or updated_at,
+// is after the time given, or with every submission if it is zero.
+//
+// Submissions are listed in order of field, and each page starts again from the last time seen,
+// rather than at an offset, skipping those already seen.
+// So submissions changed or deleted while listing can't shift others past a page boundary.
+func (s *Syncer) list(ctx context.Context, formID int64, field string, after time.Time, fn func(jotform.Submission)) error {
+	pageSize := s.PageSize
+	if pageSize <= 0 {
+		pageSize = jotform.DefaultPageSize
+	}
+	if pageSize > jotform.MaxPageSize {
+		pageSize = jotform.MaxPageSize
+	}
+
+	seen := make(map[int64]bool)
+	cursor, offset := after, 0
+	first := true
+	for {
+		var where *jotform.Filter
+		switch {
+		case first && cursor.IsZero():
+			where = &jotform.Filter{}
+		case first:
+			where = jotform.Where(field).After(cursor)
+		default:
+			where = jotform.Where(field).Gte(cursor)
+		}
+		opts := &jotform.ListOptions{Limit: pageSize, Offset: offset, Where: where.OrderBy(field + ",ASC")}
+
+		page, err := s.Source.GetFormSubmissionsTyped(ctx, formID, opts)
+		if err != nil {
+			return fmt.Errorf("jotformsync: listing submissions of form %d: %w", formID, err)
+		}
+		for _, submission := range page {
+			if !seen[int64(submission.ID)] {
+				seen[int64(submission.ID)] = true
+				fn(submission)
+			}
+		}
+		if len(page) < pageSize {
+			return nil
+		}
+
+		last := fieldTime(page[len(page)-1], field)
+		if !first && last.Equal(cursor) {
+			// A whole page at the same time can only be got past by offset.
+			offset += len(page)
+			continue
+		}
+		cursor, offset, first = last, 0, false
+	}
+}
+
+func (s *Syncer) since(checkpoint *Checkpoint) time.Time {
+	return checkpoint.Since.Add(-s.Overlap)
+}
+
+func (s *Syncer) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+// fieldTime returns a submission's created_at or updated_at time.
+func fieldTime(submission jotform.Submission, field string) time.Time {
+	if field == "updated_at" {
+		return submission.UpdatedAt.Time
+	}
+	return submission.CreatedAt.Time
+}
+
+func changedSince(submission jotform.Submission, since time.Time) bool {
+	return latest(submission).After(since)
+}
+
+// latest returns the time a submission was last created or updated.
+func latest(submission jotform.Submission) time.Time {
+	if submission.UpdatedAt.After(submission.CreatedAt.Time) {
+		return submission.UpdatedAt.Time
+	}
+	return submission.CreatedAt.Time
+}
+
+func sortedIDs[V any](set map[int64]V) []int64 {
+	ids := make([]int64, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
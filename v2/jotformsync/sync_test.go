@@ -0,0 +1,286 @@
+package jotformsync_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	jotform "github.com/jotform/jotform-api-go/v2"
+	"github.com/jotform/jotform-api-go/v2/jotformsync"
+	"github.com/jotform/jotform-api-go/v2/jotformtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingSink records the changes it receives.
+type recordingSink struct {
+	upserted []int64
+	deleted  []int64
+	err      error
+}
+
+func (s *recordingSink) Upsert(ctx context.Context, submission jotform.Submission) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.upserted = append(s.upserted, int64(submission.ID))
+	return nil
+}
+
+func (s *recordingSink) Delete(ctx context.Context, formID int64, submissionID int64) error {
+	s.deleted = append(s.deleted, submissionID)
+	return nil
+}
+
+// editingSource calls afterFirstPage once, with the first page of submissions updated since a checkpoint.
+type editingSource struct {
+	jotformsync.Source
+	afterFirstPage func(page []jotform.Submission)
+}
+
+func (s *editingSource) GetFormSubmissionsTyped(ctx context.Context, formID int64, opts *jotform.ListOptions) ([]jotform.Submission, error) {
+	page, err := s.Source.GetFormSubmissionsTyped(ctx, formID, opts)
+	if s.afterFirstPage != nil && opts.Where != nil && opts.Offset == 0 {
+		if params, _ := opts.Where.Params(); strings.Contains(params["filter"], "updated_at") {
+			s.afterFirstPage(page)
+			s.afterFirstPage = nil
+		}
+	}
+	return page, err
+}
+
+func at(hour int) jotform.Time {
+	return jotform.Time{Time: time.Date(2024, 5, 1, hour, 0, 0, 0, time.UTC)}
+}
+
+func TestSyncer(t *testing.T) {
+	ctx := context.Background()
+
+	for _, detectDeletes := range []bool{true, false} {
+		name := "happy - incremental sync"
+		if !detectDeletes {
+			name += " with filters"
+		}
+
+		t.Run(name, func(t *testing.T) {
+			server := jotformtest.NewServer()
+			defer server.Close()
+			client := jotform.NewJotFormAPIClient("api-key", "json", false)
+			client.BaseURL = server.URL
+
+			form := server.AddForm(jotform.Form{Title: "Orders"})
+			first := server.AddSubmission(jotform.Submission{FormID: form.ID, CreatedAt: at(1)})
+			second := server.AddSubmission(jotform.Submission{FormID: form.ID, CreatedAt: at(2)})
+			third := server.AddSubmission(jotform.Submission{FormID: form.ID, CreatedAt: at(3)})
+
+			sink := &recordingSink{}
+			store := jotformsync.NewFileStore(t.TempDir())
+			syncer := jotformsync.NewSyncer(client, store, sink)
+			syncer.Overlap = 30 * time.Minute
+			syncer.PageSize = 2
+			syncer.DetectDeletes = detectDeletes
+
+			result, err := syncer.SyncForm(ctx, int64(form.ID))
+			assert.Nil(t, err)
+			assert.Equal(t, 3, result.Upserted)
+			assert.Equal(t, at(3).Time, result.Checkpoint.Since)
+			assert.Equal(t, []int64{int64(first.ID), int64(second.ID), int64(third.ID)}, result.Checkpoint.IDs)
+
+			// Nothing changed, but the last submission is within the overlap.
+			sink.upserted = nil
+			result, err = syncer.SyncForm(ctx, int64(form.ID))
+			assert.Nil(t, err)
+			assert.Equal(t, []int64{int64(third.ID)}, sink.upserted)
+
+			// Edit one, add one and delete one.
+			server.Now = func() time.Time { return at(5).Time }
+			_, err = client.EditSubmission(int64(first.ID), map[string]string{"flag": "1"})
+			assert.Nil(t, err)
+			fourth := server.AddSubmission(jotform.Submission{FormID: form.ID, CreatedAt: at(4)})
+			trashed := server.AddSubmission(jotform.Submission{FormID: form.ID, CreatedAt: at(4), Status: "DELETED"})
+			_, err = client.DeleteSubmission(int64(second.ID))
+			assert.Nil(t, err)
+
+			sink.upserted = nil
+			result, err = syncer.SyncForm(ctx, int64(form.ID))
+			assert.Nil(t, err)
+			assert.Equal(t, []int64{int64(first.ID), int64(third.ID), int64(fourth.ID)}, sink.upserted)
+			assert.Equal(t, at(5).Time, result.Checkpoint.Since)
+			assert.NotContains(t, result.Checkpoint.IDs, int64(trashed.ID))
+			if detectDeletes {
+				assert.Equal(t, []int64{int64(second.ID)}, sink.deleted)
+				assert.Equal(t, []int64{int64(first.ID), int64(third.ID), int64(fourth.ID)}, result.Checkpoint.IDs)
+			}
+
+			saved, err := store.Load(ctx, int64(form.ID))
+			assert.Nil(t, err)
+			assert.Equal(t, result.Checkpoint.IDs, saved.IDs)
+		})
+	}
+
+	t.Run("sad - sink failure keeps the checkpoint", func(t *testing.T) {
+		server := jotformtest.NewServer()
+		defer server.Close()
+		client := jotform.NewJotFormAPIClient("api-key", "json", false)
+		client.BaseURL = server.URL
+
+		form := server.AddForm(jotform.Form{Title: "Orders"})
+		server.AddSubmission(jotform.Submission{FormID: form.ID, CreatedAt: at(1)})
+
+		store := jotformsync.NewFileStore(t.TempDir())
+		sink := &recordingSink{err: errors.New("database unavailable")}
+		syncer := jotformsync.NewSyncer(client, store, sink)
+
+		_, err := syncer.SyncForm(ctx, int64(form.ID))
+		assert.NotNil(t, err)
+
+		checkpoint, err := store.Load(ctx, int64(form.ID))
+		assert.Nil(t, err)
+		assert.Nil(t, checkpoint)
+	})
+
+	t.Run("happy - submissions deleted outright are found by default", func(t *testing.T) {
+		server := jotformtest.NewServer()
+		defer server.Close()
+		client := jotform.NewJotFormAPIClient("api-key", "json", false)
+		client.BaseURL = server.URL
+
+		form := server.AddForm(jotform.Form{Title: "Orders"})
+		kept := server.AddSubmission(jotform.Submission{FormID: form.ID, CreatedAt: at(1)})
+		gone := server.AddSubmission(jotform.Submission{FormID: form.ID, CreatedAt: at(2)})
+
+		sink := &recordingSink{}
+		syncer := jotformsync.NewSyncer(client, jotformsync.NewFileStore(t.TempDir()), sink)
+		_, err := syncer.SyncForm(ctx, int64(form.ID))
+		assert.Nil(t, err)
+
+		_, err = client.DeleteSubmission(int64(gone.ID))
+		assert.Nil(t, err)
+
+		result, err := syncer.SyncForm(ctx, int64(form.ID))
+		assert.Nil(t, err)
+		assert.Equal(t, []int64{int64(gone.ID)}, sink.deleted)
+		assert.Equal(t, 1, result.Deleted)
+		assert.Equal(t, []int64{int64(kept.ID)}, result.Checkpoint.IDs)
+	})
+
+	t.Run("happy - without DetectDeletes later syncs only fetch changes", func(t *testing.T) {
+		server := jotformtest.NewServer()
+		defer server.Close()
+		client := jotform.NewJotFormAPIClient("api-key", "json", false)
+		client.BaseURL = server.URL
+
+		form := server.AddForm(jotform.Form{Title: "Orders"})
+		server.AddSubmission(jotform.Submission{FormID: form.ID, CreatedAt: at(1)})
+
+		syncer := jotformsync.NewSyncer(client, jotformsync.NewFileStore(t.TempDir()), &recordingSink{})
+		syncer.DetectDeletes = false
+		_, err := syncer.SyncForm(ctx, int64(form.ID))
+		assert.Nil(t, err)
+
+		before := len(server.Requests())
+		_, err = syncer.SyncForm(ctx, int64(form.ID))
+		assert.Nil(t, err)
+		requests := server.Requests()[before:]
+		assert.Len(t, requests, 2)
+		for _, req := range requests {
+			assert.NotEmpty(t, req.Query.Get("filter"))
+		}
+	})
+
+	t.Run("happy - pages through submissions at the same time", func(t *testing.T) {
+		server := jotformtest.NewServer()
+		defer server.Close()
+		client := jotform.NewJotFormAPIClient("api-key", "json", false)
+		client.BaseURL = server.URL
+
+		form := server.AddForm(jotform.Form{Title: "Orders"})
+		var ids []int64
+		for i := 0; i < 5; i++ {
+			submission := server.AddSubmission(jotform.Submission{FormID: form.ID, CreatedAt: at(1)})
+			ids = append(ids, int64(submission.ID))
+		}
+		last := server.AddSubmission(jotform.Submission{FormID: form.ID, CreatedAt: at(2)})
+		ids = append(ids, int64(last.ID))
+
+		sink := &recordingSink{}
+		syncer := jotformsync.NewSyncer(client, jotformsync.NewFileStore(t.TempDir()), sink)
+		syncer.PageSize = 2
+
+		result, err := syncer.SyncForm(ctx, int64(form.ID))
+		assert.Nil(t, err)
+		assert.Equal(t, ids, sink.upserted)
+		assert.Equal(t, ids, result.Checkpoint.IDs)
+	})
+
+	t.Run("happy - submissions deleted while listing don't shift the next page", func(t *testing.T) {
+		server := jotformtest.NewServer()
+		defer server.Close()
+		client := jotform.NewJotFormAPIClient("api-key", "json", false)
+		client.BaseURL = server.URL
+
+		form := server.AddForm(jotform.Form{Title: "Orders"})
+		var ids []int64
+		for hour := 1; hour <= 5; hour++ {
+			submission := server.AddSubmission(jotform.Submission{FormID: form.ID, CreatedAt: at(hour)})
+			ids = append(ids, int64(submission.ID))
+		}
+
+		source := &editingSource{Source: client}
+		sink := &recordingSink{}
+		syncer := jotformsync.NewSyncer(source, jotformsync.NewFileStore(t.TempDir()), sink)
+		syncer.Overlap = 0
+		syncer.PageSize = 2
+		syncer.DetectDeletes = false
+		_, err := syncer.SyncForm(ctx, int64(form.ID))
+		assert.Nil(t, err)
+
+		for i, id := range ids {
+			server.Now = func() time.Time { return at(6 + i).Time }
+			_, err := client.EditSubmission(id, map[string]string{"flag": "1"})
+			assert.Nil(t, err)
+		}
+		source.afterFirstPage = func(page []jotform.Submission) {
+			_, err := client.DeleteSubmission(int64(page[len(page)-1].ID))
+			assert.Nil(t, err)
+		}
+
+		sink.upserted = nil
+		_, err = syncer.SyncForm(ctx, int64(form.ID))
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, ids, sink.upserted)
+	})
+
+	t.Run("happy - sink funcs", func(t *testing.T) {
+		var upserts int
+		sink := jotformsync.SinkFuncs{UpsertFunc: func(ctx context.Context, submission jotform.Submission) error {
+			upserts++
+			return nil
+		}}
+
+		assert.Nil(t, sink.Upsert(ctx, jotform.Submission{}))
+		assert.Nil(t, sink.Delete(ctx, 1, 2))
+		assert.Equal(t, 1, upserts)
+	})
+}
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("happy - round trip", func(t *testing.T) {
+		store := jotformsync.NewFileStore(t.TempDir())
+
+		missing, err := store.Load(ctx, 1)
+		assert.Nil(t, err)
+		assert.Nil(t, missing)
+
+		checkpoint := &jotformsync.Checkpoint{FormID: 1, Since: at(3).Time, IDs: []int64{5, 6}}
+		assert.Nil(t, store.Save(ctx, checkpoint))
+
+		loaded, err := store.Load(ctx, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, checkpoint.IDs, loaded.IDs)
+		assert.True(t, checkpoint.Since.Equal(loaded.Since))
+	})
+}
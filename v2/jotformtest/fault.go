@@ -0,0 +1,121 @@
+package jotformtest
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Fault makes the Server fail the requests it matches.
+//
+//	server.InjectFault(jotformtest.Fault{Path: "user/forms", StatusCode: 503, Times: 2})
+type Fault struct {
+	// Method is the HTTP method to match. Empty matches any method.
+	Method string
+	// Path is the endpoint path to match, eg. "form/1/submissions".
+	// A trailing "*" matches any path with that prefix, and an empty Path matches every path.
+	Path string
+	// StatusCode is the status to respond with.
+	// If zero, the request is handled normally after Delay.
+	StatusCode int
+	// Message is the message of the error response.
+	// Defaults to the status text.
+	Message string
+	// Header is added to the response, eg. to set Retry-After.
+	Header http.Header
+	// Delay is how long to wait before responding.
+	// The wait ends early if the client gives up on the request.
+	Delay time.Duration
+	// Disconnect closes the connection without responding,
+	// so that the client sees a network error.
+	Disconnect bool
+	// Times is the number of requests to fail.
+	// Zero fails every matching request.
+	Times int
+
+	used int
+}
+
+// InjectFault adds a fault, which applies to matching requests
+// until it has been used Times times.
+// Faults are matched in the order they were injected.
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults removes every injected fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+// takeFault returns the first fault matching the request, and counts its use.
+func (s *Server) takeFault(method string, path string) *Fault {
+	for i, fault := range s.faults {
+		if !fault.matches(method, path) {
+			continue
+		}
+
+		fault.used++
+		if fault.Times > 0 && fault.used >= fault.Times {
+			s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+		}
+		return fault
+	}
+	return nil
+}
+
+func (f *Fault) matches(method string, path string) bool {
+	if f.Method != "" && !strings.EqualFold(f.Method, method) {
+		return false
+	}
+	if prefix := strings.TrimSuffix(f.Path, "*"); prefix != f.Path {
+		return strings.HasPrefix(path, prefix)
+	}
+	return f.Path == "" || f.Path == path
+}
+
+// apply carries out the fault, and reports whether it responded to the request.
+func (f *Fault) apply(w http.ResponseWriter, r *http.Request) bool {
+	if f.Delay > 0 {
+		timer := time.NewTimer(f.Delay)
+		defer timer.Stop()
+
+		select {
+		case <-r.Context().Done():
+			return true
+		case <-timer.C:
+		}
+	}
+
+	if f.Disconnect {
+		if hijacker, ok := w.(http.Hijacker); ok {
+			if conn, _, err := hijacker.Hijack(); err == nil {
+				conn.Close()
+				return true
+			}
+		}
+		panic(http.ErrAbortHandler)
+	}
+
+	if f.StatusCode == 0 {
+		return false
+	}
+
+	for key, values := range f.Header {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+	message := f.Message
+	if message == "" {
+		message = http.StatusText(f.StatusCode)
+	}
+	writeError(w, isXML(r.URL.Path), f.StatusCode, message)
+	return true
+}
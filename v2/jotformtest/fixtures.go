@@ -0,0 +1,282 @@
+package jotformtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	jotform "github.com/jotform/jotform-api-go/v2"
+)
+
+// SetUser replaces the account's user details.
+func (s *Server) SetUser(user jotform.User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.user = user
+}
+
+// AddForm adds a form with the given questions, and returns it as stored.
+// A zero ID is assigned the next free ID, and missing details are filled in.
+func (s *Server) AddForm(f jotform.Form, questions ...jotform.Question) jotform.Form {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	properties := map[string]string{"title": f.Title}
+	var questionProperties []map[string]string
+	for _, question := range questions {
+		questionProperties = append(questionProperties, propertiesOf(question))
+	}
+
+	stored := s.createForm(properties, questionProperties, int64(f.ID))
+	if f.Status != "" {
+		stored.form.Status = f.Status
+	}
+	if f.Type != "" {
+		stored.form.Type = f.Type
+	}
+	if f.Username != "" {
+		stored.form.Username = f.Username
+	}
+	if !f.CreatedAt.IsZero() {
+		stored.form.CreatedAt = f.CreatedAt
+	}
+	if !f.UpdatedAt.IsZero() {
+		stored.form.UpdatedAt = f.UpdatedAt
+	}
+	stored.form.Favorite = f.Favorite
+	stored.form.Archived = f.Archived
+	return stored.form
+}
+
+// AddSubmission adds a submission, and returns it as stored.
+// A zero ID is assigned the next free ID, and missing details are filled in,
+// including the name, type and text of answers to questions on the form.
+func (s *Server) AddSubmission(submission jotform.Submission) jotform.Submission {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := submission
+	if stored.ID == 0 {
+		stored.ID = jotform.Int(s.nextID())
+	} else if int64(stored.ID) > s.lastID {
+		s.lastID = int64(stored.ID)
+	}
+	if stored.Status == "" {
+		stored.Status = "ACTIVE"
+	}
+	if stored.CreatedAt.IsZero() {
+		stored.CreatedAt = s.now()
+	}
+
+	answers := make(map[string]jotform.Answer, len(submission.Answers))
+	f := s.forms[int64(stored.FormID)]
+	for qid, answer := range submission.Answers {
+		if f != nil {
+			if question, ok := f.questions[qid]; ok {
+				if answer.Name == "" {
+					answer.Name = question["name"]
+				}
+				if answer.Type == "" {
+					answer.Type = question["type"]
+				}
+				if answer.Text == "" {
+					answer.Text = question["text"]
+				}
+				if answer.Order == 0 {
+					n, _ := strconv.ParseInt(question["order"], 10, 64)
+					answer.Order = jotform.Int(n)
+				}
+			}
+		}
+		answers[qid] = answer
+	}
+	stored.Answers = answers
+
+	s.submissions[int64(stored.ID)] = &stored
+	if f != nil && stored.CreatedAt.After(f.form.LastSubmission.Time) {
+		f.form.LastSubmission = stored.CreatedAt
+	}
+	s.countSubmissions(int64(stored.FormID))
+	return stored
+}
+
+// AddReport adds a report, and returns it as stored.
+func (s *Server) AddReport(report jotform.Report) jotform.Report {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := report
+	if stored.ID == 0 {
+		stored.ID = jotform.Int(s.nextID())
+	}
+	if stored.Status == "" {
+		stored.Status = "ENABLED"
+	}
+	if stored.URL == "" {
+		stored.URL = "https://www.jotform.com/report/" + strconv.FormatInt(int64(stored.ID), 10)
+	}
+	if stored.CreatedAt.IsZero() {
+		stored.CreatedAt = s.now()
+		stored.UpdatedAt = stored.CreatedAt
+	}
+	s.reports[int64(stored.ID)] = &stored
+	return stored
+}
+
+// AddFile adds a file uploaded to a form.
+func (s *Server) AddFile(file jotform.File) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.files = append(s.files, file)
+}
+
+// AddUpload stores a file uploaded with a submission, and lists it among the form's files.
+// The file is served at the returned File's URL, which accepts Range requests.
+func (s *Server) AddUpload(formID int64, submissionID int64, name string, content []byte) jotform.File {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir := fmt.Sprintf("uploads/%s/%d/%d/", s.user.Username, formID, submissionID)
+	s.uploads[dir+name] = content
+
+	file := jotform.File{
+		Name:         name,
+		Type:         mime.TypeByExtension(filepath.Ext(name)),
+		Size:         jotform.Int(len(content)),
+		Username:     s.user.Username,
+		FormID:       jotform.Int(formID),
+		SubmissionID: jotform.Int(submissionID),
+		URL:          s.URL + "/" + dir + url.PathEscape(name),
+		Date:         s.now(),
+	}
+	s.files = append(s.files, file)
+	return file
+}
+
+// AddHistory adds entries to the account activity log.
+func (s *Server) AddHistory(entries ...jotform.HistoryEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.history = append(s.history, entries...)
+}
+
+// SetFolders replaces the account's folder tree.
+func (s *Server) SetFolders(root jotform.Folder) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.folders = &root
+}
+
+// SetFormPDF sets the PDF a form was created from,
+// which is filled in by DownloadRichPDFSubmission.
+func (s *Server) SetFormPDF(formID int64, pdf []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if f, ok := s.forms[formID]; ok {
+		f.pdf = pdf
+	}
+}
+
+// Form returns a form, and false if there is no such form.
+func (s *Server) Form(formID int64) (jotform.Form, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, ok := s.forms[formID]
+	if !ok {
+		return jotform.Form{}, false
+	}
+	return f.form, true
+}
+
+// Submission returns a submission, and false if there is no such submission.
+func (s *Server) Submission(submissionID int64) (jotform.Submission, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	submission, ok := s.submissions[submissionID]
+	if !ok {
+		return jotform.Submission{}, false
+	}
+	return *submission, true
+}
+
+// FormSubmissions returns the submissions of a form, ordered by ID.
+func (s *Server) FormSubmissions(formID int64) []jotform.Submission {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var submissions []jotform.Submission
+	for _, submission := range s.submissions {
+		if int64(submission.FormID) == formID {
+			submissions = append(submissions, *submission)
+		}
+	}
+	sort.Slice(submissions, func(i, j int) bool { return submissions[i].ID < submissions[j].ID })
+	return submissions
+}
+
+// Webhooks returns the webhooks of a form, ordered by ID.
+func (s *Server) Webhooks(formID int64) []jotform.Webhook {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, ok := s.forms[formID]
+	if !ok {
+		return nil
+	}
+
+	var webhooks []jotform.Webhook
+	for id, url := range f.webhooks {
+		webhooks = append(webhooks, jotform.Webhook{ID: jotform.Int(id), URL: url})
+	}
+	sort.Slice(webhooks, func(i, j int) bool { return webhooks[i].ID < webhooks[j].ID })
+	return webhooks
+}
+
+// Emails returns the properties of each email a form was created to send, in order.
+func (s *Server) Emails(formID int64) []map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, ok := s.forms[formID]
+	if !ok {
+		return nil
+	}
+	emails := make([]map[string]string, len(f.emails))
+	for i, email := range f.emails {
+		emails[i] = make(map[string]string, len(email))
+		for key, value := range email {
+			emails[i][key] = value
+		}
+	}
+	return emails
+}
+
+// propertiesOf flattens a question into JotForm's string properties.
+func propertiesOf(question jotform.Question) map[string]string {
+	data, _ := json.Marshal(question)
+	var raw map[string]json.RawMessage
+	json.Unmarshal(data, &raw)
+
+	properties := make(map[string]string, len(raw))
+	for key, value := range raw {
+		properties[key] = stringValue(value)
+	}
+	if question.QID == 0 {
+		delete(properties, "qid")
+	}
+	if question.Order == 0 {
+		delete(properties, "order")
+	}
+	return properties
+}
@@ -0,0 +1,1277 @@
+package jotformtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	jotform "github.com/jotform/jotform-api-go/v2"
+)
+
+type handler func(s *Server, req *request) (interface{}, error)
+
+type route struct {
+	method string
+	// pattern is the endpoint path, with "*" matching any one segment.
+	pattern string
+	handle  handler
+}
+
+var routes = []route{
+	{"GET", "user", (*Server).getUser},
+	{"GET", "user/usage", (*Server).getUsage},
+	{"GET", "user/forms", (*Server).getForms},
+	{"POST", "user/forms", (*Server).postForm},
+	{"PUT", "user/forms", (*Server).putForm},
+	{"GET", "user/submissions", (*Server).getSubmissions},
+	{"GET", "user/subusers", (*Server).getSubusers},
+	{"GET", "user/folders", (*Server).getFolders},
+	{"GET", "user/reports", (*Server).getReports},
+	{"GET", "user/settings", (*Server).getSettings},
+	{"POST", "user/settings", (*Server).postSettings},
+	{"GET", "user/history", (*Server).getHistory},
+	{"GET", "user/submission/*", (*Server).getSubmission},
+	{"GET", "user/report/*", (*Server).getReport},
+	{"POST", "user/register", (*Server).getUser},
+	{"POST", "user/login", (*Server).getUser},
+	{"GET", "user/logout", (*Server).logout},
+	{"GET", "system/plan/*", (*Server).getPlan},
+	{"GET", "form/*", (*Server).getForm},
+	{"DELETE", "form/*", (*Server).deleteForm},
+	{"POST", "form/*/clone", (*Server).cloneForm},
+	{"GET", "form/*/questions", (*Server).getQuestions},
+	{"POST", "form/*/questions", (*Server).postQuestion},
+	{"PUT", "form/*/questions", (*Server).putQuestions},
+	{"GET", "form/*/question/*", (*Server).getQuestion},
+	{"POST", "form/*/question/*", (*Server).postQuestion},
+	{"DELETE", "form/*/question/*", (*Server).deleteQuestion},
+	{"GET", "form/*/properties", (*Server).getProperties},
+	{"POST", "form/*/properties", (*Server).postProperties},
+	{"PUT", "form/*/properties", (*Server).putProperties},
+	{"GET", "form/*/properties/*", (*Server).getProperty},
+	{"POST", "form/*/properties/*", (*Server).getProperty},
+	{"GET", "form/*/submissions", (*Server).getFormSubmissions},
+	{"POST", "form/*/submissions", (*Server).postSubmission},
+	{"PUT", "form/*/submissions", (*Server).putSubmissions},
+	{"GET", "form/*/files", (*Server).getFiles},
+	{"GET", "form/*/webhooks", (*Server).getWebhooks},
+	{"POST", "form/*/webhooks", (*Server).postWebhook},
+	{"DELETE", "form/*/webhooks/*", (*Server).deleteWebhook},
+	{"GET", "form/*/reports", (*Server).getFormReports},
+	{"POST", "form/*/reports", (*Server).postReport},
+	{"GET", "submission/*", (*Server).getSubmission},
+	{"POST", "submission/*", (*Server).editSubmission},
+	{"DELETE", "submission/*", (*Server).deleteSubmission},
+	{"DELETE", "report/*", (*Server).deleteReport},
+	{"GET", "folder/*", (*Server).getFolder},
+	{"POST", "folder", (*Server).postFolder},
+	{"PUT", "folder/*", (*Server).putFolder},
+	{"DELETE", "folder/*", (*Server).deleteFolder},
+	{"GET", "pdf-converter/*/fill-pdf", (*Server).fillPDF},
+	{"GET", "generatePDF", (*Server).generatePDF},
+}
+
+// isPDFPath reports whether path is an endpoint returning a PDF rather than the envelope,
+// which has no .xml variant.
+func isPDFPath(path string) bool {
+	return strings.HasPrefix(path, "pdf-converter/") || path == "generatePDF"
+}
+
+// route finds the handler for req, and sets its path parameters.
+func (s *Server) route(req *request) (handler, bool) {
+	segments := strings.Split(req.Path, "/")
+
+	for _, r := range routes {
+		if r.method != req.Method {
+			continue
+		}
+
+		pattern := strings.Split(r.pattern, "/")
+		if len(pattern) != len(segments) {
+			continue
+		}
+
+		var params []string
+		matched := true
+		for i, segment := range pattern {
+			if segment == "*" {
+				params = append(params, segments[i])
+			} else if segment != segments[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			req.params = params
+			return r.handle, true
+		}
+	}
+	return nil, false
+}
+
+// splitKey splits a bracketed parameter name, such as "submission[3][first]",
+// into its parts: "submission", "3" and "first".
+func splitKey(key string) []string {
+	parts := strings.Split(strings.TrimSuffix(key, "]"), "[")
+	for i := range parts {
+		parts[i] = strings.TrimSuffix(parts[i], "]")
+	}
+	return parts
+}
+
+// bracketed decodes the parameters within prefix, such as prefix[key] or prefix[index][key], into v.
+func bracketed(values map[string][]string, prefix string, v interface{}) error {
+	params := make(map[string]string, len(values))
+	for key, value := range values {
+		if len(value) > 0 {
+			params[key] = value[0]
+		}
+	}
+	if err := jotform.DecodeParams(params, prefix, v); err != nil {
+		return badRequest(err.Error())
+	}
+	return nil
+}
+
+// stringMap decodes a JSON object, converting its values to strings.
+func stringMap(raw json.RawMessage) (map[string]string, error) {
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]string, len(values))
+	for key, value := range values {
+		result[key] = stringValue(value)
+	}
+	return result, nil
+}
+
+func (s *Server) formParam(req *request) (*form, error) {
+	id, err := strconv.ParseInt(req.params[0], 10, 64)
+	if err != nil {
+		return nil, notFound("Form not found")
+	}
+	f, ok := s.forms[id]
+	if !ok {
+		return nil, notFound("Form not found")
+	}
+	return f, nil
+}
+
+func idParam(req *request, i int, name string) (int64, error) {
+	id, err := strconv.ParseInt(req.params[i], 10, 64)
+	if err != nil {
+		return 0, notFound(name + " not found")
+	}
+	return id, nil
+}
+
+func (s *Server) getUser(req *request) (interface{}, error) {
+	return s.user, nil
+}
+
+func (s *Server) logout(req *request) (interface{}, error) {
+	return "", nil
+}
+
+func (s *Server) getUsage(req *request) (interface{}, error) {
+	return jotform.Usage{
+		Username:         s.user.Username,
+		Submissions:      jotform.Int(len(s.submissions)),
+		TotalSubmissions: jotform.Int(len(s.submissions)),
+		Uploads:          jotform.Int(len(s.files)),
+		FormCount:        jotform.Int(len(s.forms)),
+		APICalls:         jotform.Int(s.apiCalls),
+	}, nil
+}
+
+func (s *Server) getSubusers(req *request) (interface{}, error) {
+	return []interface{}{}, nil
+}
+
+var plans = map[string]map[string]jotform.Int{
+	"FREE":     {"submissions": 100, "formCount": 5, "api": 1000},
+	"BRONZE":   {"submissions": 1000, "formCount": 25, "api": 1000},
+	"SILVER":   {"submissions": 2500, "formCount": 50, "api": 10000},
+	"GOLD":     {"submissions": 10000, "formCount": 100, "api": 100000},
+	"PLATINUM": {"submissions": 10000, "formCount": 100, "api": 100000},
+}
+
+func (s *Server) getPlan(req *request) (interface{}, error) {
+	name := strings.ToUpper(req.params[0])
+	limits, ok := plans[name]
+	if !ok {
+		return nil, notFound("Plan not found")
+	}
+	return jotform.Plan{Name: name, Limits: limits}, nil
+}
+
+func (s *Server) settingsContent() map[string]string {
+	settings := map[string]string{
+		"username":  s.user.Username,
+		"name":      s.user.Name,
+		"email":     s.user.Email,
+		"website":   s.user.Website,
+		"time_zone": s.user.TimeZone,
+		"company":   s.user.Company,
+	}
+	for key, value := range s.settings {
+		settings[key] = value
+	}
+	return settings
+}
+
+func (s *Server) getSettings(req *request) (interface{}, error) {
+	return s.settingsContent(), nil
+}
+
+func (s *Server) postSettings(req *request) (interface{}, error) {
+	for key, values := range req.Form {
+		if len(values) > 0 {
+			s.settings[key] = values[0]
+		}
+	}
+	return s.settingsContent(), nil
+}
+
+func (s *Server) getHistory(req *request) (interface{}, error) {
+	action := req.Query.Get("action")
+
+	var history []jotform.HistoryEntry
+	for _, entry := range s.history {
+		if action == "" || action == "all" || entry.Type == action {
+			history = append(history, entry)
+		}
+	}
+
+	ascending := strings.EqualFold(req.Query.Get("sortBy"), "ASC")
+	sort.SliceStable(history, func(i, j int) bool {
+		if ascending {
+			return history[i].Timestamp < history[j].Timestamp
+		}
+		return history[i].Timestamp > history[j].Timestamp
+	})
+	if history == nil {
+		history = []jotform.HistoryEntry{}
+	}
+	return history, nil
+}
+
+func (s *Server) getForms(req *request) (interface{}, error) {
+	forms := make([]jotform.Form, 0, len(s.forms))
+	for _, f := range s.forms {
+		forms = append(forms, f.form)
+	}
+	return listPage(req, forms, func(f jotform.Form) map[string]string { return fieldsOf(f) })
+}
+
+func (s *Server) getForm(req *request) (interface{}, error) {
+	f, err := s.formParam(req)
+	if err != nil {
+		return nil, err
+	}
+	return f.form, nil
+}
+
+// postForm creates a form from parameters such as
+// properties[title] and questions[1][type].
+func (s *Server) postForm(req *request) (interface{}, error) {
+	properties := make(map[string]string)
+	questions := make(map[string]map[string]string)
+	emails := make(map[string]map[string]string)
+	for prefix, v := range map[string]interface{}{"properties": &properties, "questions": &questions, "emails": &emails} {
+		if err := bracketed(req.Form, prefix, v); err != nil {
+			return nil, err
+		}
+	}
+
+	f := s.createForm(properties, sortedQuestions(questions), 0)
+	f.emails = sortedQuestions(emails)
+	return f.form, nil
+}
+
+// putForm creates a form from a JSON object of properties and questions.
+func (s *Server) putForm(req *request) (interface{}, error) {
+	var body struct {
+		Properties json.RawMessage `json:"properties"`
+		Questions  json.RawMessage `json:"questions"`
+		Emails     json.RawMessage `json:"emails"`
+	}
+	if err := json.Unmarshal(req.Body, &body); err != nil {
+		return nil, badRequest("invalid form: " + err.Error())
+	}
+
+	properties := make(map[string]string)
+	if len(body.Properties) > 0 {
+		var err error
+		if properties, err = stringMap(body.Properties); err != nil {
+			return nil, badRequest("invalid form properties: " + err.Error())
+		}
+	}
+
+	questions, err := decodeQuestions(body.Questions)
+	if err != nil {
+		return nil, err
+	}
+	emails, err := decodeQuestions(body.Emails)
+	if err != nil {
+		return nil, err
+	}
+
+	f := s.createForm(properties, questions, 0)
+	f.emails = emails
+	return f.form, nil
+}
+
+// decodeQuestions decodes questions sent as an object keyed by qid or index, or as an array.
+func decodeQuestions(raw json.RawMessage) ([]map[string]string, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	var list []json.RawMessage
+	if err := json.Unmarshal(raw, &list); err == nil {
+		var questions []map[string]string
+		for _, item := range list {
+			question, err := stringMap(item)
+			if err != nil {
+				return nil, badRequest("invalid question: " + err.Error())
+			}
+			questions = append(questions, question)
+		}
+		return questions, nil
+	}
+
+	var byKey map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &byKey); err != nil {
+		return nil, badRequest("invalid questions: " + err.Error())
+	}
+	byIndex := make(map[string]map[string]string, len(byKey))
+	for key, item := range byKey {
+		question, err := stringMap(item)
+		if err != nil {
+			return nil, badRequest("invalid question: " + err.Error())
+		}
+		byIndex[key] = question
+	}
+	return sortedQuestions(byIndex), nil
+}
+
+// sortedQuestions orders questions by their numeric keys.
+func sortedQuestions(byIndex map[string]map[string]string) []map[string]string {
+	keys := make([]string, 0, len(byIndex))
+	for key := range byIndex {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return compare(keys[i], keys[j]) < 0 })
+
+	questions := make([]map[string]string, 0, len(keys))
+	for _, key := range keys {
+		questions = append(questions, byIndex[key])
+	}
+	return questions
+}
+
+func (s *Server) createForm(properties map[string]string, questions []map[string]string, id int64) *form {
+	if id == 0 {
+		id = s.nextID()
+	} else if id > s.lastID {
+		s.lastID = id
+	}
+
+	now := s.now()
+	f := &form{
+		form: jotform.Form{
+			ID:        jotform.Int(id),
+			Username:  s.user.Username,
+			Title:     properties["title"],
+			Status:    "ENABLED",
+			Type:      "LEGACY",
+			URL:       "https://form.jotform.com/" + strconv.FormatInt(id, 10),
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		questions:  make(map[string]map[string]string),
+		properties: make(map[string]string),
+		webhooks:   make(map[int64]string),
+	}
+	for key, value := range properties {
+		f.properties[key] = value
+	}
+	for _, question := range questions {
+		f.addQuestion(question)
+	}
+
+	s.forms[id] = f
+	return f
+}
+
+// addQuestion adds a question, assigning it the next qid if it has none.
+func (f *form) addQuestion(properties map[string]string) map[string]string {
+	question := make(map[string]string, len(properties)+3)
+	for key, value := range properties {
+		question[key] = value
+	}
+
+	if question["qid"] == "" {
+		next := 1
+		for qid := range f.questions {
+			if n, _ := strconv.Atoi(qid); n >= next {
+				next = n + 1
+			}
+		}
+		question["qid"] = strconv.Itoa(next)
+	}
+	if question["order"] == "" {
+		question["order"] = question["qid"]
+	}
+	if question["name"] == "" {
+		question["name"] = "input" + question["qid"]
+	}
+
+	f.questions[question["qid"]] = question
+	return question
+}
+
+// countSubmissions updates the submission counts of a form.
+func (s *Server) countSubmissions(formID int64) {
+	if f, ok := s.forms[formID]; ok {
+		f.form.Count = 0
+		f.form.New = 0
+		for _, submission := range s.submissions {
+			if int64(submission.FormID) == formID {
+				f.form.Count++
+				if submission.New {
+					f.form.New++
+				}
+			}
+		}
+	}
+}
+
+func (s *Server) deleteForm(req *request) (interface{}, error) {
+	f, err := s.formParam(req)
+	if err != nil {
+		return nil, err
+	}
+	f.form.Status = "DELETED"
+	f.form.UpdatedAt = s.now()
+	return f.form, nil
+}
+
+func (s *Server) cloneForm(req *request) (interface{}, error) {
+	f, err := s.formParam(req)
+	if err != nil {
+		return nil, err
+	}
+
+	properties := make(map[string]string, len(f.properties))
+	for key, value := range f.properties {
+		properties[key] = value
+	}
+	properties["title"] = "Clone of " + f.form.Title
+
+	var questions []map[string]string
+	for _, question := range f.questions {
+		questions = append(questions, question)
+	}
+	return s.createForm(properties, questions, 0).form, nil
+}
+
+func (s *Server) getQuestions(req *request) (interface{}, error) {
+	f, err := s.formParam(req)
+	if err != nil {
+		return nil, err
+	}
+	return f.questions, nil
+}
+
+func (s *Server) getQuestion(req *request) (interface{}, error) {
+	f, err := s.formParam(req)
+	if err != nil {
+		return nil, err
+	}
+	question, ok := f.questions[req.params[1]]
+	if !ok {
+		return nil, notFound("Question not found")
+	}
+	return question, nil
+}
+
+// postQuestion creates a question, or edits the question in the path,
+// from parameters such as question[type].
+func (s *Server) postQuestion(req *request) (interface{}, error) {
+	f, err := s.formParam(req)
+	if err != nil {
+		return nil, err
+	}
+	properties := make(map[string]string)
+	if err := bracketed(req.Form, "question", &properties); err != nil {
+		return nil, err
+	}
+
+	if len(req.params) < 2 {
+		delete(properties, "qid")
+		question := f.addQuestion(properties)
+		f.form.UpdatedAt = s.now()
+		return question, nil
+	}
+
+	question, ok := f.questions[req.params[1]]
+	if !ok {
+		return nil, notFound("Question not found")
+	}
+	for key, value := range properties {
+		if key != "qid" {
+			question[key] = value
+		}
+	}
+	f.form.UpdatedAt = s.now()
+	return question, nil
+}
+
+func (s *Server) putQuestions(req *request) (interface{}, error) {
+	f, err := s.formParam(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body struct {
+		Questions json.RawMessage `json:"questions"`
+	}
+	if err := json.Unmarshal(req.Body, &body); err != nil {
+		return nil, badRequest("invalid questions: " + err.Error())
+	}
+	questions, err := decodeQuestions(body.Questions)
+	if err != nil {
+		return nil, err
+	}
+
+	added := make(map[string]map[string]string, len(questions))
+	for _, question := range questions {
+		delete(question, "qid")
+		q := f.addQuestion(question)
+		added[q["qid"]] = q
+	}
+	f.form.UpdatedAt = s.now()
+	return added, nil
+}
+
+func (s *Server) deleteQuestion(req *request) (interface{}, error) {
+	f, err := s.formParam(req)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := f.questions[req.params[1]]; !ok {
+		return nil, notFound("Question not found")
+	}
+	delete(f.questions, req.params[1])
+	f.form.UpdatedAt = s.now()
+	return "Question deleted", nil
+}
+
+func (f *form) propertiesContent() map[string]string {
+	properties := make(map[string]string, len(f.properties)+2)
+	for key, value := range f.properties {
+		properties[key] = value
+	}
+	properties["id"] = strconv.FormatInt(int64(f.form.ID), 10)
+	properties["title"] = f.form.Title
+	return properties
+}
+
+func (f *form) setProperties(properties map[string]string) {
+	for key, value := range properties {
+		f.properties[key] = value
+		if key == "title" {
+			f.form.Title = value
+		}
+	}
+}
+
+func (s *Server) getProperties(req *request) (interface{}, error) {
+	f, err := s.formParam(req)
+	if err != nil {
+		return nil, err
+	}
+	return f.propertiesContent(), nil
+}
+
+func (s *Server) getProperty(req *request) (interface{}, error) {
+	f, err := s.formParam(req)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := f.propertiesContent()[req.params[1]]
+	if !ok {
+		return nil, notFound("Property not found")
+	}
+	return map[string]string{req.params[1]: value}, nil
+}
+
+func (s *Server) postProperties(req *request) (interface{}, error) {
+	f, err := s.formParam(req)
+	if err != nil {
+		return nil, err
+	}
+	properties := make(map[string]string)
+	if err := bracketed(req.Form, "properties", &properties); err != nil {
+		return nil, err
+	}
+	f.setProperties(properties)
+	f.form.UpdatedAt = s.now()
+	return f.propertiesContent(), nil
+}
+
+func (s *Server) putProperties(req *request) (interface{}, error) {
+	f, err := s.formParam(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var body struct {
+		Properties json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal(req.Body, &body); err != nil || len(body.Properties) == 0 {
+		return nil, badRequest("invalid properties")
+	}
+	properties, err := stringMap(body.Properties)
+	if err != nil {
+		return nil, badRequest("invalid properties: " + err.Error())
+	}
+	f.setProperties(properties)
+	f.form.UpdatedAt = s.now()
+	return f.propertiesContent(), nil
+}
+
+// submissionFields flattens a submission for filtering,
+// including its answers as q<qid>.
+func submissionFields(submission jotform.Submission) map[string]string {
+	fields := fieldsOf(submission)
+	delete(fields, "answers")
+	for qid, answer := range submission.Answers {
+		value := answer.PrettyFormat
+		if value == "" {
+			value = stringValue(answer.Answer)
+		}
+		fields["q"+qid] = value
+	}
+	return fields
+}
+
+func (s *Server) listSubmissions(req *request, formID int64) (interface{}, error) {
+	var submissions []jotform.Submission
+	for _, submission := range s.submissions {
+		if formID == 0 || int64(submission.FormID) == formID {
+			submissions = append(submissions, *submission)
+		}
+	}
+	return listPage(req, submissions, submissionFields)
+}
+
+func (s *Server) getSubmissions(req *request) (interface{}, error) {
+	return s.listSubmissions(req, 0)
+}
+
+func (s *Server) getFormSubmissions(req *request) (interface{}, error) {
+	f, err := s.formParam(req)
+	if err != nil {
+		return nil, err
+	}
+	return s.listSubmissions(req, int64(f.form.ID))
+}
+
+func (s *Server) getSubmission(req *request) (interface{}, error) {
+	id, err := idParam(req, 0, "Submission")
+	if err != nil {
+		return nil, err
+	}
+	submission, ok := s.submissions[id]
+	if !ok {
+		return nil, notFound("Submission not found")
+	}
+	return submission, nil
+}
+
+func submissionResult(submission *jotform.Submission) jotform.SubmissionResult {
+	id := strconv.FormatInt(int64(submission.ID), 10)
+	return jotform.SubmissionResult{
+		SubmissionID: submission.ID,
+		URL:          "https://api.jotform.com/submission/" + id,
+	}
+}
+
+// answerValues groups submission parameters such as submission[3] and submission[3][first]
+// by qid, leaving out parameters that are not answers, such as submission[new].
+func answerValues(values map[string][]string) (answers map[string]interface{}, other map[string]string) {
+	answers = make(map[string]interface{})
+	other = make(map[string]string)
+	for key, value := range values {
+		parts := splitKey(key)
+		if parts[0] != "submission" || len(parts) < 2 || len(value) == 0 {
+			continue
+		}
+
+		qid := parts[1]
+		if _, err := strconv.Atoi(qid); err != nil {
+			other[qid] = value[0]
+			continue
+		}
+		if len(parts) == 2 {
+			answers[qid] = value[0]
+			continue
+		}
+		subfields, ok := answers[qid].(map[string]string)
+		if !ok {
+			subfields = make(map[string]string)
+			answers[qid] = subfields
+		}
+		subfields[parts[2]] = value[0]
+	}
+	return answers, other
+}
+
+// setAnswer sets the answer to a question from its submitted value,
+// a string or a map of subfields, merging subfields into an existing answer.
+func (f *form) setAnswer(submission *jotform.Submission, qid string, value interface{}) {
+	answer := submission.Answers[qid]
+	if question, ok := f.questions[qid]; ok {
+		answer.Name = question["name"]
+		answer.Type = question["type"]
+		answer.Text = question["text"]
+		order, _ := strconv.ParseInt(question["order"], 10, 64)
+		answer.Order = jotform.Int(order)
+	}
+
+	switch v := value.(type) {
+	case string:
+		answer.Answer, _ = json.Marshal(v)
+		answer.PrettyFormat = ""
+	case map[string]string:
+		subfields := make(map[string]string)
+		json.Unmarshal(answer.Answer, &subfields)
+		for key, part := range v {
+			subfields[key] = part
+		}
+		answer.Answer, _ = json.Marshal(subfields)
+		answer.PrettyFormat = prettyFormat(subfields)
+	}
+
+	if submission.Answers == nil {
+		submission.Answers = make(map[string]jotform.Answer)
+	}
+	submission.Answers[qid] = answer
+}
+
+// prettyFormat joins the subfields of an answer, in the order JotForm shows them.
+func prettyFormat(subfields map[string]string) string {
+	order := []string{"prefix", "first", "middle", "last", "suffix", "addr_line1", "addr_line2", "city", "state", "postal", "country"}
+	known := make(map[string]bool, len(order))
+	var parts []string
+	for _, key := range order {
+		known[key] = true
+		if subfields[key] != "" {
+			parts = append(parts, subfields[key])
+		}
+	}
+
+	var rest []string
+	for key := range subfields {
+		if !known[key] && subfields[key] != "" {
+			rest = append(rest, key)
+		}
+	}
+	sort.Strings(rest)
+	for _, key := range rest {
+		parts = append(parts, subfields[key])
+	}
+	return strings.Join(parts, " ")
+}
+
+func (s *Server) createSubmission(f *form, answers map[string]interface{}) *jotform.Submission {
+	now := s.now()
+	submission := &jotform.Submission{
+		ID:        jotform.Int(s.nextID()),
+		FormID:    f.form.ID,
+		IP:        "127.0.0.1",
+		Status:    "ACTIVE",
+		New:       true,
+		CreatedAt: now,
+		Answers:   make(map[string]jotform.Answer),
+	}
+	for qid, value := range answers {
+		f.setAnswer(submission, qid, value)
+	}
+
+	s.submissions[int64(submission.ID)] = submission
+	f.form.LastSubmission = now
+	s.countSubmissions(int64(f.form.ID))
+	return submission
+}
+
+func (s *Server) postSubmission(req *request) (interface{}, error) {
+	f, err := s.formParam(req)
+	if err != nil {
+		return nil, err
+	}
+	answers, _ := answerValues(req.Form)
+	return submissionResult(s.createSubmission(f, answers)), nil
+}
+
+// putSubmissions creates submissions from a JSON array of objects
+// keyed by qid, or qid_subfield such as "3_first".
+func (s *Server) putSubmissions(req *request) (interface{}, error) {
+	f, err := s.formParam(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []map[string]json.RawMessage
+	if err := json.Unmarshal(req.Body, &items); err != nil {
+		return nil, badRequest("invalid submissions: " + err.Error())
+	}
+
+	results := make([]jotform.SubmissionResult, 0, len(items))
+	for _, item := range items {
+		answers := make(map[string]interface{})
+		for key, raw := range item {
+			value := stringValue(raw)
+			i := strings.Index(key, "_")
+			if i < 0 {
+				answers[key] = value
+				continue
+			}
+			subfields, ok := answers[key[:i]].(map[string]string)
+			if !ok {
+				subfields = make(map[string]string)
+				answers[key[:i]] = subfields
+			}
+			subfields[key[i+1:]] = value
+		}
+		results = append(results, submissionResult(s.createSubmission(f, answers)))
+	}
+	return results, nil
+}
+
+func (s *Server) editSubmission(req *request) (interface{}, error) {
+	id, err := idParam(req, 0, "Submission")
+	if err != nil {
+		return nil, err
+	}
+	submission, ok := s.submissions[id]
+	if !ok {
+		return nil, notFound("Submission not found")
+	}
+	f, ok := s.forms[int64(submission.FormID)]
+	if !ok {
+		f = &form{questions: map[string]map[string]string{}}
+	}
+
+	answers, other := answerValues(req.Form)
+	for qid, value := range answers {
+		f.setAnswer(submission, qid, value)
+	}
+	for key, value := range other {
+		switch key {
+		case "new":
+			submission.New = value == "1"
+		case "flag":
+			submission.Flag = value == "1"
+		case "notes":
+			submission.Notes = value
+		case "status":
+			submission.Status = value
+		case "created_at":
+			var t jotform.Time
+			if err := json.Unmarshal([]byte(strconv.Quote(value)), &t); err != nil {
+				return nil, badRequest("invalid created_at " + strconv.Quote(value))
+			}
+			submission.CreatedAt = t
+		}
+	}
+	submission.UpdatedAt = s.now()
+	s.countSubmissions(int64(submission.FormID))
+	return submissionResult(submission), nil
+}
+
+func (s *Server) deleteSubmission(req *request) (interface{}, error) {
+	id, err := idParam(req, 0, "Submission")
+	if err != nil {
+		return nil, err
+	}
+	submission, ok := s.submissions[id]
+	if !ok {
+		return nil, notFound("Submission not found")
+	}
+	delete(s.submissions, id)
+	s.countSubmissions(int64(submission.FormID))
+	return fmt.Sprintf("Submission #%d deleted successfully.", id), nil
+}
+
+func (s *Server) getFiles(req *request) (interface{}, error) {
+	f, err := s.formParam(req)
+	if err != nil {
+		return nil, err
+	}
+	files := []jotform.File{}
+	for _, file := range s.files {
+		if file.FormID == f.form.ID {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// serveUpload serves a file added with AddUpload, honouring Range requests.
+func (s *Server) serveUpload(w http.ResponseWriter, r *http.Request, path string) {
+	content, ok := s.uploads[path]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	http.ServeContent(w, r, path, time.Time{}, bytes.NewReader(content))
+}
+
+func (f *form) webhooksContent() map[string]string {
+	webhooks := make(map[string]string, len(f.webhooks))
+	for id, url := range f.webhooks {
+		webhooks[strconv.FormatInt(id, 10)] = url
+	}
+	return webhooks
+}
+
+func (s *Server) getWebhooks(req *request) (interface{}, error) {
+	f, err := s.formParam(req)
+	if err != nil {
+		return nil, err
+	}
+	return f.webhooksContent(), nil
+}
+
+func (s *Server) postWebhook(req *request) (interface{}, error) {
+	f, err := s.formParam(req)
+	if err != nil {
+		return nil, err
+	}
+	url := req.Form.Get("webhookURL")
+	if url == "" {
+		return nil, badRequest("webhookURL is required")
+	}
+	f.webhooks[s.nextID()] = url
+	return f.webhooksContent(), nil
+}
+
+func (s *Server) deleteWebhook(req *request) (interface{}, error) {
+	f, err := s.formParam(req)
+	if err != nil {
+		return nil, err
+	}
+	id, err := idParam(req, 1, "Webhook")
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := f.webhooks[id]; !ok {
+		return nil, notFound("Webhook not found")
+	}
+	delete(f.webhooks, id)
+	return f.webhooksContent(), nil
+}
+
+func (s *Server) reportsOf(formID int64) []jotform.Report {
+	reports := []jotform.Report{}
+	for _, report := range s.reports {
+		if formID == 0 || int64(report.FormID) == formID {
+			reports = append(reports, *report)
+		}
+	}
+	sort.Slice(reports, func(i, j int) bool { return reports[i].ID < reports[j].ID })
+	return reports
+}
+
+func (s *Server) getReports(req *request) (interface{}, error) {
+	return s.reportsOf(0), nil
+}
+
+func (s *Server) getFormReports(req *request) (interface{}, error) {
+	f, err := s.formParam(req)
+	if err != nil {
+		return nil, err
+	}
+	return s.reportsOf(int64(f.form.ID)), nil
+}
+
+func (s *Server) getReport(req *request) (interface{}, error) {
+	id, err := idParam(req, 0, "Report")
+	if err != nil {
+		return nil, err
+	}
+	report, ok := s.reports[id]
+	if !ok {
+		return nil, notFound("Report not found")
+	}
+	return report, nil
+}
+
+func (s *Server) postReport(req *request) (interface{}, error) {
+	f, err := s.formParam(req)
+	if err != nil {
+		return nil, err
+	}
+	if req.Form.Get("title") == "" || req.Form.Get("list_type") == "" {
+		return nil, badRequest("title and list_type are required")
+	}
+
+	now := s.now()
+	id := s.nextID()
+	report := &jotform.Report{
+		ID:        jotform.Int(id),
+		FormID:    f.form.ID,
+		Title:     req.Form.Get("title"),
+		ListType:  req.Form.Get("list_type"),
+		Fields:    req.Form.Get("fields"),
+		Status:    "ENABLED",
+		URL:       "https://www.jotform.com/report/" + strconv.FormatInt(id, 10),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	s.reports[id] = report
+	return report, nil
+}
+
+func (s *Server) deleteReport(req *request) (interface{}, error) {
+	id, err := idParam(req, 0, "Report")
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := s.reports[id]; !ok {
+		return nil, notFound("Report not found")
+	}
+	delete(s.reports, id)
+	return fmt.Sprintf("Report #%d deleted successfully.", id), nil
+}
+
+func (s *Server) getFolders(req *request) (interface{}, error) {
+	return s.folders, nil
+}
+
+func (s *Server) getFolder(req *request) (interface{}, error) {
+	if folder := findFolder(s.folders, req.params[0]); folder != nil {
+		return folder, nil
+	}
+	return nil, notFound("Folder not found")
+}
+
+func findFolder(folder *jotform.Folder, id string) *jotform.Folder {
+	if folder.ID == id {
+		return folder
+	}
+	for _, subfolder := range folder.Subfolders {
+		if found := findFolder(subfolder, id); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// findParent returns the folder holding the subfolder id.
+func findParent(folder *jotform.Folder, id string) *jotform.Folder {
+	for _, subfolder := range folder.Subfolders {
+		if subfolder.ID == id {
+			return folder
+		}
+		if found := findParent(subfolder, id); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// detachFolder removes the subfolder id from the tree, and returns it.
+func (s *Server) detachFolder(id string) *jotform.Folder {
+	parent := findParent(s.folders, id)
+	for i, subfolder := range parent.Subfolders {
+		if subfolder.ID == id {
+			parent.Subfolders = append(parent.Subfolders[:i:i], parent.Subfolders[i+1:]...)
+			return subfolder
+		}
+	}
+	return nil
+}
+
+// takeForms removes forms from every folder in the tree.
+func takeForms(folder *jotform.Folder, formIDs map[jotform.Int]bool) {
+	var kept []jotform.Form
+	for _, f := range folder.Forms {
+		if !formIDs[f.ID] {
+			kept = append(kept, f)
+		}
+	}
+	folder.Forms = kept
+	for _, subfolder := range folder.Subfolders {
+		takeForms(subfolder, formIDs)
+	}
+}
+
+// allForms returns the forms in a folder and its subfolders.
+func allForms(folder *jotform.Folder) []jotform.Form {
+	forms := append([]jotform.Form(nil), folder.Forms...)
+	for _, subfolder := range folder.Subfolders {
+		forms = append(forms, allForms(subfolder)...)
+	}
+	return forms
+}
+
+func (s *Server) postFolder(req *request) (interface{}, error) {
+	name := req.Form.Get("name")
+	if name == "" {
+		return nil, badRequest("name is required")
+	}
+	parentID := req.Form.Get("parent")
+	if parentID == "" {
+		parentID = s.folders.ID
+	}
+	parent := findFolder(s.folders, parentID)
+	if parent == nil {
+		return nil, notFound("Parent folder not found")
+	}
+
+	folder := jotform.Folder{
+		ID:     strconv.FormatInt(s.nextID(), 16),
+		Owner:  s.user.Username,
+		Name:   name,
+		Parent: parent.ID,
+		Color:  req.Form.Get("color"),
+	}
+	parent.Subfolders = append(parent.Subfolders, &folder)
+	return folder, nil
+}
+
+// putFolder updates a folder from a JSON object of name, color, parent and forms.
+func (s *Server) putFolder(req *request) (interface{}, error) {
+	id := req.params[0]
+	if findFolder(s.folders, id) == nil {
+		return nil, notFound("Folder not found")
+	}
+
+	var body struct {
+		Name   *string  `json:"name"`
+		Color  *string  `json:"color"`
+		Parent *string  `json:"parent"`
+		Forms  []string `json:"forms"`
+	}
+	if err := json.Unmarshal(req.Body, &body); err != nil {
+		return nil, badRequest("invalid folder: " + err.Error())
+	}
+
+	if body.Parent != nil && id == s.folders.ID {
+		return nil, badRequest("The root folder cannot be moved")
+	}
+	if body.Parent != nil && *body.Parent != findParent(s.folders, id).ID {
+		moving := findFolder(s.folders, id)
+		if findFolder(moving, *body.Parent) != nil {
+			return nil, badRequest("A folder cannot be moved into itself")
+		}
+		if findFolder(s.folders, *body.Parent) == nil {
+			return nil, notFound("Parent folder not found")
+		}
+
+		folder := s.detachFolder(id)
+		folder.Parent = *body.Parent
+		parent := findFolder(s.folders, *body.Parent)
+		parent.Subfolders = append(parent.Subfolders, folder)
+	}
+
+	folder := findFolder(s.folders, id)
+	if body.Name != nil {
+		folder.Name = *body.Name
+	}
+	if body.Color != nil {
+		folder.Color = *body.Color
+	}
+
+	if len(body.Forms) > 0 {
+		moved := make(map[jotform.Int]bool, len(body.Forms))
+		var forms []jotform.Form
+		for _, formID := range body.Forms {
+			f, ok := s.forms[parseID(formID)]
+			if !ok {
+				return nil, notFound("Form " + formID + " not found")
+			}
+			moved[f.form.ID] = true
+			forms = append(forms, f.form)
+		}
+		takeForms(s.folders, moved)
+		folder = findFolder(s.folders, id)
+		folder.Forms = append(folder.Forms, forms...)
+	}
+	return folder, nil
+}
+
+// deleteFolder deletes a folder and its subfolders, moving their forms to the root folder.
+func (s *Server) deleteFolder(req *request) (interface{}, error) {
+	id := req.params[0]
+	if id == s.folders.ID {
+		return nil, badRequest("The root folder cannot be deleted")
+	}
+	if findFolder(s.folders, id) == nil {
+		return nil, notFound("Folder not found")
+	}
+
+	folder := s.detachFolder(id)
+	s.folders.Forms = append(s.folders.Forms, allForms(folder)...)
+	return "Folder deleted", nil
+}
+
+// fillPDF fills in the PDF set by SetFormPDF.
+func (s *Server) fillPDF(req *request) (interface{}, error) {
+	f, err := s.formParam(req)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := s.submissions[parseID(req.Query.Get("submissionID"))]; !ok {
+		return nil, notFound("Submission not found")
+	}
+	if f.pdf == nil {
+		return nil, badRequest("draw-pdf-answers Request Failed")
+	}
+	return pdfContent(f.pdf), nil
+}
+
+// generatePDF returns a minimal PDF listing a submission's answers.
+func (s *Server) generatePDF(req *request) (interface{}, error) {
+	submission, ok := s.submissions[parseID(req.Query.Get("submissionid"))]
+	if !ok || strconv.FormatInt(int64(submission.FormID), 10) != req.Query.Get("formid") {
+		return nil, &httpError{code: http.StatusNotFound, message: "Submission not found"}
+	}
+
+	qids := make([]string, 0, len(submission.Answers))
+	for qid := range submission.Answers {
+		qids = append(qids, qid)
+	}
+	sort.Slice(qids, func(i, j int) bool { return compare(qids[i], qids[j]) < 0 })
+
+	var b strings.Builder
+	b.WriteString("%PDF-1.4\n")
+	fmt.Fprintf(&b, "%% Submission %d\n", int64(submission.ID))
+	for _, qid := range qids {
+		answer := submission.Answers[qid]
+		value := answer.PrettyFormat
+		if value == "" {
+			value = stringValue(answer.Answer)
+		}
+		fmt.Fprintf(&b, "%% %s: %s\n", answer.Text, value)
+	}
+	b.WriteString("%%EOF\n")
+	return pdfContent(b.String()), nil
+}
+
+func parseID(s string) int64 {
+	id, _ := strconv.ParseInt(s, 10, 64)
+	return id
+}
@@ -0,0 +1,227 @@
+package jotformtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	jotform "github.com/jotform/jotform-api-go/v2"
+)
+
+// defaultLimit and maxLimit are JotForm's page sizes when limit is missing, and at most.
+const (
+	defaultLimit = 20
+	maxLimit     = 1000
+)
+
+// condition is one condition of a filter, such as {"created_at:gt": "2020-01-01"}.
+type condition struct {
+	field string
+	op    string
+	value string
+}
+
+// parseFilter decodes a filter parameter, keeping its conditions in order
+// and allowing several conditions on the same field.
+func parseFilter(raw string) ([]condition, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || raw == "null" {
+		return nil, nil
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(raw))
+	decoder.UseNumber()
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil, fmt.Errorf("invalid filter %q", raw)
+	}
+
+	var conditions []condition
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter %q", raw)
+		}
+		key := token.(string)
+
+		var value interface{}
+		if err := decoder.Decode(&value); err != nil {
+			return nil, fmt.Errorf("invalid filter %q", raw)
+		}
+
+		c := condition{field: key, value: fmt.Sprint(value)}
+		if i := strings.Index(key, ":"); i >= 0 {
+			c.field, c.op = key[:i], key[i+1:]
+		}
+		switch c.op {
+		case "", "eq", "ne", "gt", "gte", "lt", "lte", "matches":
+		default:
+			return nil, fmt.Errorf("invalid filter operator %q", c.op)
+		}
+		conditions = append(conditions, c)
+	}
+	return conditions, nil
+}
+
+// fieldsOf flattens a model into its JSON fields as strings, as JotForm filters on them.
+func fieldsOf(v interface{}) map[string]string {
+	data, _ := json.Marshal(v)
+	var raw map[string]json.RawMessage
+	json.Unmarshal(data, &raw)
+
+	fields := make(map[string]string, len(raw))
+	for key, value := range raw {
+		fields[key] = stringValue(value)
+	}
+	return fields
+}
+
+// stringValue returns a JSON value as a string, unquoting strings.
+func stringValue(value json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		return s
+	}
+	if bytes.Equal(bytes.TrimSpace(value), []byte("null")) {
+		return ""
+	}
+	return string(value)
+}
+
+func (c condition) matches(fields map[string]string) bool {
+	if c.field == "fullText" {
+		text := strings.ToLower(c.value)
+		for _, value := range fields {
+			if strings.Contains(strings.ToLower(value), text) {
+				return true
+			}
+		}
+		return false
+	}
+
+	value, ok := fields[c.field]
+	if !ok {
+		return false
+	}
+
+	switch c.op {
+	case "", "eq":
+		return compare(value, c.value) == 0
+	case "ne":
+		return compare(value, c.value) != 0
+	case "gt":
+		return compare(value, c.value) > 0
+	case "gte":
+		return compare(value, c.value) >= 0
+	case "lt":
+		return compare(value, c.value) < 0
+	case "lte":
+		return compare(value, c.value) <= 0
+	case "matches":
+		return strings.Contains(strings.ToLower(value), strings.ToLower(c.value))
+	}
+	return false
+}
+
+// compare compares values as numbers if both are numbers, and as strings otherwise.
+// Timestamps in JotForm's layout compare correctly as strings.
+func compare(a string, b string) int {
+	x, errA := strconv.ParseFloat(a, 64)
+	y, errB := strconv.ParseFloat(b, 64)
+	if errA == nil && errB == nil {
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(a, b)
+}
+
+// listPage filters, orders and pages items by the filter, orderby, offset and limit parameters.
+// Items are ordered newest first unless orderby says otherwise.
+func listPage[T any](req *request, items []T, fields func(T) map[string]string) (paged, error) {
+	conditions, err := parseFilter(req.Query.Get("filter"))
+	if err != nil {
+		return paged{}, badRequest(err.Error())
+	}
+
+	offset, err := intParam(req, "offset", 0)
+	if err != nil {
+		return paged{}, err
+	}
+	limit, err := intParam(req, "limit", defaultLimit)
+	if err != nil {
+		return paged{}, err
+	}
+	if limit <= 0 || limit > maxLimit {
+		limit = maxLimit
+	}
+
+	type row struct {
+		item   T
+		fields map[string]string
+	}
+	var rows []row
+	for _, item := range items {
+		r := row{item: item, fields: fields(item)}
+		matched := true
+		for _, c := range conditions {
+			if !c.matches(r.fields) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			rows = append(rows, r)
+		}
+	}
+
+	orderBy, descending := "created_at", true
+	if value := req.Query.Get("orderby"); value != "" {
+		orderBy = value
+		if i := strings.Index(value, ","); i >= 0 {
+			orderBy = value[:i]
+			descending = !strings.EqualFold(strings.TrimSpace(value[i+1:]), "ASC")
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		c := compare(rows[i].fields[orderBy], rows[j].fields[orderBy])
+		if c == 0 {
+			c = compare(rows[i].fields["id"], rows[j].fields["id"])
+		}
+		if descending {
+			return c > 0
+		}
+		return c < 0
+	})
+
+	page := make([]T, 0, limit)
+	for i := offset; i < len(rows) && len(page) < limit; i++ {
+		page = append(page, rows[i].item)
+	}
+	return paged{
+		items: page,
+		resultSet: jotform.ResultSet{
+			Offset: jotform.Int(offset),
+			Limit:  jotform.Int(limit),
+			Count:  jotform.Int(len(page)),
+		},
+	}, nil
+}
+
+func intParam(req *request, key string, defaultValue int) (int, error) {
+	value := req.Query.Get(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, badRequest(fmt.Sprintf("invalid %s %q", key, value))
+	}
+	return n, nil
+}
@@ -0,0 +1,296 @@
+// Package jotformtest provides an in-memory fake of the JotForm API
+// for end-to-end tests of code using the jotform client.
+//
+//	server := jotformtest.NewServer()
+//	defer server.Close()
+//	form := server.AddForm(jotform.Form{Title: "Contact"})
+//
+//	client := jotform.NewJotFormAPIClient("api-key", "json", false)
+//	client.BaseURL = server.URL
+package jotformtest
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	jotform "github.com/jotform/jotform-api-go/v2"
+)
+
+// Server is a fake JotForm API server keeping its data in memory.
+// Its fields must be set before the server receives requests.
+type Server struct {
+	*httptest.Server
+
+	// APIKey, if set, is the only API key the server accepts.
+	APIKey string
+	// Now returns the time used to timestamp new and updated data.
+	// Defaults to time.Now.
+	Now func() time.Time
+
+	mu          sync.Mutex
+	lastID      int64
+	user        jotform.User
+	settings    map[string]string
+	forms       map[int64]*form
+	submissions map[int64]*jotform.Submission
+	reports     map[int64]*jotform.Report
+	files       []jotform.File
+	uploads     map[string][]byte
+	history     []jotform.HistoryEntry
+	folders     *jotform.Folder
+	faults      []*Fault
+	requests    []Request
+	quota       int
+	apiCalls    int
+}
+
+// form is a form along with the data JotForm keeps for it.
+type form struct {
+	form jotform.Form
+	// questions holds each question's properties, keyed by qid.
+	questions  map[string]map[string]string
+	properties map[string]string
+	webhooks   map[int64]string
+	// emails holds the properties of each email the form sends, in order.
+	emails []map[string]string
+	pdf    []byte
+}
+
+// Request is a request received by the Server.
+type Request struct {
+	Method string
+	// Path is the path of the endpoint, eg. "form/1/submissions".
+	Path  string
+	Query url.Values
+	// Form holds the parameters of a POST request.
+	Form url.Values
+	// Body is the body of a PUT request.
+	Body   []byte
+	APIKey string
+	// Range is the Range header of a request for an upload.
+	Range string
+	// Header holds the request's headers.
+	Header http.Header
+}
+
+// NewServer starts a Server with an empty account.
+// The caller should call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		user: jotform.User{
+			Username:    "jotformtest",
+			Name:        "JotForm Test",
+			Email:       "test@example.com",
+			AccountType: "https://api.jotform.com/system/plan/FREE",
+			Status:      "ACTIVE",
+			Region:      "US",
+		},
+		settings:    make(map[string]string),
+		forms:       make(map[int64]*form),
+		submissions: make(map[int64]*jotform.Submission),
+		reports:     make(map[int64]*jotform.Report),
+		uploads:     make(map[string][]byte),
+		folders:     &jotform.Folder{ID: "root", Name: "root"},
+		lastID:      1000,
+		quota:       -1,
+	}
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// Requests returns the requests received so far, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// SetQuota sets the number of API calls left today,
+// which the server reports as limit-left and counts down on every request.
+// Once it reaches zero, requests fail with a 429. A negative quota is unlimited.
+func (s *Server) SetQuota(remaining int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.quota = remaining
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	asXML := isXML(r.URL.Path)
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, asXML, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	req := &request{
+		Request: Request{
+			Method: r.Method,
+			Path:   strings.TrimSuffix(strings.Trim(strings.TrimPrefix(r.URL.Path, "/v1"), "/"), ".xml"),
+			Query:  r.URL.Query(),
+			APIKey: r.Header.Get("apiKey"),
+			Range:  r.Header.Get("Range"),
+			Header: r.Header.Clone(),
+		},
+	}
+	if r.Method == "POST" {
+		req.Form, _ = url.ParseQuery(string(body))
+	} else if r.Method == "PUT" {
+		req.Body = body
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req.Request)
+	fault := s.takeFault(req.Method, req.Path)
+	s.mu.Unlock()
+
+	if fault != nil && fault.apply(w, r) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.APIKey != "" && req.APIKey != s.APIKey {
+		writeError(w, asXML, http.StatusUnauthorized, "You're not authorized to use ("+req.Path+")")
+		return
+	}
+
+	// Uploads are not API calls, so don't count against the quota.
+	if strings.HasPrefix(req.Path, "uploads/") {
+		s.serveUpload(w, r, req.Path)
+		return
+	}
+
+	if s.quota == 0 {
+		writeError(w, asXML, http.StatusTooManyRequests, "You have reached your daily limit")
+		return
+	}
+	s.apiCalls++
+	if s.quota > 0 {
+		s.quota--
+	}
+
+	handle, ok := s.route(req)
+	if !ok || (asXML && isPDFPath(req.Path)) {
+		writeError(w, asXML, http.StatusNotFound, "Requested URL ("+req.Path+") is not available!")
+		return
+	}
+
+	content, err := handle(s, req)
+	if err != nil {
+		if e, ok := err.(*httpError); ok {
+			writeError(w, asXML, e.code, e.message)
+		} else {
+			writeError(w, asXML, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	if pdf, ok := content.(pdfContent); ok {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Write(pdf)
+		return
+	}
+
+	env := map[string]interface{}{
+		"responseCode": http.StatusOK,
+		"message":      "success",
+		"content":      content,
+		"duration":     "1ms",
+	}
+	if p, ok := content.(paged); ok {
+		env["content"] = p.items
+		env["resultSet"] = p.resultSet
+	}
+	if s.quota >= 0 {
+		env["limit-left"] = s.quota
+	}
+	writeEnvelope(w, asXML, http.StatusOK, env)
+}
+
+// request is a Request with the parameters matched from its path.
+type request struct {
+	Request
+	params []string
+}
+
+// httpError is an error response from a handler.
+type httpError struct {
+	code    int
+	message string
+}
+
+func (e *httpError) Error() string { return e.message }
+
+func notFound(message string) error {
+	return &httpError{code: http.StatusNotFound, message: message}
+}
+
+func badRequest(message string) error {
+	return &httpError{code: http.StatusBadRequest, message: message}
+}
+
+// pdfContent is content written as a PDF rather than in the JSON envelope.
+type pdfContent []byte
+
+// paged is content with its paging metadata.
+type paged struct {
+	items     interface{}
+	resultSet jotform.ResultSet
+}
+
+func writeError(w http.ResponseWriter, asXML bool, code int, message string) {
+	writeEnvelope(w, asXML, code, map[string]interface{}{
+		"responseCode": code,
+		"message":      message,
+		"content":      "",
+		"duration":     "1ms",
+		"info":         "https://api.jotform.com/docs/",
+	})
+}
+
+// writeEnvelope writes a response envelope as json, or as XML if asXML.
+func writeEnvelope(w http.ResponseWriter, asXML bool, code int, env interface{}) {
+	if asXML {
+		body, err := encodeXML(env)
+		if err != nil {
+			code = http.StatusInternalServerError
+			body = []byte(xml.Header + `<response><responseCode>500</responseCode><message>jotformtest: cannot encode response</message><content></content></response>`)
+		}
+		w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		w.WriteHeader(code)
+		w.Write(body)
+		return
+	}
+
+	body, err := json.Marshal(env)
+	if err != nil {
+		code = http.StatusInternalServerError
+		body = []byte(`{"responseCode":500,"message":"jotformtest: cannot encode response","content":""}`)
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(code)
+	w.Write(body)
+}
+
+func (s *Server) now() jotform.Time {
+	now := time.Now
+	if s.Now != nil {
+		now = s.Now
+	}
+	return jotform.Time{Time: now().In(jotform.TimeLocation).Truncate(time.Second)}
+}
+
+func (s *Server) nextID() int64 {
+	s.lastID++
+	return s.lastID
+}
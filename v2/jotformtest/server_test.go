@@ -0,0 +1,211 @@
+package jotformtest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	jotform "github.com/jotform/jotform-api-go/v2"
+	"github.com/jotform/jotform-api-go/v2/jotformtest"
+	"github.com/stretchr/testify/assert"
+)
+
+var fixedNow = time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+
+	server := jotformtest.NewServer()
+	defer server.Close()
+	server.APIKey = "api-key"
+	server.Now = func() time.Time { return fixedNow }
+
+	client := jotform.NewJotFormAPIClient("api-key", "json", false)
+	client.BaseURL = server.URL
+	client.Retry = &jotform.RetryPolicy{
+		MaxAttempts:          3,
+		BaseDelay:            time.Millisecond,
+		RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+	}
+
+	form := server.AddForm(jotform.Form{Title: "Contact"},
+		jotform.Question{Type: "control_fullname", Name: "name", Text: "Name"},
+		jotform.Question{Type: "control_email", Name: "email", Text: "Email"},
+	)
+	formID := int64(form.ID)
+
+	t.Run("happy - user", func(t *testing.T) {
+		user, err := client.GetUserTyped(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, "jotformtest", user.Username)
+	})
+
+	t.Run("happy - forms and questions", func(t *testing.T) {
+		got, err := client.GetFormTyped(ctx, formID)
+		assert.Nil(t, err)
+		assert.Equal(t, "Contact", got.Title)
+		assert.Equal(t, "ENABLED", got.Status)
+
+		questions, err := client.GetFormQuestionsTyped(ctx, formID)
+		assert.Nil(t, err)
+		assert.Len(t, questions, 2)
+		assert.Equal(t, jotform.Int(1), questions[0].QID)
+		assert.Equal(t, "control_email", questions[1].Type)
+
+		_, err = client.CreateFormQuestion(formID, map[string]string{"type": "control_textarea", "text": "Message"})
+		assert.Nil(t, err)
+		question, err := client.GetFormQuestionTyped(ctx, formID, 3)
+		assert.Nil(t, err)
+		assert.Equal(t, "Message", question.Text)
+	})
+
+	t.Run("happy - submissions round trip", func(t *testing.T) {
+		result, err := client.CreateFormSubmissionTyped(ctx, formID, map[string]string{
+			"1_first": "Ada",
+			"1_last":  "Lovelace",
+			"2":       "ada@example.com",
+		})
+		assert.Nil(t, err)
+
+		submission, err := client.GetSubmissionTyped(ctx, int64(result.SubmissionID))
+		assert.Nil(t, err)
+		assert.Equal(t, form.ID, submission.FormID)
+		assert.Equal(t, "Ada Lovelace", submission.Answers["1"].PrettyFormat)
+		assert.Equal(t, "control_email", submission.Answers["2"].Type)
+		assert.Equal(t, fixedNow, submission.CreatedAt.Time)
+
+		_, err = client.EditSubmission(int64(result.SubmissionID), map[string]string{"flag": "1", "2": "ada@lovelace.dev"})
+		assert.Nil(t, err)
+		stored, _ := server.Submission(int64(result.SubmissionID))
+		assert.True(t, bool(stored.Flag))
+		assert.Equal(t, `"ada@lovelace.dev"`, string(stored.Answers["2"].Answer))
+
+		_, err = client.DeleteSubmission(int64(result.SubmissionID))
+		assert.Nil(t, err)
+		_, err = client.GetSubmissionTyped(ctx, int64(result.SubmissionID))
+		assert.True(t, errors.Is(err, jotform.ErrNotFound))
+	})
+
+	t.Run("happy - filter, order and page submissions", func(t *testing.T) {
+		pager := server.AddForm(jotform.Form{Title: "Pager"})
+		for day := 1; day <= 5; day++ {
+			server.AddSubmission(jotform.Submission{
+				FormID:    pager.ID,
+				CreatedAt: jotform.Time{Time: time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC)},
+				Answers:   map[string]jotform.Answer{"3": {Answer: []byte(`"day"`)}},
+			})
+		}
+
+		opts := &jotform.ListOptions{
+			Limit: 2,
+			Where: jotform.Where("created_at").After(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)).OrderBy("created_at,ASC"),
+		}
+		page, err := client.GetFormSubmissionsTyped(ctx, int64(pager.ID), opts)
+		assert.Nil(t, err)
+		assert.Len(t, page, 2)
+		assert.Equal(t, 2, page[0].CreatedAt.Day())
+		assert.Equal(t, 3, page[1].CreatedAt.Day())
+
+		var days []int
+		it := client.IterFormSubmissions(ctx, int64(pager.ID), &jotform.IterOptions{ListOptions: jotform.ListOptions{Limit: 2}})
+		for it.Next() {
+			days = append(days, it.Submission().CreatedAt.Day())
+		}
+		assert.Nil(t, it.Err())
+		assert.Equal(t, []int{5, 4, 3, 2, 1}, days)
+
+		matched, err := client.GetFormSubmissionsTyped(ctx, int64(pager.ID), &jotform.ListOptions{
+			Where: jotform.Where(jotform.QuestionField(3)).Eq("day"),
+		})
+		assert.Nil(t, err)
+		assert.Len(t, matched, 5)
+	})
+
+	t.Run("happy - webhooks", func(t *testing.T) {
+		webhooks, err := client.CreateFormWebhookTyped(ctx, formID, "https://example.com/hook")
+		assert.Nil(t, err)
+		assert.Len(t, webhooks, 1)
+
+		webhooks, err = client.DeleteFormWebhookTyped(ctx, formID, int64(webhooks[0].ID))
+		assert.Nil(t, err)
+		assert.Len(t, webhooks, 0)
+		assert.Len(t, server.Webhooks(formID), 0)
+	})
+
+	t.Run("happy - folders and reports", func(t *testing.T) {
+		server.SetFolders(jotform.Folder{ID: "root", Subfolders: []*jotform.Folder{{ID: "f1", Name: "Sales", Parent: "root"}}})
+		folder, err := client.GetFolderTyped(ctx, "f1")
+		assert.Nil(t, err)
+		assert.Equal(t, "Sales", folder.Name)
+
+		report, err := client.CreateReportTyped(ctx, formID, map[string]string{"title": "All", "list_type": "csv"})
+		assert.Nil(t, err)
+		reports, err := client.GetFormReportsTyped(ctx, formID)
+		assert.Nil(t, err)
+		assert.Equal(t, []jotform.Int{report.ID}, []jotform.Int{reports[0].ID})
+	})
+
+	t.Run("happy - PDFs", func(t *testing.T) {
+		result, _ := client.CreateFormSubmissionTyped(ctx, formID, map[string]string{"2": "pdf@example.com"})
+		fid := strconv.FormatInt(formID, 10)
+		sid := strconv.FormatInt(int64(result.SubmissionID), 10)
+
+		pdf, err := client.DownloadSimplePDFSubmission(fid, sid, "")
+		assert.Nil(t, err)
+		assert.Contains(t, string(pdf), "%PDF-")
+		assert.Contains(t, string(pdf), "pdf@example.com")
+
+		_, err = client.DownloadRichPDFSubmission(fid, sid)
+		assert.True(t, errors.Is(err, jotform.ErrNotImplemented))
+
+		server.SetFormPDF(formID, []byte("%PDF-1.7 filled"))
+		pdf, err = client.DownloadRichPDFSubmission(fid, sid)
+		assert.Nil(t, err)
+		assert.Equal(t, "%PDF-1.7 filled", string(pdf))
+	})
+
+	t.Run("sad - wrong API key", func(t *testing.T) {
+		other := jotform.NewJotFormAPIClient("wrong", "json", false)
+		other.BaseURL = server.URL
+
+		_, err := other.GetUser()
+		assert.True(t, errors.Is(err, jotform.ErrUnauthorized))
+	})
+
+	t.Run("sad - injected faults are retried", func(t *testing.T) {
+		server.InjectFault(jotformtest.Fault{Path: "user", StatusCode: http.StatusServiceUnavailable, Times: 2})
+
+		_, err := client.GetUser()
+		assert.Nil(t, err)
+
+		server.InjectFault(jotformtest.Fault{Path: "user", StatusCode: http.StatusServiceUnavailable})
+		_, err = client.GetUser()
+		assert.True(t, errors.Is(err, jotform.ErrServerError))
+		server.ClearFaults()
+	})
+
+	t.Run("sad - disconnect", func(t *testing.T) {
+		server.InjectFault(jotformtest.Fault{Path: "form/*", Disconnect: true})
+		defer server.ClearFaults()
+
+		_, err := client.GetFormTyped(ctx, formID)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("sad - quota", func(t *testing.T) {
+		server.SetQuota(1)
+		defer server.SetQuota(-1)
+
+		_, err := client.GetUser()
+		assert.Nil(t, err)
+		remaining, ok := client.QuotaRemaining()
+		assert.True(t, ok)
+		assert.Equal(t, 0, remaining)
+
+		_, err = client.GetUser()
+		assert.True(t, errors.Is(err, jotform.ErrRateLimited))
+	})
+}
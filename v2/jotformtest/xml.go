@@ -0,0 +1,130 @@
+package jotformtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// isXML reports whether a request path asks for XML output, as the client's "xml" output type does.
+func isXML(path string) bool {
+	return strings.HasSuffix(path, ".xml")
+}
+
+// encodeXML writes a response envelope as JotForm's XML output:
+// objects as elements named by their keys, or <item key="..."> if a key isn't a valid name,
+// arrays as <item>s marked type="array", and empty objects, numbers, booleans and nulls marked by type.
+func encodeXML(env interface{}) ([]byte, error) {
+	data, err := json.Marshal(env)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(&buf)
+	if err := encodeXMLValue(enc, xml.StartElement{Name: xml.Name{Local: "response"}}, value); err != nil {
+		return nil, err
+	}
+	if err := enc.Flush(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func encodeXMLValue(enc *xml.Encoder, start xml.StartElement, value interface{}) error {
+	typed := func(typ string) {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "type"}, Value: typ})
+	}
+
+	var text string
+	var children func() error
+	switch v := value.(type) {
+	case nil:
+		typed("null")
+	case bool:
+		typed("boolean")
+		text = "false"
+		if v {
+			text = "true"
+		}
+	case json.Number:
+		typed("number")
+		text = v.String()
+	case string:
+		text = v
+	case []interface{}:
+		typed("array")
+		children = func() error {
+			for _, item := range v {
+				if err := encodeXMLValue(enc, xml.StartElement{Name: xml.Name{Local: "item"}}, item); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	case map[string]interface{}:
+		if len(v) == 0 {
+			typed("object")
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		children = func() error {
+			for _, key := range keys {
+				child := xml.StartElement{Name: xml.Name{Local: key}}
+				if !isXMLName(key) || key == "item" {
+					child = xml.StartElement{
+						Name: xml.Name{Local: "item"},
+						Attr: []xml.Attr{{Name: xml.Name{Local: "key"}, Value: key}},
+					}
+				}
+				if err := encodeXMLValue(enc, child, v[key]); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	if err := enc.EncodeToken(start); err != nil {
+		return err
+	}
+	if children != nil {
+		if err := children(); err != nil {
+			return err
+		}
+	} else if text != "" {
+		if err := enc.EncodeToken(xml.CharData(text)); err != nil {
+			return err
+		}
+	}
+	return enc.EncodeToken(start.End())
+}
+
+// isXMLName reports whether s can be used as an element name.
+func isXMLName(s string) bool {
+	if s == "" || strings.HasPrefix(strings.ToLower(s), "xml") {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case unicode.IsLetter(r) || r == '_':
+		case i > 0 && (unicode.IsDigit(r) || r == '-' || r == '.'):
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,220 @@
+package jotform
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Doer sends HTTP requests. It is what middleware wraps, and what HttpClient is.
+type Doer = HttpClient
+
+// DoerFunc adapts a function to a Doer.
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps a Doer to add behaviour to every request the client sends.
+type Middleware func(next Doer) Doer
+
+// Use adds middleware to the client.
+//
+// Every request, including PDF and file downloads, passes through the middleware
+// in the order it was added, then the Retry policy, then the RateLimiter, and then HttpClient.
+// So middleware sees each call once, however many times it is retried.
+// To see every attempt instead, set Retry to nil and add RetryMiddleware before it:
+//
+//	client.Retry = nil
+//	client.Use(jotform.RetryMiddleware(jotform.DefaultRetryPolicy()), jotform.LoggingMiddleware(logger))
+func (client *Client) Use(middleware ...Middleware) {
+	// Copy, so that clients copied before Use don't share the new middleware.
+	client.middleware = append(client.middleware[:len(client.middleware):len(client.middleware)], middleware...)
+}
+
+// chain returns the Doer that sends the client's requests.
+func (client Client) chain() Doer {
+	var doer Doer = client.HttpClient
+	if client.RateLimiter != nil {
+		doer = limitedClient{next: doer, limiter: client.RateLimiter}
+	}
+	if client.Retry != nil {
+		doer = retryingClient{next: doer, policy: client.Retry}
+	}
+	for i := len(client.middleware) - 1; i >= 0; i-- {
+		doer = client.middleware[i](doer)
+	}
+	return doer
+}
+
+// RetryMiddleware retries requests according to policy, as the client's Retry field does.
+func RetryMiddleware(policy *RetryPolicy) Middleware {
+	return func(next Doer) Doer {
+		return retryingClient{next: next, policy: policy}
+	}
+}
+
+// RateLimitMiddleware waits on limiter before every request, as the client's RateLimiter field does.
+// The limiter is not told the remaining quota; set RateLimiter for that.
+func RateLimitMiddleware(limiter RateLimiter) Middleware {
+	return func(next Doer) Doer {
+		return limitedClient{next: next, limiter: limiter}
+	}
+}
+
+// LoggingMiddleware logs each request's method, URL, status and duration to logger,
+// at Info level, or Warn level if it failed.
+// The API key is sent in a header, and is not logged.
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.Do(req)
+
+			attrs := []any{"method", req.Method, "url", req.URL.String(), "duration", time.Since(start)}
+			switch {
+			case err != nil:
+				logger.WarnContext(req.Context(), "jotform request failed", append(attrs, "error", err)...)
+			case resp.StatusCode >= 400:
+				logger.WarnContext(req.Context(), "jotform request", append(attrs, "status", resp.StatusCode)...)
+			default:
+				logger.InfoContext(req.Context(), "jotform request", append(attrs, "status", resp.StatusCode)...)
+			}
+			return resp, err
+		})
+	}
+}
+
+// RequestMetrics describes a request sent, for MetricsMiddleware.
+type RequestMetrics struct {
+	Method string
+	// Path is the URL's path, eg. "/v1/form/1234/submissions".
+	Path string
+	// StatusCode is zero if the request failed without a response.
+	StatusCode int
+	Duration   time.Duration
+	Err        error
+}
+
+// MetricsMiddleware calls observe after every request, eg. to update a histogram.
+// observe may be called from several goroutines at once.
+func MetricsMiddleware(observe func(RequestMetrics)) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.Do(req)
+
+			metrics := RequestMetrics{Method: req.Method, Path: req.URL.Path, Duration: time.Since(start), Err: err}
+			if resp != nil {
+				metrics.StatusCode = resp.StatusCode
+			}
+			observe(metrics)
+			return resp, err
+		})
+	}
+}
+
+// DefaultRequestIDHeader is the header RequestIDMiddleware sets by default.
+const DefaultRequestIDHeader = "X-Request-ID"
+
+// RequestIDMiddleware sets header, or DefaultRequestIDHeader if empty,
+// to a random ID on each request that doesn't already have one,
+// so that requests can be traced through proxies and logs.
+// Retries of a request keep its ID if the middleware comes before RetryMiddleware.
+func RequestIDMiddleware(header string) Middleware {
+	if header == "" {
+		header = DefaultRequestIDHeader
+	}
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get(header) == "" {
+				id := make([]byte, 16)
+				rand.Read(id)
+				req = req.Clone(req.Context())
+				req.Header.Set(header, hex.EncodeToString(id))
+			}
+			return next.Do(req)
+		})
+	}
+}
+
+// HeaderMiddleware adds header to every request, eg. to set a User-Agent.
+// Headers the request already has are replaced.
+func HeaderMiddleware(header http.Header) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			for key, values := range header {
+				req.Header[http.CanonicalHeaderKey(key)] = append([]string(nil), values...)
+			}
+			return next.Do(req)
+		})
+	}
+}
+
+// Recording is a response recorded by a Recorder.
+type Recording struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// Recorder keeps the responses RecordingMiddleware records, eg. to save as test fixtures.
+// It is safe for concurrent use.
+type Recorder struct {
+	mu         sync.Mutex
+	recordings []Recording
+}
+
+// Recordings returns the responses recorded so far, in order.
+func (r *Recorder) Recordings() []Recording {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Recording(nil), r.recordings...)
+}
+
+func (r *Recorder) add(recording Recording) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.recordings = append(r.recordings, recording)
+}
+
+// RecordingMiddleware records every response in recorder.
+// Each response body is read into memory to record it, so large downloads are best left unrecorded.
+func RecordingMiddleware(recorder *Recorder) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := next.Do(req)
+			if err != nil {
+				return resp, err
+			}
+
+			body, err := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+			recorder.add(Recording{
+				Method:     req.Method,
+				URL:        req.URL.String(),
+				StatusCode: resp.StatusCode,
+				Header:     resp.Header.Clone(),
+				Body:       body,
+			})
+			return resp, nil
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package jotform_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	jotform "github.com/jotform/jotform-api-go/v2"
+	"github.com/jotform/jotform-api-go/v2/jotformtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("happy - middleware runs in the order added", func(t *testing.T) {
+		server := jotformtest.NewServer()
+		defer server.Close()
+		client := jotform.NewJotFormAPIClient("api-key", "json", false)
+		client.BaseURL = server.URL
+
+		var calls []string
+		trace := func(name string) jotform.Middleware {
+			return func(next jotform.Doer) jotform.Doer {
+				return jotform.DoerFunc(func(req *http.Request) (*http.Response, error) {
+					calls = append(calls, name+" before")
+					resp, err := next.Do(req)
+					calls = append(calls, name+" after")
+					return resp, err
+				})
+			}
+		}
+		client.Use(trace("first"))
+		client.Use(trace("second"))
+
+		_, err := client.GetUserTyped(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"first before", "second before", "second after", "first after"}, calls)
+	})
+
+	t.Run("happy - headers and request IDs", func(t *testing.T) {
+		server := jotformtest.NewServer()
+		defer server.Close()
+		client := jotform.NewJotFormAPIClient("api-key", "json", false)
+		client.BaseURL = server.URL
+		client.Use(
+			jotform.HeaderMiddleware(http.Header{"User-Agent": {"intake-sync/1.0"}}),
+			jotform.RequestIDMiddleware(""),
+		)
+
+		_, err := client.GetUserTyped(ctx)
+		assert.NoError(t, err)
+		_, err = client.GetUsageTyped(ctx)
+		assert.NoError(t, err)
+
+		requests := server.Requests()
+		assert.Equal(t, "intake-sync/1.0", requests[0].Header.Get("User-Agent"))
+		assert.Len(t, requests[0].Header.Get(jotform.DefaultRequestIDHeader), 32)
+		assert.NotEqual(t, requests[0].Header.Get(jotform.DefaultRequestIDHeader), requests[1].Header.Get(jotform.DefaultRequestIDHeader))
+	})
+
+	t.Run("happy - logging, metrics and recording", func(t *testing.T) {
+		server := jotformtest.NewServer()
+		defer server.Close()
+		client := jotform.NewJotFormAPIClient("api-key", "json", false)
+		client.BaseURL = server.URL
+		client.Retry = nil
+
+		var logs bytes.Buffer
+		var mu sync.Mutex
+		var metrics []jotform.RequestMetrics
+		recorder := &jotform.Recorder{}
+		client.Use(
+			jotform.LoggingMiddleware(slog.New(slog.NewTextHandler(&logs, nil))),
+			jotform.MetricsMiddleware(func(m jotform.RequestMetrics) {
+				mu.Lock()
+				defer mu.Unlock()
+				metrics = append(metrics, m)
+			}),
+			jotform.RecordingMiddleware(recorder),
+		)
+
+		_, err := client.GetUserTyped(ctx)
+		assert.NoError(t, err)
+		_, err = client.GetFormTyped(ctx, 404)
+		assert.ErrorIs(t, err, jotform.ErrNotFound)
+
+		assert.Contains(t, logs.String(), "level=INFO")
+		assert.Contains(t, logs.String(), "status=200")
+		assert.Contains(t, logs.String(), "level=WARN")
+		assert.Contains(t, logs.String(), "status=404")
+		assert.NotContains(t, logs.String(), "api-key")
+
+		if assert.Len(t, metrics, 2) {
+			assert.Equal(t, "/v1/user", metrics[0].Path)
+			assert.Equal(t, 200, metrics[0].StatusCode)
+			assert.Equal(t, 404, metrics[1].StatusCode)
+		}
+
+		recordings := recorder.Recordings()
+		if assert.Len(t, recordings, 2) {
+			assert.Equal(t, "GET", recordings[0].Method)
+			assert.True(t, strings.HasSuffix(recordings[0].URL, "/v1/user"))
+			assert.Contains(t, string(recordings[0].Body), "jotformtest")
+		}
+	})
+
+	t.Run("happy - retries inside the middleware see every attempt", func(t *testing.T) {
+		server := jotformtest.NewServer()
+		defer server.Close()
+		client := jotform.NewJotFormAPIClient("api-key", "json", false)
+		client.BaseURL = server.URL
+		client.Retry = nil
+
+		policy := jotform.DefaultRetryPolicy()
+		policy.BaseDelay = 0
+		var statuses []int
+		client.Use(
+			jotform.RetryMiddleware(policy),
+			jotform.MetricsMiddleware(func(m jotform.RequestMetrics) { statuses = append(statuses, m.StatusCode) }),
+		)
+
+		server.InjectFault(jotformtest.Fault{Path: "user", StatusCode: 503, Times: 2})
+		_, err := client.GetUserTyped(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{503, 503, 200}, statuses)
+	})
+
+	t.Run("happy - downloads pass through the middleware", func(t *testing.T) {
+		server := jotformtest.NewServer()
+		defer server.Close()
+		client := jotform.NewJotFormAPIClient("api-key", "json", false)
+		client.BaseURL = server.URL
+
+		form := server.AddForm(jotform.Form{Title: "Contact"})
+		submission := server.AddSubmission(jotform.Submission{FormID: form.ID})
+
+		var paths []string
+		client.Use(jotform.MetricsMiddleware(func(m jotform.RequestMetrics) { paths = append(paths, m.Path) }))
+
+		var pdf bytes.Buffer
+		_, err := client.WriteSimplePDFSubmission(ctx, &pdf, strconv.FormatInt(int64(form.ID), 10), strconv.FormatInt(int64(submission.ID), 10), "")
+		assert.NoError(t, err)
+		assert.Len(t, paths, 1)
+	})
+}
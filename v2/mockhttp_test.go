@@ -2,6 +2,7 @@ package jotform
 
 import (
 	"bytes"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 )
@@ -17,8 +18,19 @@ func (m *MockHttpClient) Do(req *http.Request) (*http.Response, error) {
 	return &http.Response{Body: ioutil.NopCloser(bytes.NewBufferString("Dummy Response"))}, nil
 }
 
-func NewTestClient(mockHttp *MockHttpClient) *jotformAPIClient {
+func NewTestClient(mockHttp *MockHttpClient) *Client {
 	client := NewJotFormAPIClient("api-key", "json", false)
 	client.HttpClient = mockHttp
 	return client
 }
+
+// NewMockResponse returns a response to req with the given status code and body.
+func NewMockResponse(req *http.Request, statusCode int, body string) *http.Response {
+	return &http.Response{
+		Request:    req,
+		StatusCode: statusCode,
+		Status:     fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		Header:     make(http.Header),
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+	}
+}
@@ -0,0 +1,468 @@
+package jotform
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeLayout is the layout JotForm uses for timestamps such as created_at.
+const TimeLayout = "2006-01-02 15:04:05"
+
+// TimeLocation is the location JotForm timestamps are interpreted in.
+// JotForm does not include a zone in its timestamps,
+// so set this to match your account if UTC is not appropriate.
+var TimeLocation = time.UTC
+
+// Int is an integer that JotForm may encode as either a JSON number or a string.
+// Empty strings and null decode to zero.
+type Int int64
+
+func (i *Int) UnmarshalJSON(data []byte) error {
+	s := string(bytes.TrimSpace(data))
+	if s == "null" {
+		*i = 0
+		return nil
+	}
+
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		s = strings.TrimSpace(s)
+		if s == "" {
+			*i = 0
+			return nil
+		}
+	}
+
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("jotform: cannot decode %s as an integer", data)
+	}
+	*i = Int(n)
+	return nil
+}
+
+// MarshalJSON encodes the integer as a string, the same way JotForm does.
+func (i Int) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strconv.FormatInt(int64(i), 10))
+}
+
+// Bool is a boolean that JotForm encodes as "1"/"0", "Yes"/"No" or "true"/"false".
+type Bool bool
+
+func (b *Bool) UnmarshalJSON(data []byte) error {
+	s := string(bytes.TrimSpace(data))
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "1", "true", "yes", "on":
+		*b = true
+	case "0", "false", "no", "off", "", "null":
+		*b = false
+	default:
+		return fmt.Errorf("jotform: cannot decode %s as a boolean", data)
+	}
+	return nil
+}
+
+// MarshalJSON encodes the boolean as "1" or "0", the same way JotForm does.
+func (b Bool) MarshalJSON() ([]byte, error) {
+	if b {
+		return []byte(`"1"`), nil
+	}
+	return []byte(`"0"`), nil
+}
+
+// Time is a timestamp in JotForm's TimeLayout.
+// Empty strings, null and "0000-00-00 00:00:00" decode to the zero time.
+type Time struct {
+	time.Time
+}
+
+func (t *Time) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("jotform: cannot decode %s as a time", data)
+	}
+	return t.parse(s)
+}
+
+func (t *Time) parse(s string) error {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.HasPrefix(s, "0000-00-00") {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	parsed, err := time.ParseInLocation(TimeLayout, s, TimeLocation)
+	if err != nil {
+		return fmt.Errorf("jotform: cannot decode %q as a time: %w", s, err)
+	}
+	t.Time = parsed
+	return nil
+}
+
+// MarshalJSON encodes the time in TimeLayout, or null for the zero time.
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.In(TimeLocation).Format(TimeLayout))
+}
+
+// User is the account returned by GetUser.
+type User struct {
+	Username    string `json:"username"`
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	Website     string `json:"website"`
+	TimeZone    string `json:"time_zone"`
+	AccountType string `json:"account_type"`
+	Status      string `json:"status"`
+	Company     string `json:"company"`
+	AvatarURL   string `json:"avatarUrl"`
+	UsageURL    string `json:"usage"`
+	Region      string `json:"region"`
+	CreatedAt   Time   `json:"created_at"`
+	UpdatedAt   Time   `json:"updated_at"`
+}
+
+// Usage is the monthly usage returned by GetUsage.
+type Usage struct {
+	Username         string `json:"username"`
+	Submissions      Int    `json:"submissions"`
+	SSLSubmissions   Int    `json:"ssl_submissions"`
+	Payments         Int    `json:"payments"`
+	Uploads          Int    `json:"uploads"`
+	TotalSubmissions Int    `json:"total_submissions"`
+	FormCount        Int    `json:"form_count"`
+	Views            Int    `json:"views"`
+	APICalls         Int    `json:"api"`
+}
+
+// Form is a form as returned by GetForm and GetForms.
+type Form struct {
+	ID             Int    `json:"id"`
+	Username       string `json:"username"`
+	Title          string `json:"title"`
+	Height         Int    `json:"height"`
+	Status         string `json:"status"`
+	Type           string `json:"type"`
+	URL            string `json:"url"`
+	New            Int    `json:"new"`
+	Count          Int    `json:"count"`
+	Favorite       Bool   `json:"favorite"`
+	Archived       Bool   `json:"archived"`
+	CreatedAt      Time   `json:"created_at"`
+	UpdatedAt      Time   `json:"updated_at"`
+	LastSubmission Time   `json:"last_submission"`
+}
+
+// Submission is a single form submission.
+// Answers are keyed by question ID.
+type Submission struct {
+	ID        Int               `json:"id"`
+	FormID    Int               `json:"form_id"`
+	IP        string            `json:"ip"`
+	Status    string            `json:"status"`
+	New       Bool              `json:"new"`
+	Flag      Bool              `json:"flag"`
+	Notes     string            `json:"notes"`
+	CreatedAt Time              `json:"created_at"`
+	UpdatedAt Time              `json:"updated_at"`
+	Answers   map[string]Answer `json:"answers"`
+}
+
+// Answer is one question's answer within a Submission.
+// The shape of Answer depends on Type, so it is left undecoded.
+type Answer struct {
+	Name         string          `json:"name"`
+	Order        Int             `json:"order"`
+	Text         string          `json:"text"`
+	Type         string          `json:"type"`
+	Answer       json.RawMessage `json:"answer,omitempty"`
+	PrettyFormat string          `json:"prettyFormat,omitempty"`
+}
+
+// SubmissionResult is returned when a submission is created.
+type SubmissionResult struct {
+	SubmissionID Int    `json:"submissionID"`
+	URL          string `json:"URL"`
+}
+
+// Question is a single question on a form.
+// Properties holds every property JotForm returned for the question,
+// including the ones also decoded into the named fields.
+type Question struct {
+	QID        Int
+	Type       string
+	Name       string
+	Text       string
+	Order      Int
+	Required   Bool
+	Properties map[string]json.RawMessage
+}
+
+type questionFields struct {
+	QID      Int    `json:"qid"`
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Text     string `json:"text"`
+	Order    Int    `json:"order"`
+	Required Bool   `json:"required"`
+}
+
+func (q *Question) UnmarshalJSON(data []byte) error {
+	var fields questionFields
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	var properties map[string]json.RawMessage
+	if err := json.Unmarshal(data, &properties); err != nil {
+		return err
+	}
+
+	*q = Question{
+		QID:        fields.QID,
+		Type:       fields.Type,
+		Name:       fields.Name,
+		Text:       fields.Text,
+		Order:      fields.Order,
+		Required:   fields.Required,
+		Properties: properties,
+	}
+	return nil
+}
+
+// MarshalJSON flattens the question back into JotForm's property object.
+func (q Question) MarshalJSON() ([]byte, error) {
+	out := make(map[string]interface{}, len(q.Properties)+6)
+	for k, v := range q.Properties {
+		out[k] = v
+	}
+	out["qid"] = q.QID
+	out["type"] = q.Type
+	out["name"] = q.Name
+	out["text"] = q.Text
+	out["order"] = q.Order
+	if q.Required {
+		out["required"] = "Yes"
+	} else {
+		out["required"] = "No"
+	}
+	return json.Marshal(out)
+}
+
+// Property returns the named question property as a string.
+// Non-string properties are returned as raw JSON.
+func (q Question) Property(key string) string {
+	raw, ok := q.Properties[key]
+	if !ok {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	return string(raw)
+}
+
+// Report is a report on a form, such as a CSV, Excel or HTML table.
+type Report struct {
+	ID          Int    `json:"id"`
+	FormID      Int    `json:"form_id"`
+	Title       string `json:"title"`
+	ListType    string `json:"list_type"`
+	Fields      string `json:"fields"`
+	Status      string `json:"status"`
+	URL         string `json:"url"`
+	IsProtected Bool   `json:"isProtected"`
+	CreatedAt   Time   `json:"created_at"`
+	UpdatedAt   Time   `json:"updated_at"`
+}
+
+// Folder is a form folder. GetFolders returns the root folder,
+// with every other folder nested in Subfolders.
+// Subfolders are pointers, so a *Folder stays valid as subfolders are added around it.
+type Folder struct {
+	ID         string
+	Path       string
+	Owner      string
+	Name       string
+	Parent     string
+	Color      string
+	Forms      []Form
+	Subfolders []*Folder
+}
+
+type folderFields struct {
+	ID         string          `json:"id"`
+	Path       string          `json:"path"`
+	Owner      string          `json:"owner"`
+	Name       string          `json:"name"`
+	Parent     string          `json:"parent"`
+	Color      string          `json:"color"`
+	Forms      json.RawMessage `json:"forms"`
+	Subfolders []*Folder       `json:"subfolders"`
+}
+
+func (f *Folder) UnmarshalJSON(data []byte) error {
+	var fields folderFields
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	// JotForm returns forms as an object keyed by form ID,
+	// or as an empty array when the folder has none.
+	var forms map[string]Form
+	if err := decodeObject(fields.Forms, &forms); err != nil {
+		return err
+	}
+
+	*f = Folder{
+		ID:         fields.ID,
+		Path:       fields.Path,
+		Owner:      fields.Owner,
+		Name:       fields.Name,
+		Parent:     fields.Parent,
+		Color:      fields.Color,
+		Subfolders: fields.Subfolders,
+	}
+	for _, form := range forms {
+		f.Forms = append(f.Forms, form)
+	}
+	sort.Slice(f.Forms, func(i, j int) bool { return f.Forms[i].ID < f.Forms[j].ID })
+	return nil
+}
+
+// MarshalJSON encodes the folder with forms keyed by ID, as JotForm does.
+func (f Folder) MarshalJSON() ([]byte, error) {
+	forms := make(map[string]Form, len(f.Forms))
+	for _, form := range f.Forms {
+		forms[strconv.FormatInt(int64(form.ID), 10)] = form
+	}
+	subfolders := f.Subfolders
+	if subfolders == nil {
+		subfolders = []*Folder{}
+	}
+	return json.Marshal(folderFieldsOut{
+		ID:         f.ID,
+		Path:       f.Path,
+		Owner:      f.Owner,
+		Name:       f.Name,
+		Parent:     f.Parent,
+		Color:      f.Color,
+		Forms:      forms,
+		Subfolders: subfolders,
+	})
+}
+
+type folderFieldsOut struct {
+	ID         string          `json:"id"`
+	Path       string          `json:"path"`
+	Owner      string          `json:"owner"`
+	Name       string          `json:"name"`
+	Parent     string          `json:"parent"`
+	Color      string          `json:"color"`
+	Forms      map[string]Form `json:"forms"`
+	Subfolders []*Folder       `json:"subfolders"`
+}
+
+// Webhook is a URL that JotForm posts submissions of a form to.
+type Webhook struct {
+	ID  Int
+	URL string
+}
+
+// decodeWebhooks decodes JotForm's webhook listing,
+// an object of webhook ID to URL, into a slice ordered by ID.
+func decodeWebhooks(data []byte) ([]Webhook, error) {
+	var byID map[string]string
+	if err := decodeObject(data, &byID); err != nil {
+		return nil, err
+	}
+
+	webhooks := make([]Webhook, 0, len(byID))
+	for id, u := range byID {
+		n, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("jotform: unexpected webhook ID %q", id)
+		}
+		webhooks = append(webhooks, Webhook{ID: Int(n), URL: u})
+	}
+	sort.Slice(webhooks, func(i, j int) bool { return webhooks[i].ID < webhooks[j].ID })
+	return webhooks, nil
+}
+
+// HistoryEntry is one entry of the account activity log.
+type HistoryEntry struct {
+	Type       string `json:"type"`
+	FormID     Int    `json:"formID"`
+	Username   string `json:"username"`
+	FormTitle  string `json:"formTitle"`
+	FormStatus string `json:"formStatus"`
+	IP         string `json:"ip"`
+	Server     string `json:"server"`
+	Timestamp  Int    `json:"timestamp"`
+}
+
+// Time returns the Timestamp of the entry.
+func (h HistoryEntry) Time() time.Time {
+	return time.Unix(int64(h.Timestamp), 0)
+}
+
+// Plan describes a JotForm account plan and its limits.
+type Plan struct {
+	Name   string         `json:"name"`
+	Limits map[string]Int `json:"limits"`
+}
+
+// Settings are the account settings returned by GetSettings.
+type Settings struct {
+	Username         string `json:"username"`
+	Name             string `json:"name"`
+	Email            string `json:"email"`
+	Website          string `json:"website"`
+	TimeZone         string `json:"time_zone"`
+	Company          string `json:"company"`
+	Industry         string `json:"industry"`
+	SecurityQuestion string `json:"securityQuestion"`
+}
+
+// File is an uploaded file, as listed by GetFormFiles.
+type File struct {
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	Size         Int    `json:"size"`
+	Username     string `json:"username"`
+	FormID       Int    `json:"form_id"`
+	SubmissionID Int    `json:"submission_id"`
+	URL          string `json:"url"`
+	Date         Time   `json:"date"`
+}
+
+// decodeObject decodes a JSON object into v,
+// treating an empty array (PHP's encoding of an empty map) or null as empty.
+func decodeObject(data []byte, v interface{}) error {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) || bytes.Equal(trimmed, []byte("[]")) {
+		return nil
+	}
+	return json.Unmarshal(trimmed, v)
+}
@@ -0,0 +1,93 @@
+package jotform_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	jotform "github.com/jotform/jotform-api-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScalarTypes(t *testing.T) {
+	t.Run("happy - Int accepts strings, numbers and empty values", func(t *testing.T) {
+		var v struct {
+			A jotform.Int `json:"a"`
+			B jotform.Int `json:"b"`
+			C jotform.Int `json:"c"`
+			D jotform.Int `json:"d"`
+		}
+		err := json.Unmarshal([]byte(`{"a":"123","b":456,"c":"","d":null}`), &v)
+		assert.Nil(t, err)
+		assert.Equal(t, jotform.Int(123), v.A)
+		assert.Equal(t, jotform.Int(456), v.B)
+		assert.Equal(t, jotform.Int(0), v.C)
+		assert.Equal(t, jotform.Int(0), v.D)
+	})
+
+	t.Run("sad - Int rejects non-numeric strings", func(t *testing.T) {
+		var v jotform.Int
+		assert.NotNil(t, json.Unmarshal([]byte(`"abc"`), &v))
+	})
+
+	t.Run("happy - Bool accepts JotForm's encodings", func(t *testing.T) {
+		for input, want := range map[string]bool{
+			`"1"`: true, `"0"`: false, `"Yes"`: true, `"No"`: false,
+			`true`: true, `false`: false, `1`: true, `""`: false, `null`: false,
+		} {
+			var v jotform.Bool
+			assert.Nil(t, json.Unmarshal([]byte(input), &v), input)
+			assert.Equal(t, want, bool(v), input)
+		}
+	})
+
+	t.Run("happy - Time parses JotForm's layout", func(t *testing.T) {
+		var v jotform.Time
+		assert.Nil(t, json.Unmarshal([]byte(`"2013-05-21 15:03:40"`), &v))
+		assert.Equal(t, time.Date(2013, 5, 21, 15, 3, 40, 0, time.UTC), v.Time)
+
+		out, err := json.Marshal(v)
+		assert.Nil(t, err)
+		assert.Equal(t, `"2013-05-21 15:03:40"`, string(out))
+	})
+
+	t.Run("happy - Time treats empty values as zero", func(t *testing.T) {
+		for _, input := range []string{`""`, `null`, `"0000-00-00 00:00:00"`} {
+			v := jotform.Time{Time: time.Now()}
+			assert.Nil(t, json.Unmarshal([]byte(input), &v), input)
+			assert.True(t, v.IsZero(), input)
+		}
+	})
+}
+
+func TestQuestion(t *testing.T) {
+	t.Run("happy - keeps every property", func(t *testing.T) {
+		var q jotform.Question
+		err := json.Unmarshal([]byte(`{"qid":"3","type":"control_textbox","name":"yourName","text":"Your Name","order":"2","required":"Yes","maxsize":"40"}`), &q)
+		assert.Nil(t, err)
+		assert.Equal(t, jotform.Int(3), q.QID)
+		assert.Equal(t, "control_textbox", q.Type)
+		assert.Equal(t, jotform.Int(2), q.Order)
+		assert.True(t, bool(q.Required))
+		assert.Equal(t, "40", q.Property("maxsize"))
+
+		out, err := json.Marshal(q)
+		assert.Nil(t, err)
+		var roundTripped jotform.Question
+		assert.Nil(t, json.Unmarshal(out, &roundTripped))
+		assert.Equal(t, q.QID, roundTripped.QID)
+		assert.Equal(t, "40", roundTripped.Property("maxsize"))
+	})
+}
+
+func TestFolder(t *testing.T) {
+	t.Run("happy - decodes forms keyed by ID", func(t *testing.T) {
+		var f jotform.Folder
+		err := json.Unmarshal([]byte(`{"id":"abc","name":"Root","forms":{"20":{"id":"20","title":"B"},"10":{"id":"10","title":"A"}},"subfolders":[{"id":"def","name":"Child","parent":"abc","forms":[]}]}`), &f)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(f.Forms))
+		assert.Equal(t, "A", f.Forms[0].Title)
+		assert.Equal(t, 1, len(f.Subfolders))
+		assert.Equal(t, 0, len(f.Subfolders[0].Forms))
+	})
+}
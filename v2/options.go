@@ -0,0 +1,116 @@
+package jotform
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout is the timeout of the http.Client New uses by default.
+const DefaultTimeout = 60 * time.Second
+
+// Option configures a Client made by New.
+type Option func(*settings)
+
+// settings are the options given to New, applied once they are all known.
+type settings struct {
+	baseURL    string
+	region     *Region
+	httpClient HttpClient
+	timeout    time.Duration
+	timeoutSet bool
+	userAgent  string
+	logger     *slog.Logger
+	outputType string
+}
+
+// New returns a client for the API key, configured by opts.
+// By default it calls the US API for JSON, with DefaultTimeout and DefaultRetryPolicy.
+//
+//	client := jotform.New(apiKey,
+//		jotform.WithRegion(jotform.RegionEU),
+//		jotform.WithTimeout(30*time.Second),
+//		jotform.WithUserAgent("intake-sync/1.0"),
+//	)
+func New(apiKey string, opts ...Option) *Client {
+	s := settings{outputType: "json", timeout: DefaultTimeout}
+	for _, opt := range opts {
+		opt(&s)
+	}
+
+	client := NewJotFormAPIClient(apiKey, s.outputType, false)
+	if s.region != nil {
+		client.SetRegion(*s.region)
+	}
+	if s.baseURL != "" {
+		client.BaseURL = strings.TrimSuffix(s.baseURL, "/")
+	}
+
+	switch httpClient := s.httpClient.(type) {
+	case nil:
+		client.HttpClient = newHTTPClient(s.timeout)
+	case *http.Client:
+		if s.timeoutSet {
+			// Copy, rather than change the caller's client.
+			copied := *httpClient
+			copied.Timeout = s.timeout
+			client.HttpClient = &copied
+		} else {
+			client.HttpClient = httpClient
+		}
+	default:
+		client.HttpClient = httpClient
+	}
+
+	if s.userAgent != "" {
+		client.Use(HeaderMiddleware(http.Header{"User-Agent": {s.userAgent}}))
+	}
+	if s.logger != nil {
+		client.logger = s.logger
+		client.Use(LoggingMiddleware(s.logger))
+	}
+	return client
+}
+
+// WithBaseURL sets the URL of the API, eg. "https://acme.jotform.com/API".
+// It takes precedence over WithRegion.
+func WithBaseURL(baseURL string) Option {
+	return func(s *settings) { s.baseURL = baseURL }
+}
+
+// WithRegion calls the API of a region, such as RegionEU or Enterprise("acme.jotform.com"), as SetRegion does.
+func WithRegion(region Region) Option {
+	return func(s *settings) { s.region = &region }
+}
+
+// WithHTTPClient sends requests with httpClient, eg. to use a custom http.Transport.
+func WithHTTPClient(httpClient HttpClient) Option {
+	return func(s *settings) { s.httpClient = httpClient }
+}
+
+// WithTimeout sets the timeout of each request.
+// It applies to the default http.Client, or to a copy of an *http.Client given to WithHTTPClient;
+// other HttpClients must time out requests themselves.
+func WithTimeout(timeout time.Duration) Option {
+	return func(s *settings) {
+		s.timeout = timeout
+		s.timeoutSet = true
+	}
+}
+
+// WithUserAgent sets the User-Agent header of every request.
+func WithUserAgent(userAgent string) Option {
+	return func(s *settings) { s.userAgent = userAgent }
+}
+
+// WithLogger logs every request to logger, as LoggingMiddleware does,
+// along with the client's debug output at Debug level.
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *settings) { s.logger = logger }
+}
+
+// WithOutput sets the format responses are requested in, "json" or "xml".
+func WithOutput(outputType string) Option {
+	return func(s *settings) { s.outputType = strings.ToLower(outputType) }
+}
@@ -0,0 +1,104 @@
+package jotform_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	jotform "github.com/jotform/jotform-api-go/v2"
+	"github.com/jotform/jotform-api-go/v2/jotformtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// usage is a consumer of the API interface, as code under test would be.
+type usage struct {
+	api jotform.API
+}
+
+func (u usage) submissions(ctx context.Context) (int, error) {
+	stats, err := u.api.GetUsageTyped(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return int(stats.Submissions), nil
+}
+
+// fakeAPI implements only the endpoints a test needs; the others panic.
+type fakeAPI struct {
+	jotform.API
+}
+
+func (fakeAPI) GetUsageTyped(ctx context.Context) (*jotform.Usage, error) {
+	return &jotform.Usage{Submissions: 42}, nil
+}
+
+func canceled() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
+
+func TestNew(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("happy - defaults", func(t *testing.T) {
+		client := jotform.New("api-key")
+		assert.Equal(t, "https://api.jotform.com", client.BaseURL)
+		assert.Equal(t, "json", client.GetOutputType())
+		assert.Equal(t, jotform.DefaultTimeout, client.HttpClient.(*http.Client).Timeout)
+		assert.NotNil(t, client.Retry)
+	})
+
+	t.Run("happy - options", func(t *testing.T) {
+		httpClient := &http.Client{}
+		client := jotform.New("api-key",
+			jotform.WithRegion(jotform.RegionEU),
+			jotform.WithHTTPClient(httpClient),
+			jotform.WithTimeout(5*time.Second),
+			jotform.WithOutput("XML"),
+		)
+		assert.Equal(t, "https://eu-api.jotform.com", client.BaseURL)
+		assert.Equal(t, "xml", client.GetOutputType())
+		assert.Equal(t, 5*time.Second, client.HttpClient.(*http.Client).Timeout)
+		assert.Zero(t, httpClient.Timeout, "the caller's client is not changed")
+
+		client = jotform.New("api-key", jotform.WithHTTPClient(httpClient), jotform.WithTimeout(jotform.DefaultTimeout))
+		assert.Equal(t, jotform.DefaultTimeout, client.HttpClient.(*http.Client).Timeout)
+
+		client = jotform.New("api-key", jotform.WithHTTPClient(httpClient))
+		assert.Same(t, httpClient, client.HttpClient)
+
+		client = jotform.New("api-key", jotform.WithBaseURL("https://acme.jotform.com/API/"), jotform.WithRegion(jotform.RegionEU))
+		assert.Equal(t, "https://acme.jotform.com/API", client.BaseURL)
+	})
+
+	t.Run("happy - user agent and logger", func(t *testing.T) {
+		server := jotformtest.NewServer()
+		defer server.Close()
+
+		var logs bytes.Buffer
+		client := jotform.New("api-key",
+			jotform.WithBaseURL(server.URL),
+			jotform.WithUserAgent("intake-sync/1.0"),
+			jotform.WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+		)
+
+		_, err := client.GetUserTyped(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "intake-sync/1.0", server.Requests()[0].Header.Get("User-Agent"))
+		assert.Contains(t, logs.String(), "jotform request")
+	})
+
+	t.Run("happy - the API interface can be faked", func(t *testing.T) {
+		n, err := usage{api: fakeAPI{}}.submissions(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 42, n)
+
+		n, err = usage{api: jotform.New("api-key")}.submissions(canceled())
+		assert.Error(t, err)
+		assert.Zero(t, n)
+	})
+}
@@ -0,0 +1,519 @@
+package jotform
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EncodeParams flattens v into the bracketed form parameters JotForm reads, as PHP parses them.
+// Maps and structs become name[key], and slices and arrays name[0], name[1] and so on,
+// within prefix if it is not empty:
+//
+//	params, err := jotform.EncodeParams("submission", map[string]interface{}{
+//		"3": map[string]string{"first": "Ada", "last": "Lovelace"},
+//		"4": "ada@example.com",
+//	})
+//	// submission[3][first]=Ada, submission[3][last]=Lovelace, submission[4]=ada@example.com
+//
+// Struct fields are named by their `jotform:"name"` tag, or else by the field's name.
+// A tag of "-" leaves the field out, as does the "omitempty" option if the field is empty.
+//
+// Strings, numbers, booleans (as "1" or "0"), times (in TimeLayout) and
+// encoding.TextMarshalers are values. Nil pointers, interfaces, maps and slices are left out.
+// Keys may not be empty or contain brackets, as they would be read back as other keys.
+func EncodeParams(prefix string, v interface{}) (map[string]string, error) {
+	if prefix != "" {
+		if err := checkParamKey(prefix); err != nil {
+			return nil, err
+		}
+	}
+
+	params := make(map[string]string)
+	if err := encodeParam(params, prefix, reflect.ValueOf(v)); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+// DecodeParams reverses EncodeParams, decoding the parameters within prefix into v,
+// which must be a non-nil pointer. Parameters outside prefix are ignored,
+// as are keys that do not match a struct field.
+//
+// Values decoded into an interface{} are strings, or map[string]interface{} if they have keys.
+// A parameter with both a value and keys, such as a=1 and a[b]=2, is an error.
+func DecodeParams(params map[string]string, prefix string, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.New("jotform: DecodeParams needs a non-nil pointer")
+	}
+
+	root := &paramNode{}
+	for key, value := range params {
+		if prefix != "" && key != prefix && !strings.HasPrefix(key, prefix+"[") {
+			continue
+		}
+		parts, err := splitParamKey(key)
+		if err != nil {
+			return err
+		}
+		if prefix != "" {
+			parts = parts[1:]
+		}
+		if err := root.insert(key, parts, value); err != nil {
+			return err
+		}
+	}
+
+	if root.value == nil && root.children == nil {
+		return nil
+	}
+	return decodeParam(root, prefix, rv.Elem())
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	jotformTimeType   = reflect.TypeOf(Time{})
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+func encodeParam(params map[string]string, name string, rv reflect.Value) error {
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return nil
+	}
+
+	switch rv.Type() {
+	case timeType:
+		return setParam(params, name, rv.Interface().(time.Time).In(TimeLocation).Format(TimeLayout))
+	case jotformTimeType:
+		return setParam(params, name, rv.Interface().(Time).In(TimeLocation).Format(TimeLayout))
+	}
+	if rv.Type().Implements(textMarshalerType) {
+		text, err := rv.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return err
+		}
+		return setParam(params, name, string(text))
+	}
+
+	switch rv.Kind() {
+	case reflect.String:
+		return setParam(params, name, rv.String())
+	case reflect.Bool:
+		if rv.Bool() {
+			return setParam(params, name, "1")
+		}
+		return setParam(params, name, "0")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return setParam(params, name, strconv.FormatInt(rv.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return setParam(params, name, strconv.FormatUint(rv.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		return setParam(params, name, strconv.FormatFloat(rv.Float(), 'f', -1, rv.Type().Bits()))
+
+	case reflect.Map:
+		for _, key := range rv.MapKeys() {
+			k, err := paramMapKey(key)
+			if err != nil {
+				return err
+			}
+			if err := encodeParam(params, joinParam(name, k), rv.MapIndex(key)); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 {
+			return setParam(params, name, string(rv.Bytes()))
+		}
+		for i := 0; i < rv.Len(); i++ {
+			if err := encodeParam(params, joinParam(name, strconv.Itoa(i)), rv.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case reflect.Struct:
+		for _, field := range paramFields(rv.Type()) {
+			value := rv.Field(field.index)
+			if field.omitEmpty && value.IsZero() {
+				continue
+			}
+			if err := encodeParam(params, joinParam(name, field.name), value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("jotform: cannot encode %s as a parameter", rv.Type())
+}
+
+func setParam(params map[string]string, name string, value string) error {
+	if name == "" {
+		return errors.New("jotform: a parameter value needs a name")
+	}
+	params[name] = value
+	return nil
+}
+
+// joinParam names key within name, as name[key].
+func joinParam(name, key string) string {
+	if name == "" {
+		return key
+	}
+	return name + "[" + key + "]"
+}
+
+func paramMapKey(key reflect.Value) (string, error) {
+	var k string
+	switch key.Kind() {
+	case reflect.String:
+		k = key.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		k = strconv.FormatInt(key.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		k = strconv.FormatUint(key.Uint(), 10)
+	default:
+		return "", fmt.Errorf("jotform: cannot encode %s map keys as parameters", key.Type())
+	}
+	return k, checkParamKey(k)
+}
+
+func checkParamKey(key string) error {
+	if key == "" {
+		return errors.New("jotform: empty parameter key")
+	}
+	if strings.ContainsAny(key, "[]") {
+		return fmt.Errorf("jotform: parameter key %q contains a bracket", key)
+	}
+	return nil
+}
+
+// paramField is a struct field encoded as a parameter.
+type paramField struct {
+	index     int
+	name      string
+	omitEmpty bool
+}
+
+func paramFields(t reflect.Type) []paramField {
+	var fields []paramField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		tag := field.Tag.Get("jotform")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+		fields = append(fields, paramField{index: i, name: name, omitEmpty: options == "omitempty"})
+	}
+	return fields
+}
+
+// paramNode is a parameter name in a tree of decoded parameters,
+// with either a value or keys within it.
+type paramNode struct {
+	value    *string
+	children map[string]*paramNode
+}
+
+func (n *paramNode) insert(key string, parts []string, value string) error {
+	for _, part := range parts {
+		if n.value != nil {
+			return fmt.Errorf("jotform: parameter %s is within a value", key)
+		}
+		if n.children == nil {
+			n.children = make(map[string]*paramNode)
+		}
+		child, ok := n.children[part]
+		if !ok {
+			child = &paramNode{}
+			n.children[part] = child
+		}
+		n = child
+	}
+	if n.children != nil {
+		return fmt.Errorf("jotform: parameter %s has both a value and keys", key)
+	}
+	n.value = &value
+	return nil
+}
+
+// splitParamKey splits a parameter name, such as "submission[3][first]",
+// into its parts: "submission", "3" and "first".
+func splitParamKey(key string) ([]string, error) {
+	open := strings.IndexByte(key, '[')
+	if open < 0 {
+		if err := checkParamKey(key); err != nil {
+			return nil, err
+		}
+		return []string{key}, nil
+	}
+
+	parts := []string{key[:open]}
+	rest := key[open:]
+	for rest != "" {
+		end := strings.IndexByte(rest, ']')
+		if rest[0] != '[' || end < 0 {
+			return nil, fmt.Errorf("jotform: malformed parameter name %q", key)
+		}
+		parts = append(parts, rest[1:end])
+		rest = rest[end+1:]
+	}
+	for _, part := range parts {
+		if err := checkParamKey(part); err != nil {
+			return nil, fmt.Errorf("jotform: malformed parameter name %q", key)
+		}
+	}
+	return parts, nil
+}
+
+func decodeParam(n *paramNode, name string, rv reflect.Value) error {
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		return decodeParam(n, name, rv.Elem())
+	}
+
+	switch rv.Type() {
+	case timeType, jotformTimeType:
+		value, err := n.leaf(name)
+		if err != nil {
+			return err
+		}
+		t, err := time.ParseInLocation(TimeLayout, value, TimeLocation)
+		if err != nil {
+			return fmt.Errorf("jotform: parameter %s: %w", name, err)
+		}
+		if rv.Type() == jotformTimeType {
+			rv.Set(reflect.ValueOf(Time{Time: t}))
+		} else {
+			rv.Set(reflect.ValueOf(t))
+		}
+		return nil
+	}
+	if rv.CanAddr() && rv.Addr().Type().Implements(textUnmarshalType) {
+		value, err := n.leaf(name)
+		if err != nil {
+			return err
+		}
+		return rv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value))
+	}
+
+	switch rv.Kind() {
+	case reflect.Interface:
+		if rv.NumMethod() != 0 {
+			break
+		}
+		rv.Set(reflect.ValueOf(n.interfaceValue()))
+		return nil
+
+	case reflect.String:
+		value, err := n.leaf(name)
+		if err != nil {
+			return err
+		}
+		rv.SetString(value)
+		return nil
+
+	case reflect.Bool:
+		value, err := n.leaf(name)
+		if err != nil {
+			return err
+		}
+		var b Bool
+		if err := b.UnmarshalJSON([]byte(strconv.Quote(value))); err != nil {
+			return fmt.Errorf("jotform: parameter %s: %w", name, err)
+		}
+		rv.SetBool(bool(b))
+		return nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, err := n.leaf(name)
+		if err != nil {
+			return err
+		}
+		i, err := strconv.ParseInt(value, 10, rv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("jotform: parameter %s: %w", name, err)
+		}
+		rv.SetInt(i)
+		return nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value, err := n.leaf(name)
+		if err != nil {
+			return err
+		}
+		u, err := strconv.ParseUint(value, 10, rv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("jotform: parameter %s: %w", name, err)
+		}
+		rv.SetUint(u)
+		return nil
+
+	case reflect.Float32, reflect.Float64:
+		value, err := n.leaf(name)
+		if err != nil {
+			return err
+		}
+		f, err := strconv.ParseFloat(value, rv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("jotform: parameter %s: %w", name, err)
+		}
+		rv.SetFloat(f)
+		return nil
+
+	case reflect.Map:
+		if n.value != nil {
+			return fmt.Errorf("jotform: parameter %s is a value, not keys", name)
+		}
+		if rv.IsNil() {
+			rv.Set(reflect.MakeMapWithSize(rv.Type(), len(n.children)))
+		}
+		for key, child := range n.children {
+			k := reflect.New(rv.Type().Key()).Elem()
+			if err := setParamMapKey(k, key); err != nil {
+				return fmt.Errorf("jotform: parameter %s: %w", joinParam(name, key), err)
+			}
+			element := reflect.New(rv.Type().Elem()).Elem()
+			if existing := rv.MapIndex(k); existing.IsValid() {
+				element.Set(existing)
+			}
+			if err := decodeParam(child, joinParam(name, key), element); err != nil {
+				return err
+			}
+			rv.SetMapIndex(k, element)
+		}
+		return nil
+
+	case reflect.Slice, reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 && rv.Kind() == reflect.Slice {
+			value, err := n.leaf(name)
+			if err != nil {
+				return err
+			}
+			rv.SetBytes([]byte(value))
+			return nil
+		}
+		indexes, err := n.indexes(name)
+		if err != nil {
+			return err
+		}
+		length := 0
+		if len(indexes) > 0 {
+			length = indexes[len(indexes)-1] + 1
+		}
+		if rv.Kind() == reflect.Array {
+			if length > rv.Len() {
+				return fmt.Errorf("jotform: parameter %s has more than %d elements", name, rv.Len())
+			}
+		} else if rv.Len() < length {
+			grown := reflect.MakeSlice(rv.Type(), length, length)
+			reflect.Copy(grown, rv)
+			rv.Set(grown)
+		}
+		for _, i := range indexes {
+			key := strconv.Itoa(i)
+			if err := decodeParam(n.children[key], joinParam(name, key), rv.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case reflect.Struct:
+		if n.value != nil {
+			return fmt.Errorf("jotform: parameter %s is a value, not keys", name)
+		}
+		for _, field := range paramFields(rv.Type()) {
+			child, ok := n.children[field.name]
+			if !ok {
+				continue
+			}
+			if err := decodeParam(child, joinParam(name, field.name), rv.Field(field.index)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("jotform: cannot decode parameter %s into %s", name, rv.Type())
+}
+
+// leaf returns the node's value, or an error if it has keys instead.
+func (n *paramNode) leaf(name string) (string, error) {
+	if n.value == nil {
+		return "", fmt.Errorf("jotform: parameter %s has keys, not a value", name)
+	}
+	return *n.value, nil
+}
+
+// indexes returns the node's keys as sorted slice indexes.
+// Each index must be less than the number of keys, so that a sparse index can't allocate a huge slice.
+func (n *paramNode) indexes(name string) ([]int, error) {
+	if n.value != nil {
+		return nil, fmt.Errorf("jotform: parameter %s is a value, not a list", name)
+	}
+	indexes := make([]int, 0, len(n.children))
+	for key := range n.children {
+		i, err := strconv.Atoi(key)
+		if err != nil || i < 0 || i >= len(n.children) || strconv.Itoa(i) != key {
+			return nil, fmt.Errorf("jotform: parameter %s has a key %q out of order", name, key)
+		}
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+	return indexes, nil
+}
+
+func (n *paramNode) interfaceValue() interface{} {
+	if n.value != nil {
+		return *n.value
+	}
+	values := make(map[string]interface{}, len(n.children))
+	for key, child := range n.children {
+		values[key] = child.interfaceValue()
+	}
+	return values
+}
+
+func setParamMapKey(k reflect.Value, key string) error {
+	switch k.Kind() {
+	case reflect.String:
+		k.SetString(key)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(key, 10, k.Type().Bits())
+		if err != nil {
+			return err
+		}
+		k.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(key, 10, k.Type().Bits())
+		if err != nil {
+			return err
+		}
+		k.SetUint(u)
+	default:
+		return fmt.Errorf("cannot decode %s map keys", k.Type())
+	}
+	return nil
+}
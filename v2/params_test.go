@@ -0,0 +1,213 @@
+package jotform_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	jotform "github.com/jotform/jotform-api-go/v2"
+	"github.com/jotform/jotform-api-go/v2/jotformtest"
+	"github.com/stretchr/testify/assert"
+)
+
+type paramsAddress struct {
+	Line1  string `jotform:"addr_line1"`
+	City   string `jotform:"city"`
+	Postal string `jotform:"postal,omitempty"`
+}
+
+type paramsSubmission struct {
+	Name     map[string]string `jotform:"3"`
+	Email    string            `jotform:"4"`
+	Address  paramsAddress     `jotform:"5"`
+	Tags     []string          `jotform:"6"`
+	Count    int               `jotform:"7"`
+	Agreed   jotform.Bool      `jotform:"8"`
+	Created  jotform.Time      `jotform:"created_at"`
+	Skipped  string            `jotform:"-"`
+	Optional *string           `jotform:"optional,omitempty"`
+	Untagged string
+}
+
+func TestEncodeParams(t *testing.T) {
+	created := jotform.Time{Time: time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)}
+	submission := paramsSubmission{
+		Name:     map[string]string{"first": "Ada", "last": "Lovelace"},
+		Email:    "ada@example.com",
+		Address:  paramsAddress{Line1: "12 St James's Square", City: "London"},
+		Tags:     []string{"math", "engines"},
+		Count:    3,
+		Agreed:   true,
+		Created:  created,
+		Skipped:  "not sent",
+		Untagged: "sent",
+	}
+
+	t.Run("happy - struct with tags", func(t *testing.T) {
+		params, err := jotform.EncodeParams("submission", submission)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"submission[3][first]":      "Ada",
+			"submission[3][last]":       "Lovelace",
+			"submission[4]":             "ada@example.com",
+			"submission[5][addr_line1]": "12 St James's Square",
+			"submission[5][city]":       "London",
+			"submission[6][0]":          "math",
+			"submission[6][1]":          "engines",
+			"submission[7]":             "3",
+			"submission[8]":             "1",
+			"submission[created_at]":    "2024-05-01 09:30:00",
+			"submission[Untagged]":      "sent",
+		}, params)
+	})
+
+	t.Run("happy - round trip", func(t *testing.T) {
+		params, err := jotform.EncodeParams("submission", submission)
+		assert.NoError(t, err)
+
+		var decoded paramsSubmission
+		assert.NoError(t, jotform.DecodeParams(params, "submission", &decoded))
+		submission.Skipped = ""
+		assert.Equal(t, submission, decoded)
+	})
+
+	t.Run("happy - no prefix", func(t *testing.T) {
+		params, err := jotform.EncodeParams("", map[string]interface{}{
+			"properties": map[string]string{"title": "Contact"},
+			"questions":  []map[string]string{{"type": "control_textbox"}},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"properties[title]": "Contact", "questions[0][type]": "control_textbox"}, params)
+	})
+
+	t.Run("happy - decode into an interface", func(t *testing.T) {
+		var decoded interface{}
+		err := jotform.DecodeParams(map[string]string{
+			"submission[3][first]": "Ada",
+			"submission[4]":        "ada@example.com",
+			"other[1]":             "ignored",
+			"malformed[":           "ignored",
+		}, "submission", &decoded)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"3": map[string]interface{}{"first": "Ada"},
+			"4": "ada@example.com",
+		}, decoded)
+	})
+
+	for name, v := range map[string]interface{}{
+		"empty key":          map[string]string{"": "a"},
+		"bracketed key":      map[string]string{"a[b]": "c"},
+		"unsupported value":  map[string]interface{}{"a": make(chan int)},
+		"value with no name": "a",
+	} {
+		t.Run("sad - encode "+name, func(t *testing.T) {
+			_, err := jotform.EncodeParams("", v)
+			assert.Error(t, err)
+		})
+	}
+
+	for name, params := range map[string]map[string]string{
+		"value and keys":    {"a[b]": "1", "a[b][c]": "2"},
+		"malformed name":    {"a[b": "1"},
+		"empty key":         {"a[]": "1"},
+		"sparse list index": {"a[list][1000000]": "1"},
+		"not a number":      {"a[count]": "many"},
+	} {
+		t.Run("sad - decode "+name, func(t *testing.T) {
+			var decoded struct {
+				B     string   `jotform:"b"`
+				List  []string `jotform:"list"`
+				Count int      `jotform:"count"`
+			}
+			assert.Error(t, jotform.DecodeParams(params, "a", &decoded))
+		})
+	}
+}
+
+func TestSubmissionParams(t *testing.T) {
+	ctx := context.Background()
+	server := jotformtest.NewServer()
+	defer server.Close()
+	client := jotform.NewJotFormAPIClient("api-key", "json", false)
+	client.BaseURL = server.URL
+
+	form := server.AddForm(jotform.Form{Title: "Contact"})
+	submission := server.AddSubmission(jotform.Submission{FormID: form.ID})
+
+	t.Run("happy - only qids are split into subfields", func(t *testing.T) {
+		_, err := client.EditSubmissionContext(ctx, int64(submission.ID), map[string]string{
+			"3_first":    "Ada",
+			"4":          "ada@example.com",
+			"created_at": "2024-05-01 09:30:00",
+			"my_field":   "kept",
+		})
+		assert.NoError(t, err)
+
+		requests := server.Requests()
+		assert.Equal(t, url.Values{
+			"submission[3][first]":   {"Ada"},
+			"submission[4]":          {"ada@example.com"},
+			"submission[created_at]": {"2024-05-01 09:30:00"},
+			"submission[my_field]":   {"kept"},
+		}, requests[len(requests)-1].Form)
+	})
+
+	t.Run("sad - an answer and its subfields", func(t *testing.T) {
+		_, err := client.CreateFormSubmissionContext(ctx, int64(form.ID), map[string]string{"3": "Ada", "3_first": "Ada"})
+		assert.Error(t, err)
+	})
+}
+
+func FuzzParamsRoundTrip(f *testing.F) {
+	f.Add("3", "first", "Ada")
+	f.Add("created_at", "0", "2024-05-01 09:30:00")
+	f.Add("a b", "ü", "")
+	f.Add("[", "]", "x")
+
+	f.Fuzz(func(t *testing.T, key, subkey, value string) {
+		v := map[string]map[string]string{key: {subkey: value}}
+		params, err := jotform.EncodeParams("p", v)
+		if err != nil {
+			return
+		}
+
+		var decoded map[string]map[string]string
+		if err := jotform.DecodeParams(params, "p", &decoded); err != nil {
+			t.Fatalf("decoding %v: %v", params, err)
+		}
+		if decoded[key][subkey] != value || len(decoded) != 1 || len(decoded[key]) != 1 {
+			t.Fatalf("decoded %v from %v, want %v", decoded, params, v)
+		}
+	})
+}
+
+func FuzzDecodeParams(f *testing.F) {
+	f.Add("p[3][first]", "Ada", "p[3][last]")
+	f.Add("p[0]", "a", "p[1]")
+	f.Add("p[a]", "1", "p[a][b]")
+	f.Add("p[", "", "p]]")
+
+	f.Fuzz(func(t *testing.T, key1, value, key2 string) {
+		params := map[string]string{key1: value, key2: value}
+
+		var decoded interface{}
+		if err := jotform.DecodeParams(params, "p", &decoded); err != nil {
+			return
+		}
+		// Whatever decodes must encode back to the same parameters.
+		encoded, err := jotform.EncodeParams("p", decoded)
+		if err != nil {
+			t.Fatalf("encoding %v: %v", decoded, err)
+		}
+		var again interface{}
+		if err := jotform.DecodeParams(encoded, "p", &again); err != nil {
+			t.Fatalf("decoding %v: %v", encoded, err)
+		}
+		assert.Equal(t, decoded, again)
+
+		var list []string
+		jotform.DecodeParams(params, "p", &list)
+	})
+}
@@ -0,0 +1,195 @@
+package jotform
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"sync"
+)
+
+// DefaultPDFNameTemplate names the files written by DownloadPDFs.
+const DefaultPDFNameTemplate = "{formID}/{submissionID}.pdf"
+
+// DefaultPDFConcurrency is the number of PDFs DownloadPDFs downloads at once by default.
+const DefaultPDFConcurrency = 4
+
+// BatchPDFOptions configures DownloadPDFs.
+type BatchPDFOptions struct {
+	// ListOptions selects the submissions, eg. with Where.
+	// Its Limit is the page size in which they are listed.
+	ListOptions
+	// Dir is the directory the files are written in.
+	Dir string
+	// NameTemplate is the path of each file within Dir.
+	// {formID}, {submissionID} and {createdAt} are replaced by the submission's,
+	// with {createdAt} formatted as 20060102-150405.
+	// Defaults to DefaultPDFNameTemplate.
+	NameTemplate string
+	// Concurrency is the number of PDFs downloaded at once.
+	// Defaults to DefaultPDFConcurrency.
+	Concurrency int
+	// Rich downloads the form's own PDF filled in, as DownloadRichPDFSubmission,
+	// rather than the simple PDF.
+	Rich bool
+	// ReportID selects the PDF report the simple PDF is formatted with.
+	ReportID string
+	// Overwrite downloads PDFs again even if their file exists.
+	// By default, existing files are skipped.
+	Overwrite bool
+	// OnResult, if set, is called with each result as it completes.
+	// It may be called from several goroutines at once.
+	OnResult func(PDFResult)
+}
+
+// PDFResult is the outcome of downloading one submission's PDF.
+type PDFResult struct {
+	SubmissionID int64
+	Path         string
+	// Bytes is the size of the PDF downloaded.
+	Bytes int64
+	// Skipped is set if the file already existed.
+	Skipped bool
+	Err     error
+}
+
+var pdfNamePlaceholder = regexp.MustCompile(`\{[^}]*\}`)
+
+// DownloadPDFs downloads the PDF of each submission to a form selected by opts,
+// writing each to its own file.
+// A failure to download a PDF is reported in its PDFResult and does not stop the others.
+// The error is only set if the submissions could not be listed, or ctx was cancelled;
+// the results of the submissions listed so far are still returned,
+// with ctx's error for those whose download was cancelled or never started.
+func (client Client) DownloadPDFs(ctx context.Context, formID int64, opts *BatchPDFOptions) ([]PDFResult, error) {
+	if opts == nil {
+		opts = &BatchPDFOptions{}
+	}
+	template := opts.NameTemplate
+	if template == "" {
+		template = DefaultPDFNameTemplate
+	}
+	if err := checkPDFNameTemplate(template); err != nil {
+		return nil, err
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultPDFConcurrency
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type job struct {
+		index      int
+		submission Submission
+	}
+	jobs := make(chan job)
+	var mu sync.Mutex
+	var results []PDFResult
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				result := client.downloadPDF(ctx, j.submission, opts, template)
+				mu.Lock()
+				results[j.index] = result
+				mu.Unlock()
+				if opts.OnResult != nil {
+					opts.OnResult(result)
+				}
+			}
+		}()
+	}
+
+	it := client.IterFormSubmissions(ctx, formID, &IterOptions{ListOptions: opts.ListOptions})
+	for it.Next() {
+		submission := it.Submission()
+		if submission.FormID == 0 {
+			submission.FormID = Int(formID)
+		}
+
+		mu.Lock()
+		results = append(results, PDFResult{})
+		index := len(results) - 1
+		mu.Unlock()
+
+		select {
+		case jobs <- job{index: index, submission: submission}:
+			continue
+		case <-ctx.Done():
+		}
+		// Jobs already handed out fill in their own results, so only this one is left unstarted.
+		mu.Lock()
+		results[index] = PDFResult{
+			SubmissionID: int64(submission.ID),
+			Path:         filepath.Join(opts.Dir, filepath.FromSlash(pdfName(template, submission))),
+			Err:          ctx.Err(),
+		}
+		mu.Unlock()
+		break
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := it.Err(); err != nil {
+		return results, err
+	}
+	return results, ctx.Err()
+}
+
+func (client Client) downloadPDF(ctx context.Context, submission Submission, opts *BatchPDFOptions, template string) PDFResult {
+	result := PDFResult{
+		SubmissionID: int64(submission.ID),
+		Path:         filepath.Join(opts.Dir, filepath.FromSlash(pdfName(template, submission))),
+	}
+
+	if !opts.Overwrite {
+		if _, err := os.Stat(result.Path); err == nil {
+			result.Skipped = true
+			return result
+		}
+	}
+
+	formID := strconv.FormatInt(int64(submission.FormID), 10)
+	submissionID := strconv.FormatInt(int64(submission.ID), 10)
+	if opts.Rich {
+		result.Bytes, result.Err = client.SaveRichPDFSubmission(ctx, result.Path, formID, submissionID)
+	} else {
+		result.Bytes, result.Err = client.SaveSimplePDFSubmission(ctx, result.Path, formID, submissionID, opts.ReportID)
+	}
+	if result.Err != nil {
+		result.Err = fmt.Errorf("jotform: downloading PDF of submission %s: %w", submissionID, result.Err)
+	}
+	return result
+}
+
+func checkPDFNameTemplate(template string) error {
+	for _, placeholder := range pdfNamePlaceholder.FindAllString(template, -1) {
+		switch placeholder {
+		case "{formID}", "{submissionID}", "{createdAt}":
+		default:
+			return fmt.Errorf("jotform: unknown placeholder %s in PDF name template", placeholder)
+		}
+	}
+	return nil
+}
+
+func pdfName(template string, submission Submission) string {
+	return pdfNamePlaceholder.ReplaceAllStringFunc(template, func(placeholder string) string {
+		switch placeholder {
+		case "{formID}":
+			return strconv.FormatInt(int64(submission.FormID), 10)
+		case "{submissionID}":
+			return strconv.FormatInt(int64(submission.ID), 10)
+		case "{createdAt}":
+			return submission.CreatedAt.In(TimeLocation).Format("20060102-150405")
+		}
+		return placeholder
+	})
+}
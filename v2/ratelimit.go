@@ -0,0 +1,180 @@
+package jotform
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrQuotaExhausted is returned instead of sending a request
+// once the account's daily API quota has run out.
+var ErrQuotaExhausted = errors.New("jotform: daily API quota exhausted")
+
+// RateLimiter paces the requests sent by the client.
+type RateLimiter interface {
+	// Wait blocks until a request may be sent,
+	// or returns an error if it should not be sent at all.
+	Wait(ctx context.Context) error
+	// Observe records the number of API calls JotForm reports as left today.
+	Observe(remaining int)
+}
+
+// Limiter is a RateLimiter combining a token bucket,
+// which smooths bursts of requests,
+// with tracking of JotForm's daily quota.
+type Limiter struct {
+	// Rate is the sustained number of requests per second.
+	// Zero disables the token bucket.
+	Rate float64
+	// Burst is the number of requests that may be sent at once.
+	Burst int
+	// Reserve is the number of calls to keep in hand for other uses of the account.
+	// Requests are held back once the remaining quota falls to Reserve.
+	Reserve int
+	// BlockOnQuota makes Wait block until the quota resets,
+	// instead of failing with ErrQuotaExhausted.
+	BlockOnQuota bool
+	// ResetLocation is the location whose midnight resets the daily quota.
+	// Defaults to UTC.
+	ResetLocation *time.Location
+
+	mu         sync.Mutex
+	tokens     float64
+	last       time.Time
+	remaining  int
+	observedAt time.Time
+}
+
+// NewLimiter returns a Limiter allowing rate requests per second,
+// in bursts of up to burst requests.
+func NewLimiter(rate float64, burst int) *Limiter {
+	return &Limiter{
+		Rate:  rate,
+		Burst: burst,
+	}
+}
+
+func (l *Limiter) Wait(ctx context.Context) error {
+	for {
+		delay, err := l.reserve(time.Now())
+		if err != nil || delay == 0 {
+			return err
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+func (l *Limiter) Observe(remaining int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.remaining = remaining
+	l.observedAt = time.Now()
+}
+
+// reserve takes a token if a request may be sent now,
+// and otherwise returns how long to wait before trying again.
+func (l *Limiter) reserve(now time.Time) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.observedAt.IsZero() {
+		reset := l.nextReset(l.observedAt)
+		if !now.Before(reset) {
+			l.observedAt = time.Time{}
+		} else if l.remaining <= l.Reserve {
+			if !l.BlockOnQuota {
+				return 0, ErrQuotaExhausted
+			}
+			return reset.Sub(now), nil
+		}
+	}
+
+	if l.Rate > 0 {
+		burst := math.Max(float64(l.Burst), 1)
+		if l.last.IsZero() {
+			l.tokens = burst
+		} else {
+			l.tokens = math.Min(burst, l.tokens+now.Sub(l.last).Seconds()*l.Rate)
+		}
+		l.last = now
+
+		if l.tokens < 1 {
+			return time.Duration((1 - l.tokens) / l.Rate * float64(time.Second)), nil
+		}
+		l.tokens--
+	}
+
+	if !l.observedAt.IsZero() {
+		// Count the call against the quota until JotForm reports a fresh figure.
+		l.remaining--
+	}
+	return 0, nil
+}
+
+func (l *Limiter) nextReset(t time.Time) time.Time {
+	location := l.ResetLocation
+	if location == nil {
+		location = time.UTC
+	}
+	t = t.In(location)
+	return time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, location)
+}
+
+// limitedClient is an HttpClient that waits on limiter before every request.
+type limitedClient struct {
+	next    HttpClient
+	limiter RateLimiter
+}
+
+func (l limitedClient) Do(req *http.Request) (*http.Response, error) {
+	if err := l.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return l.next.Do(req)
+}
+
+// quotaTracker remembers the last remaining-calls figure JotForm reported.
+type quotaTracker struct {
+	mu        sync.Mutex
+	remaining int
+	known     bool
+}
+
+func (q *quotaTracker) set(remaining int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.remaining = remaining
+	q.known = true
+}
+
+func (q *quotaTracker) get() (int, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.remaining, q.known
+}
+
+// QuotaRemaining returns the number of API calls JotForm last reported
+// as left today, and false if no response has reported it yet.
+func (client Client) QuotaRemaining() (int, bool) {
+	if client.quota == nil {
+		return 0, false
+	}
+	return client.quota.get()
+}
+
+func (client Client) observeQuota(remaining int) {
+	if client.quota != nil {
+		client.quota.set(remaining)
+	}
+	if client.RateLimiter != nil {
+		client.RateLimiter.Observe(remaining)
+	}
+}
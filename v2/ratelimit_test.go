@@ -0,0 +1,106 @@
+package jotform_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	jotform "github.com/jotform/jotform-api-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// quotaClient reports one less remaining call on every response, starting at left.
+func quotaClient(left int, calls *int) *jotform.MockHttpClient {
+	return &jotform.MockHttpClient{DoFunc: func(req *http.Request) (*http.Response, error) {
+		*calls++
+		body := fmt.Sprintf(`{"responseCode":200,"content":{},"limit-left":%d}`, left-*calls)
+		return jotform.NewMockResponse(req, 200, body), nil
+	}}
+}
+
+func TestQuotaRemaining(t *testing.T) {
+	t.Run("happy - unknown before the first response", func(t *testing.T) {
+		client := jotform.NewTestClient(&jotform.MockHttpClient{})
+
+		_, known := client.QuotaRemaining()
+		assert.False(t, known)
+	})
+
+	t.Run("happy - parsed from every response", func(t *testing.T) {
+		var calls int
+		client := jotform.NewTestClient(quotaClient(1000, &calls))
+
+		_, err := client.GetUser()
+		assert.Nil(t, err)
+		remaining, known := client.QuotaRemaining()
+		assert.True(t, known)
+		assert.Equal(t, 999, remaining)
+
+		_, err = client.GetUsage()
+		assert.Nil(t, err)
+		remaining, _ = client.QuotaRemaining()
+		assert.Equal(t, 998, remaining)
+	})
+
+	t.Run("happy - parsed from error responses", func(t *testing.T) {
+		body := `{"responseCode":404,"message":"Not found","content":"","limit-left":"42"}`
+		client := jotform.NewTestClient(newJSONClient(404, body, nil))
+
+		_, err := client.GetUser()
+		assert.NotNil(t, err)
+		remaining, known := client.QuotaRemaining()
+		assert.True(t, known)
+		assert.Equal(t, 42, remaining)
+	})
+}
+
+func TestLimiter(t *testing.T) {
+	t.Run("sad - fails fast once the quota reaches the reserve", func(t *testing.T) {
+		var calls int
+		client := jotform.NewTestClient(quotaClient(13, &calls))
+		limiter := jotform.NewLimiter(0, 0)
+		limiter.Reserve = 10
+		client.RateLimiter = limiter
+
+		var err error
+		for i := 0; i < 5 && err == nil; i++ {
+			_, err = client.GetUser()
+		}
+		assert.True(t, errors.Is(err, jotform.ErrQuotaExhausted))
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("sad - blocks on quota until the context ends", func(t *testing.T) {
+		var calls int
+		client := jotform.NewTestClient(quotaClient(1, &calls))
+		limiter := jotform.NewLimiter(0, 0)
+		limiter.BlockOnQuota = true
+		client.RateLimiter = limiter
+
+		_, err := client.GetUser()
+		assert.Nil(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err = client.GetUserContext(ctx)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("happy - token bucket paces requests", func(t *testing.T) {
+		var calls int
+		client := jotform.NewTestClient(quotaClient(1000, &calls))
+		client.RateLimiter = jotform.NewLimiter(100, 1)
+
+		start := time.Now()
+		for i := 0; i < 4; i++ {
+			_, err := client.GetUser()
+			assert.Nil(t, err)
+		}
+		assert.True(t, time.Since(start) >= 25*time.Millisecond)
+		assert.Equal(t, 4, calls)
+	})
+}
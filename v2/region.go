@@ -0,0 +1,78 @@
+package jotform
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Region is a JotForm deployment, with its own API host.
+// Accounts only exist in the region they were created in.
+type Region struct {
+	// Name identifies the region, eg. "eu".
+	Name string
+	// APIURL is the base URL of the region's API, PDF endpoints included.
+	APIURL string
+	// Host is the host the region's forms and uploaded files are served from.
+	Host string
+}
+
+// The regions of JotForm's public cloud.
+var (
+	RegionUS    = Region{Name: "us", APIURL: defaultBaseURL, Host: "www.jotform.com"}
+	RegionEU    = Region{Name: "eu", APIURL: "https://eu-api.jotform.com", Host: "eu.jotform.com"}
+	RegionHIPAA = Region{Name: "hipaa", APIURL: "https://hipaa-api.jotform.com", Host: "hipaa.jotform.com"}
+)
+
+// Regions are the regions of JotForm's public cloud.
+var Regions = []Region{RegionUS, RegionEU, RegionHIPAA}
+
+// Enterprise returns the region of a JotForm Enterprise server, eg. Enterprise("acme.jotform.com"),
+// whose API is under "https://acme.jotform.com/API".
+// The host may also be given as a URL.
+func Enterprise(host string) Region {
+	host = strings.TrimSuffix(host, "/")
+	if u, err := url.Parse(host); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	return Region{Name: host, APIURL: "https://" + host + "/API", Host: host}
+}
+
+// ParseRegion returns the region named "us", "eu" or "hipaa",
+// or, for a host name such as "acme.jotform.com", the Enterprise region of that host.
+func ParseRegion(name string) (Region, error) {
+	name = strings.TrimSpace(name)
+	for _, region := range Regions {
+		if strings.EqualFold(name, region.Name) {
+			return region, nil
+		}
+	}
+	if strings.Contains(name, ".") {
+		return Enterprise(name), nil
+	}
+	return Region{}, fmt.Errorf("jotform: unknown region %q", name)
+}
+
+// SetRegion points the client at region's API,
+// and sends the API key with requests for files on its Host.
+func (client *Client) SetRegion(region Region) {
+	client.BaseURL = strings.TrimSuffix(region.APIURL, "/")
+	client.fileHost = region.Host
+}
+
+// DetectRegion finds the public region the client's account is in, and points the client at it.
+// It calls GetUser once, and looks up the region the account reports in Regions.
+func (client *Client) DetectRegion(ctx context.Context) (Region, error) {
+	user, err := client.GetUserTyped(ctx)
+	if err != nil {
+		return Region{}, err
+	}
+	for _, region := range Regions {
+		if strings.EqualFold(user.Region, region.Name) {
+			client.SetRegion(region)
+			return region, nil
+		}
+	}
+	return Region{}, fmt.Errorf("jotform: unknown account region %q", user.Region)
+}
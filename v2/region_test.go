@@ -0,0 +1,119 @@
+package jotform_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	jotform "github.com/jotform/jotform-api-go/v2"
+	"github.com/jotform/jotform-api-go/v2/jotformtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegion(t *testing.T) {
+	t.Run("happy - enterprise layout", func(t *testing.T) {
+		region := jotform.Enterprise("acme.jotform.com")
+		assert.Equal(t, "https://acme.jotform.com/API", region.APIURL)
+		assert.Equal(t, "acme.jotform.com", region.Host)
+
+		assert.Equal(t, region, jotform.Enterprise("https://acme.jotform.com/"))
+	})
+
+	t.Run("happy - parse region", func(t *testing.T) {
+		region, err := jotform.ParseRegion("EU")
+		assert.Nil(t, err)
+		assert.Equal(t, jotform.RegionEU, region)
+
+		region, err = jotform.ParseRegion("hipaa")
+		assert.Nil(t, err)
+		assert.Equal(t, "https://hipaa-api.jotform.com", region.APIURL)
+
+		region, err = jotform.ParseRegion("forms.acme.com")
+		assert.Nil(t, err)
+		assert.Equal(t, jotform.Enterprise("forms.acme.com"), region)
+	})
+
+	t.Run("sad - unknown region", func(t *testing.T) {
+		_, err := jotform.ParseRegion("mars")
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "mars")
+		}
+	})
+
+	t.Run("happy - region sets API and file hosts", func(t *testing.T) {
+		var urls []string
+		var keys []string
+		client := jotform.New("key", jotform.WithRegion(jotform.Region{
+			APIURL: "https://api.example.com/API/",
+			Host:   "forms.example.com",
+		}), jotform.WithHTTPClient(&jotform.MockHttpClient{DoFunc: func(req *http.Request) (*http.Response, error) {
+			urls = append(urls, req.URL.Scheme+"://"+req.URL.Host+req.URL.Path)
+			keys = append(keys, req.Header.Get("apiKey"))
+			return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(bytes.NewBufferString(`{"responseCode": 200, "content": {}}`))}, nil
+		}}))
+		ctx := context.Background()
+
+		_, _ = client.GetUserContext(ctx)
+		_, _ = client.WriteSimplePDFSubmission(ctx, ioutil.Discard, "1", "2", "")
+		_, _ = client.DownloadFile(ctx, ioutil.Discard, jotform.File{URL: "https://forms.example.com/uploads/a.pdf"})
+		_, _ = client.DownloadFile(ctx, ioutil.Discard, jotform.File{URL: "https://elsewhere.example.com/a.pdf"})
+
+		assert.Equal(t, []string{
+			"https://api.example.com/API/v1/user",
+			"https://api.example.com/API/v1/generatePDF",
+			"https://forms.example.com/uploads/a.pdf",
+			"https://elsewhere.example.com/a.pdf",
+		}, urls)
+		assert.Equal(t, []string{"key", "key", "key", ""}, keys)
+	})
+
+	t.Run("happy - base URL overrides the region", func(t *testing.T) {
+		client := jotform.New("key", jotform.WithRegion(jotform.RegionEU), jotform.WithBaseURL("https://proxy.example.com"))
+		assert.Equal(t, "https://proxy.example.com", client.BaseURL)
+	})
+}
+
+func TestDetectRegion(t *testing.T) {
+	server := jotformtest.NewServer()
+	defer server.Close()
+	server.APIKey = "key"
+	ctx := context.Background()
+
+	t.Run("happy - maps the account's region to a preset with one call", func(t *testing.T) {
+		for _, region := range jotform.Regions {
+			server.SetUser(jotform.User{Username: "someone", Region: strings.ToUpper(region.Name)})
+			client := jotform.New("key", jotform.WithBaseURL(server.URL))
+			before := len(server.Requests())
+
+			detected, err := client.DetectRegion(ctx)
+			assert.Nil(t, err)
+			assert.Equal(t, region, detected)
+			assert.Equal(t, region.APIURL, client.BaseURL)
+			assert.Equal(t, before+1, len(server.Requests()))
+		}
+	})
+
+	t.Run("sad - unknown region", func(t *testing.T) {
+		server.SetUser(jotform.User{Username: "someone", Region: "MARS"})
+		client := jotform.New("key", jotform.WithBaseURL(server.URL))
+
+		_, err := client.DetectRegion(ctx)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "MARS")
+		}
+		assert.Equal(t, server.URL, client.BaseURL)
+	})
+
+	t.Run("sad - errors leave the client as it was", func(t *testing.T) {
+		client := jotform.New("wrong", jotform.WithBaseURL(server.URL))
+		client.Retry = nil
+
+		_, err := client.DetectRegion(ctx)
+		assert.True(t, errors.Is(err, jotform.ErrUnauthorized))
+		assert.Equal(t, server.URL, client.BaseURL)
+	})
+}
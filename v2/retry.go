@@ -0,0 +1,234 @@
+package jotform
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy configures how the client retries transient failures.
+// GET and DELETE requests are retried; POST and PUT requests are only
+// retried when RetryNonIdempotent is set, as they may not be safe to repeat.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	// Values below 2 disable retries.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry.
+	// It doubles for every following retry.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between attempts, including Retry-After.
+	// Zero means no cap.
+	MaxDelay time.Duration
+	// Jitter is the fraction of each delay, between 0 and 1,
+	// that is randomised to spread out retries from concurrent callers.
+	Jitter float64
+	// RetryableStatusCodes are the HTTP status codes that are retried.
+	RetryableStatusCodes []int
+	// RetryableError reports whether a transport error is retried.
+	// If nil, only errors that may not recur are retried:
+	// timeouts, refused and reset connections, temporary DNS failures,
+	// and connections closed early with io.EOF or io.ErrUnexpectedEOF.
+	// Errors such as an invalid certificate or URL are returned at once.
+	RetryableError func(err error) bool
+	// RetryNonIdempotent enables retries for POST and PUT requests.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns the policy used by NewJotFormAPIClient:
+// three attempts, starting at half a second apart,
+// retrying temporary network errors, 429s and 5xx gateway errors.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+		Jitter:      0.2,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+func (policy *RetryPolicy) allows(req *http.Request) bool {
+	if policy == nil || policy.MaxAttempts < 2 {
+		return false
+	}
+
+	switch req.Method {
+	case "GET", "HEAD", "DELETE", "OPTIONS":
+		return true
+	}
+	return policy.RetryNonIdempotent && (req.Body == nil || req.GetBody != nil)
+}
+
+func (policy *RetryPolicy) retryableStatus(statusCode int) bool {
+	for _, code := range policy.RetryableStatusCodes {
+		if code == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+func (policy *RetryPolicy) retryableError(req *http.Request, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if policy.RetryableError != nil {
+		return policy.RetryableError(err)
+	}
+	return temporaryError(err)
+}
+
+// temporaryError reports whether a transport error may not recur, for the default RetryableError.
+func temporaryError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrQuotaExhausted) {
+		return false
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNABORTED) {
+		return true
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTemporary || dnsErr.IsTimeout
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// backoff returns the delay before the given retry, counting from 1.
+func (policy *RetryPolicy) backoff(retry int) time.Duration {
+	delay := float64(policy.BaseDelay) * math.Pow(2, float64(retry-1))
+	if policy.MaxDelay > 0 && delay > float64(policy.MaxDelay) {
+		delay = float64(policy.MaxDelay)
+	}
+	if policy.Jitter > 0 {
+		delay -= delay * policy.Jitter * rand.Float64()
+	}
+	return time.Duration(delay)
+}
+
+func (policy *RetryPolicy) capDelay(delay time.Duration) time.Duration {
+	if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+		return policy.MaxDelay
+	}
+	return delay
+}
+
+// retryingClient is an HttpClient that retries requests according to policy.
+type retryingClient struct {
+	next   HttpClient
+	policy *RetryPolicy
+}
+
+func (r retryingClient) Do(req *http.Request) (*http.Response, error) {
+	if !r.policy.allows(req) {
+		return r.next.Do(req)
+	}
+
+	for attempt := 1; ; attempt++ {
+		attemptReq := req
+		if attempt > 1 {
+			var err error
+			if attemptReq, err = rewind(req); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := r.next.Do(attemptReq)
+		if attempt >= r.policy.MaxAttempts {
+			return resp, err
+		}
+
+		var delay time.Duration
+		if err != nil {
+			if !r.policy.retryableError(req, err) {
+				return resp, err
+			}
+			delay = r.policy.backoff(attempt)
+		} else {
+			if !r.policy.retryableStatus(resp.StatusCode) {
+				return resp, err
+			}
+			delay = r.policy.backoff(attempt)
+			if after, ok := retryAfter(resp); ok {
+				delay = r.policy.capDelay(after)
+			}
+			drain(resp)
+		}
+
+		if err := sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// rewind returns a copy of req with a fresh body, ready to be sent again.
+func rewind(req *http.Request) (*http.Request, error) {
+	retry := req.Clone(req.Context())
+	if req.Body != nil && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		retry.Body = body
+	}
+	return retry, nil
+}
+
+// retryAfter parses the Retry-After header,
+// which is either a number of seconds or an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+
+	return 0, false
+}
+
+// drain discards and closes the body of a response that won't be used,
+// so that its connection can be reused.
+func drain(resp *http.Response) {
+	if resp.Body != nil {
+		io.CopyN(ioutil.Discard, resp.Body, 4096)
+		resp.Body.Close()
+	}
+}
+
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
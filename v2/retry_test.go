@@ -0,0 +1,230 @@
+package jotform_test
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	jotform "github.com/jotform/jotform-api-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+const okBody = `{"responseCode":200,"content":{"username":"johnsmith"}}`
+
+// scriptedClient answers each request with the next step of the script,
+// repeating the last step once the script runs out.
+type scriptStep struct {
+	status int
+	header http.Header
+	err    error
+}
+
+func scriptedClient(attempts *int, bodies *[]string, steps ...scriptStep) *jotform.MockHttpClient {
+	return &jotform.MockHttpClient{DoFunc: func(req *http.Request) (*http.Response, error) {
+		step := steps[len(steps)-1]
+		if *attempts < len(steps) {
+			step = steps[*attempts]
+		}
+		*attempts++
+
+		if bodies != nil && req.Body != nil {
+			body, _ := ioutil.ReadAll(req.Body)
+			*bodies = append(*bodies, string(body))
+		}
+
+		if step.err != nil {
+			return nil, step.err
+		}
+		resp := jotform.NewMockResponse(req, step.status, okBody)
+		for k, v := range step.header {
+			resp.Header[k] = v
+		}
+		return resp, nil
+	}}
+}
+
+func fastRetryPolicy() *jotform.RetryPolicy {
+	policy := jotform.DefaultRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	policy.MaxDelay = 5 * time.Millisecond
+	return policy
+}
+
+func TestRetry(t *testing.T) {
+	t.Run("happy - retries transient statuses on GET", func(t *testing.T) {
+		var attempts int
+		client := jotform.NewTestClient(scriptedClient(&attempts, nil,
+			scriptStep{status: 503}, scriptStep{status: 502}, scriptStep{status: 200},
+		))
+		client.Retry = fastRetryPolicy()
+
+		content, err := client.GetUser()
+		assert.Nil(t, err)
+		assert.Equal(t, 3, attempts)
+		assert.Equal(t, `{"username":"johnsmith"}`, string(content))
+	})
+
+	t.Run("happy - retries network errors", func(t *testing.T) {
+		var attempts int
+		client := jotform.NewTestClient(scriptedClient(&attempts, nil,
+			scriptStep{err: io.EOF}, scriptStep{status: 200},
+		))
+		client.Retry = fastRetryPolicy()
+
+		_, err := client.DeleteSubmission(123)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, attempts)
+	})
+
+	t.Run("happy - retries temporary network errors", func(t *testing.T) {
+		for _, transient := range []error{
+			io.ErrUnexpectedEOF,
+			&url.Error{Op: "Get", URL: "https://api.jotform.com", Err: &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}},
+			&url.Error{Op: "Get", URL: "https://api.jotform.com", Err: &net.DNSError{Err: "server misbehaving", IsTemporary: true}},
+			&url.Error{Op: "Get", URL: "https://api.jotform.com", Err: os.ErrDeadlineExceeded},
+		} {
+			var attempts int
+			client := jotform.NewTestClient(scriptedClient(&attempts, nil,
+				scriptStep{err: transient}, scriptStep{status: 200},
+			))
+			client.Retry = fastRetryPolicy()
+
+			_, err := client.GetUser()
+			assert.Nil(t, err, transient.Error())
+			assert.Equal(t, 2, attempts, transient.Error())
+		}
+	})
+
+	t.Run("sad - does not retry other errors", func(t *testing.T) {
+		for _, permanent := range []error{
+			&url.Error{Op: "Get", URL: "https://api.jotform.com", Err: x509.UnknownAuthorityError{}},
+			&url.Error{Op: "Get", URL: "https://api.jotform.com", Err: &net.DNSError{Err: "no such host", IsNotFound: true}},
+			errors.New("unsupported protocol scheme"),
+			jotform.ErrQuotaExhausted,
+		} {
+			var attempts int
+			client := jotform.NewTestClient(scriptedClient(&attempts, nil,
+				scriptStep{err: permanent}, scriptStep{status: 200},
+			))
+			client.Retry = fastRetryPolicy()
+
+			_, err := client.GetUser()
+			assert.NotNil(t, err, permanent.Error())
+			assert.Equal(t, 1, attempts, permanent.Error())
+		}
+	})
+
+	t.Run("sad - gives up after MaxAttempts", func(t *testing.T) {
+		var attempts int
+		client := jotform.NewTestClient(scriptedClient(&attempts, nil, scriptStep{status: 503}))
+		client.Retry = fastRetryPolicy()
+
+		_, err := client.GetUser()
+		assert.True(t, errors.Is(err, jotform.ErrServerError))
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("sad - does not retry other statuses", func(t *testing.T) {
+		var attempts int
+		client := jotform.NewTestClient(scriptedClient(&attempts, nil, scriptStep{status: 404}))
+		client.Retry = fastRetryPolicy()
+
+		_, err := client.GetUser()
+		assert.True(t, errors.Is(err, jotform.ErrNotFound))
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("sad - does not retry POST by default", func(t *testing.T) {
+		var attempts int
+		client := jotform.NewTestClient(scriptedClient(&attempts, nil, scriptStep{status: 503}))
+		client.Retry = fastRetryPolicy()
+
+		_, err := client.CreateFormWebhook(123, "https://example.com/hook")
+		assert.NotNil(t, err)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("happy - retries POST when enabled, replaying the body", func(t *testing.T) {
+		var attempts int
+		var bodies []string
+		client := jotform.NewTestClient(scriptedClient(&attempts, &bodies,
+			scriptStep{status: 503}, scriptStep{status: 200},
+		))
+		client.Retry = fastRetryPolicy()
+		client.Retry.RetryNonIdempotent = true
+
+		_, err := client.CreateFormWebhook(123, "https://example.com/hook")
+		assert.Nil(t, err)
+		assert.Equal(t, 2, attempts)
+		assert.Equal(t, []string{
+			"webhookURL=https%3A%2F%2Fexample.com%2Fhook",
+			"webhookURL=https%3A%2F%2Fexample.com%2Fhook",
+		}, bodies)
+	})
+
+	t.Run("happy - honours Retry-After", func(t *testing.T) {
+		var attempts int
+		client := jotform.NewTestClient(scriptedClient(&attempts, nil,
+			scriptStep{status: 429, header: http.Header{"Retry-After": []string{"0"}}},
+			scriptStep{status: 200},
+		))
+		client.Retry = fastRetryPolicy()
+		client.Retry.BaseDelay = time.Hour
+		client.Retry.MaxDelay = 0
+
+		start := time.Now()
+		_, err := client.GetUser()
+		assert.Nil(t, err)
+		assert.Equal(t, 2, attempts)
+		assert.True(t, time.Since(start) < time.Second)
+	})
+
+	t.Run("sad - cancellation interrupts the backoff", func(t *testing.T) {
+		var attempts int
+		client := jotform.NewTestClient(scriptedClient(&attempts, nil, scriptStep{status: 503}))
+		client.Retry = fastRetryPolicy()
+		client.Retry.BaseDelay = time.Hour
+		client.Retry.MaxDelay = 0
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(10*time.Millisecond, cancel)
+
+		_, err := client.GetUserContext(ctx)
+		assert.True(t, errors.Is(err, context.Canceled))
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("happy - nil policy disables retries", func(t *testing.T) {
+		var attempts int
+		client := jotform.NewTestClient(scriptedClient(&attempts, nil, scriptStep{status: 503}))
+		client.Retry = nil
+
+		_, err := client.GetUser()
+		assert.NotNil(t, err)
+		assert.Equal(t, 1, attempts)
+	})
+}
+
+func TestDefaultHTTPClient(t *testing.T) {
+	t.Run("happy - connections aren't reused", func(t *testing.T) {
+		for _, client := range []*jotform.Client{
+			jotform.NewJotFormAPIClient("api-key", "json", false),
+			jotform.New("api-key"),
+		} {
+			httpClient, ok := client.HttpClient.(*http.Client)
+			if assert.True(t, ok) {
+				assert.Equal(t, jotform.DefaultTimeout, httpClient.Timeout)
+				assert.True(t, httpClient.Transport.(*http.Transport).DisableKeepAlives)
+			}
+		}
+	})
+}
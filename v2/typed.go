@@ -0,0 +1,452 @@
+package jotform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// ListOptions selects a page of results from the list endpoints,
+// ie. GetForms, GetSubmissions and GetFormSubmissions.
+// The zero value uses JotForm's defaults.
+type ListOptions struct {
+	// Offset is the start of the result set.
+	Offset int
+	// Limit is the number of results per page.
+	Limit int
+	// Filter narrows the results, eg. {"created_at:gt": "2013-01-01 00:00:00"}.
+	Filter map[string]string
+	// OrderBy orders results by a field name, eg. "created_at".
+	OrderBy string
+	// Where narrows the results, like Filter, but can also order them
+	// and hold several conditions on one field.
+	// Only one of Filter and Where may be set.
+	Where *Filter
+}
+
+func (opts *ListOptions) params() (map[string]string, error) {
+	params := make(map[string]string)
+	if opts == nil {
+		return params, nil
+	}
+
+	if opts.Where != nil {
+		if len(opts.Filter) > 0 {
+			return nil, fmt.Errorf("jotform: only one of ListOptions.Filter and ListOptions.Where may be set")
+		}
+		where, err := opts.Where.Params()
+		if err != nil {
+			return nil, err
+		}
+		params = where
+	}
+
+	if opts.Offset > 0 {
+		params["offset"] = strconv.Itoa(opts.Offset)
+	}
+	if opts.Limit > 0 {
+		params["limit"] = strconv.Itoa(opts.Limit)
+	}
+	if len(opts.Filter) > 0 {
+		filter, err := json.Marshal(opts.Filter)
+		if err == nil {
+			params["filter"] = string(filter)
+		}
+	}
+	if opts.OrderBy != "" {
+		params["orderby"] = opts.OrderBy
+	}
+	return params, nil
+}
+
+// decodeContent performs the request and decodes the response content into v.
+func (client Client) decodeContent(ctx context.Context, requestPath string, params interface{}, method string, v interface{}) error {
+	_, err := client.decodePage(ctx, requestPath, params, method, v)
+	return err
+}
+
+// decodePage is decodeContent for list endpoints,
+// also returning the paging metadata of the response.
+func (client Client) decodePage(ctx context.Context, requestPath string, params interface{}, method string, v interface{}) (*ResultSet, error) {
+	if client.outputType != "json" && client.outputType != "xml" {
+		return nil, fmt.Errorf("typed responses require json or xml output, client is using %q", client.outputType)
+	}
+
+	if client.outputType == "json" {
+		response, err := client.send(ctx, requestPath, params, method)
+		if err != nil {
+			return nil, err
+		}
+		defer response.Body.Close()
+
+		env, err := decodeEnvelope(response.Body, v)
+		if err != nil {
+			return nil, err
+		}
+		if err := client.checkEnvelope(response, env); err != nil {
+			return nil, err
+		}
+		return env.ResultSet, nil
+	}
+
+	result, err := client.execute(ctx, requestPath, params, method)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.outputType == "xml" {
+		if len(result.content) == 0 {
+			// No content element, as json's "content": null.
+			return result.resultSet, nil
+		}
+		content, err := parseXML(result.content)
+		if err != nil {
+			return nil, err
+		}
+		return result.resultSet, content.decodeJSON(v)
+	}
+	return result.resultSet, json.Unmarshal(result.content, v)
+}
+
+// decodeEnvelope reads a json envelope from r, decoding its content into v as it goes,
+// so that large pages aren't held in memory twice.
+// Content that comes before the responseCode, or with a failed one, is kept in the envelope instead,
+// and only decoded into v once the envelope is known to have succeeded.
+func decodeEnvelope(r io.Reader, v interface{}) (envelope, error) {
+	var env envelope
+	dec := json.NewDecoder(r)
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return env, fmt.Errorf("Unexpected non-json response")
+	}
+
+	fields := map[string]interface{}{
+		"message":    &env.Message,
+		"duration":   &env.Duration,
+		"info":       &env.Info,
+		"limit-left": &env.LimitLeft,
+		"resultSet":  &env.ResultSet,
+	}
+	sawCode, decoded := false, false
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return env, err
+		}
+		key, _ := tok.(string)
+
+		switch {
+		case key == "responseCode":
+			sawCode = true
+			err = dec.Decode(&env.ResponseCode)
+		case key == "content" && sawCode && !env.failed():
+			decoded = true
+			err = dec.Decode(v)
+		case key == "content":
+			err = dec.Decode(&env.Content)
+		case fields[key] != nil:
+			err = dec.Decode(fields[key])
+		default:
+			var skipped json.RawMessage
+			err = dec.Decode(&skipped)
+		}
+		if err != nil {
+			return env, err
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return env, err
+	}
+
+	if !decoded && !env.failed() && env.Content != nil {
+		return env, json.Unmarshal(env.Content, v)
+	}
+	return env, nil
+}
+
+// GetUserTyped is GetUser, decoded into a User.
+func (client Client) GetUserTyped(ctx context.Context) (*User, error) {
+	var user User
+	if err := client.decodeContent(ctx, "user", "", "GET", &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetUsageTyped is GetUsage, decoded into a Usage.
+func (client Client) GetUsageTyped(ctx context.Context) (*Usage, error) {
+	var usage Usage
+	if err := client.decodeContent(ctx, "user/usage", "", "GET", &usage); err != nil {
+		return nil, err
+	}
+	return &usage, nil
+}
+
+// GetFormsTyped is GetForms, decoded into Forms.
+func (client Client) GetFormsTyped(ctx context.Context, opts *ListOptions) ([]Form, error) {
+	params, err := opts.params()
+	if err != nil {
+		return nil, err
+	}
+
+	var forms []Form
+	if err := client.decodeContent(ctx, "user/forms", params, "GET", &forms); err != nil {
+		return nil, err
+	}
+	return forms, nil
+}
+
+// GetSubmissionsTyped is GetSubmissions, decoded into Submissions.
+func (client Client) GetSubmissionsTyped(ctx context.Context, opts *ListOptions) ([]Submission, error) {
+	params, err := opts.params()
+	if err != nil {
+		return nil, err
+	}
+
+	var submissions []Submission
+	if err := client.decodeContent(ctx, "user/submissions", params, "GET", &submissions); err != nil {
+		return nil, err
+	}
+	return submissions, nil
+}
+
+// GetFoldersTyped is GetFolders, decoded into the root Folder.
+func (client Client) GetFoldersTyped(ctx context.Context) (*Folder, error) {
+	var folder Folder
+	if err := client.decodeContent(ctx, "user/folders", "", "GET", &folder); err != nil {
+		return nil, err
+	}
+	return &folder, nil
+}
+
+// GetReportsTyped is GetReports, decoded into Reports.
+func (client Client) GetReportsTyped(ctx context.Context) ([]Report, error) {
+	var reports []Report
+	if err := client.decodeContent(ctx, "user/reports", "", "GET", &reports); err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+// GetSettingsTyped is GetSettings, decoded into Settings.
+func (client Client) GetSettingsTyped(ctx context.Context) (*Settings, error) {
+	var settings Settings
+	if err := client.decodeContent(ctx, "user/settings", "", "GET", &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// UpdateSettingsTyped is UpdateSettings, decoded into the changed Settings.
+func (client Client) UpdateSettingsTyped(ctx context.Context, settings map[string]string) (*Settings, error) {
+	var updated Settings
+	if err := client.decodeContent(ctx, "user/settings", settings, "POST", &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// GetHistoryTyped is GetHistory, decoded into HistoryEntries.
+func (client Client) GetHistoryTyped(ctx context.Context, action string, date string, sortBy string, startDate string, endDate string) ([]HistoryEntry, error) {
+	var history []HistoryEntry
+	params := createHistoryQuery(action, date, sortBy, startDate, endDate)
+	if err := client.decodeContent(ctx, "user/history", params, "GET", &history); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// GetFormTyped is GetForm, decoded into a Form.
+func (client Client) GetFormTyped(ctx context.Context, formID int64) (*Form, error) {
+	var form Form
+	if err := client.decodeContent(ctx, "form/"+strconv.FormatInt(formID, 10), "", "GET", &form); err != nil {
+		return nil, err
+	}
+	return &form, nil
+}
+
+// GetFormQuestionsTyped is GetFormQuestions, decoded into Questions
+// sorted by their order on the form.
+func (client Client) GetFormQuestionsTyped(ctx context.Context, formID int64) ([]Question, error) {
+	content, err := client.executeTyped(ctx, "form/"+strconv.FormatInt(formID, 10)+"/questions", "", "GET")
+	if err != nil {
+		return nil, err
+	}
+
+	var byID map[string]Question
+	if err := decodeObject(content, &byID); err != nil {
+		return nil, err
+	}
+
+	questions := make([]Question, 0, len(byID))
+	for _, question := range byID {
+		questions = append(questions, question)
+	}
+	sort.Slice(questions, func(i, j int) bool {
+		if questions[i].Order != questions[j].Order {
+			return questions[i].Order < questions[j].Order
+		}
+		return questions[i].QID < questions[j].QID
+	})
+	return questions, nil
+}
+
+// GetFormQuestionTyped is GetFormQuestion, decoded into a Question.
+func (client Client) GetFormQuestionTyped(ctx context.Context, formID int64, qid int) (*Question, error) {
+	var question Question
+	if err := client.decodeContent(ctx, "form/"+strconv.FormatInt(formID, 10)+"/question/"+strconv.Itoa(qid), "", "GET", &question); err != nil {
+		return nil, err
+	}
+	return &question, nil
+}
+
+// GetFormSubmissionsTyped is GetFormSubmissions, decoded into Submissions.
+func (client Client) GetFormSubmissionsTyped(ctx context.Context, formID int64, opts *ListOptions) ([]Submission, error) {
+	params, err := opts.params()
+	if err != nil {
+		return nil, err
+	}
+
+	var submissions []Submission
+	if err := client.decodeContent(ctx, "form/"+strconv.FormatInt(formID, 10)+"/submissions", params, "GET", &submissions); err != nil {
+		return nil, err
+	}
+	return submissions, nil
+}
+
+// CreateFormSubmissionTyped is CreateFormSubmission, decoded into a SubmissionResult.
+func (client Client) CreateFormSubmissionTyped(ctx context.Context, formID int64, submission map[string]string) (*SubmissionResult, error) {
+	var result SubmissionResult
+	params, err := submissionParams(submission)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.decodeContent(ctx, "form/"+strconv.FormatInt(formID, 10)+"/submissions", params, "POST", &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetFormFilesTyped is GetFormFiles, decoded into Files.
+func (client Client) GetFormFilesTyped(ctx context.Context, formID int64) ([]File, error) {
+	var files []File
+	if err := client.decodeContent(ctx, "form/"+strconv.FormatInt(formID, 10)+"/files", "", "GET", &files); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// GetFormWebhooksTyped is GetFormWebhooks, decoded into Webhooks ordered by ID.
+func (client Client) GetFormWebhooksTyped(ctx context.Context, formID int64) ([]Webhook, error) {
+	content, err := client.executeTyped(ctx, "form/"+strconv.FormatInt(formID, 10)+"/webhooks", "", "GET")
+	if err != nil {
+		return nil, err
+	}
+	return decodeWebhooks(content)
+}
+
+// CreateFormWebhookTyped is CreateFormWebhook, decoded into the form's Webhooks.
+func (client Client) CreateFormWebhookTyped(ctx context.Context, formID int64, webhookURL string) ([]Webhook, error) {
+	params := map[string]string{
+		"webhookURL": webhookURL,
+	}
+
+	content, err := client.executeTyped(ctx, "form/"+strconv.FormatInt(formID, 10)+"/webhooks", params, "POST")
+	if err != nil {
+		return nil, err
+	}
+	return decodeWebhooks(content)
+}
+
+// DeleteFormWebhookTyped is DeleteFormWebhook, decoded into the form's remaining Webhooks.
+func (client Client) DeleteFormWebhookTyped(ctx context.Context, formID int64, webhookID int64) ([]Webhook, error) {
+	content, err := client.executeTyped(ctx, "form/"+strconv.FormatInt(formID, 10)+"/webhooks/"+strconv.FormatInt(webhookID, 10), nil, "DELETE")
+	if err != nil {
+		return nil, err
+	}
+	return decodeWebhooks(content)
+}
+
+// GetSubmissionTyped is GetSubmission, decoded into a Submission.
+func (client Client) GetSubmissionTyped(ctx context.Context, sid int64) (*Submission, error) {
+	var submission Submission
+	if err := client.decodeContent(ctx, "user/submission/"+strconv.FormatInt(sid, 10), "", "GET", &submission); err != nil {
+		return nil, err
+	}
+	return &submission, nil
+}
+
+// GetReportTyped is GetReport, decoded into a Report.
+func (client Client) GetReportTyped(ctx context.Context, reportID int64) (*Report, error) {
+	var report Report
+	if err := client.decodeContent(ctx, "user/report/"+strconv.FormatInt(reportID, 10), "", "GET", &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// GetFolderTyped is GetFolder, decoded into a Folder.
+func (client Client) GetFolderTyped(ctx context.Context, folderID string) (*Folder, error) {
+	var folder Folder
+	if err := client.decodeContent(ctx, "folder/"+folderID, "", "GET", &folder); err != nil {
+		return nil, err
+	}
+	return &folder, nil
+}
+
+// GetFormReportsTyped is GetFormReports, decoded into Reports.
+func (client Client) GetFormReportsTyped(ctx context.Context, formID int64) ([]Report, error) {
+	var reports []Report
+	if err := client.decodeContent(ctx, "form/"+strconv.FormatInt(formID, 10)+"/reports", "", "GET", &reports); err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+// CreateReportTyped is CreateReport, decoded into the new Report.
+func (client Client) CreateReportTyped(ctx context.Context, formID int64, report map[string]string) (*Report, error) {
+	var created Report
+	if err := client.decodeContent(ctx, "form/"+strconv.FormatInt(formID, 10)+"/reports", report, "POST", &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// CloneFormTyped is CloneForm, decoded into the new Form.
+func (client Client) CloneFormTyped(ctx context.Context, formID int64) (*Form, error) {
+	var form Form
+	if err := client.decodeContent(ctx, "form/"+strconv.FormatInt(formID, 10)+"/clone", nil, "POST", &form); err != nil {
+		return nil, err
+	}
+	return &form, nil
+}
+
+// DeleteFormTyped is DeleteForm, decoded into the deleted Form.
+func (client Client) DeleteFormTyped(ctx context.Context, formID int64) (*Form, error) {
+	var form Form
+	if err := client.decodeContent(ctx, "form/"+strconv.FormatInt(formID, 10), nil, "DELETE", &form); err != nil {
+		return nil, err
+	}
+	return &form, nil
+}
+
+// GetPlanTyped is GetPlan, decoded into a Plan.
+func (client Client) GetPlanTyped(ctx context.Context, planName string) (*Plan, error) {
+	var plan Plan
+	if err := client.decodeContent(ctx, "system/plan/"+planName, "", "GET", &plan); err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+// executeTyped is executeHttpRequest for typed calls
+// that need to decode the raw content themselves.
+func (client Client) executeTyped(ctx context.Context, requestPath string, params interface{}, method string) ([]byte, error) {
+	var content json.RawMessage
+	if err := client.decodeContent(ctx, requestPath, params, method, &content); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
@@ -0,0 +1,116 @@
+package jotform_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	jotform "github.com/jotform/jotform-api-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func newJSONClient(status int, body string, reqURL *string) *jotform.MockHttpClient {
+	return &jotform.MockHttpClient{DoFunc: func(req *http.Request) (*http.Response, error) {
+		if reqURL != nil {
+			*reqURL = req.URL.String()
+		}
+		return jotform.NewMockResponse(req, status, body), nil
+	}}
+}
+
+func TestGetFormTyped(t *testing.T) {
+	t.Run("happy - decodes form", func(t *testing.T) {
+		body := `{"responseCode":200,"message":"success","content":{"id":"31751954731962","username":"johnsmith","title":"Contact Us","height":"539","status":"ENABLED","created_at":"2013-06-24 18:52:59","updated_at":"2013-06-25 19:01:53","new":"2","count":"13","favorite":"0","archived":"0","url":"https://form.jotform.com/31751954731962"},"duration":"12ms"}`
+		var reqURL string
+		client := jotform.NewTestClient(newJSONClient(200, body, &reqURL))
+
+		form, err := client.GetFormTyped(context.Background(), 31751954731962)
+		assert.Nil(t, err)
+		assert.Equal(t, "https://api.jotform.com/v1/form/31751954731962", reqURL)
+		assert.Equal(t, jotform.Int(31751954731962), form.ID)
+		assert.Equal(t, "Contact Us", form.Title)
+		assert.Equal(t, jotform.Int(13), form.Count)
+		assert.False(t, bool(form.Favorite))
+		assert.Equal(t, time.Date(2013, 6, 24, 18, 52, 59, 0, time.UTC), form.CreatedAt.Time)
+	})
+
+	t.Run("sad - requires json or xml output", func(t *testing.T) {
+		client := jotform.NewTestClient(newJSONClient(200, "", nil))
+		client.SetOutputType("yaml")
+
+		_, err := client.GetFormTyped(context.Background(), 1)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestGetFormSubmissionsTyped(t *testing.T) {
+	t.Run("happy - decodes submissions and sends list options", func(t *testing.T) {
+		body := `{"responseCode":200,"content":[{"id":"237955080346633702","form_id":"31751954731962","ip":"123.123.123.123","created_at":"2013-06-25 03:38:00","updated_at":null,"status":"ACTIVE","new":"1","answers":{"3":{"name":"yourName","order":"1","text":"Your Name","type":"control_textbox","answer":"John"}}}],"resultSet":{"offset":20,"limit":10,"count":1}}`
+		var reqURL string
+		client := jotform.NewTestClient(newJSONClient(200, body, &reqURL))
+
+		submissions, err := client.GetFormSubmissionsTyped(context.Background(), 31751954731962, &jotform.ListOptions{
+			Offset:  20,
+			Limit:   10,
+			OrderBy: "created_at",
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, "https://api.jotform.com/v1/form/31751954731962/submissions?limit=10&offset=20&orderby=created_at", reqURL)
+		assert.Equal(t, 1, len(submissions))
+		assert.Equal(t, jotform.Int(237955080346633702), submissions[0].ID)
+		assert.True(t, bool(submissions[0].New))
+		assert.True(t, submissions[0].UpdatedAt.IsZero())
+		assert.Equal(t, `"John"`, string(submissions[0].Answers["3"].Answer))
+	})
+}
+
+func TestGetFormQuestionsTyped(t *testing.T) {
+	t.Run("happy - sorts questions by order", func(t *testing.T) {
+		body := `{"responseCode":200,"content":{"1":{"qid":"1","type":"control_head","text":"Header","order":"1"},"3":{"qid":"3","type":"control_email","text":"Email","order":"2"},"2":{"qid":"2","type":"control_textbox","text":"Name","order":"3"}}}`
+		client := jotform.NewTestClient(newJSONClient(200, body, nil))
+
+		questions, err := client.GetFormQuestionsTyped(context.Background(), 1)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, len(questions))
+		assert.Equal(t, "Header", questions[0].Text)
+		assert.Equal(t, "Email", questions[1].Text)
+		assert.Equal(t, "Name", questions[2].Text)
+	})
+}
+
+func TestGetFormWebhooksTyped(t *testing.T) {
+	t.Run("happy - decodes webhooks ordered by ID", func(t *testing.T) {
+		body := `{"responseCode":200,"content":{"1":"https://example.com/b","0":"https://example.com/a"}}`
+		client := jotform.NewTestClient(newJSONClient(200, body, nil))
+
+		webhooks, err := client.GetFormWebhooksTyped(context.Background(), 1)
+		assert.Nil(t, err)
+		assert.Equal(t, []jotform.Webhook{
+			{ID: 0, URL: "https://example.com/a"},
+			{ID: 1, URL: "https://example.com/b"},
+		}, webhooks)
+	})
+
+	t.Run("happy - empty list", func(t *testing.T) {
+		body := `{"responseCode":200,"content":[]}`
+		client := jotform.NewTestClient(newJSONClient(200, body, nil))
+
+		webhooks, err := client.GetFormWebhooksTyped(context.Background(), 1)
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(webhooks))
+	})
+}
+
+func TestGetUserTyped(t *testing.T) {
+	t.Run("happy - decodes user", func(t *testing.T) {
+		body := `{"responseCode":200,"content":{"username":"johnsmith","name":"John Smith","email":"john@example.com","time_zone":"America/New_York","account_type":"https://api.jotform.com/system/plan/FREE","status":"ACTIVE","created_at":"2013-06-24 18:52:59","updated_at":null}}`
+		client := jotform.NewTestClient(newJSONClient(200, body, nil))
+
+		user, err := client.GetUserTyped(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, "johnsmith", user.Username)
+		assert.Equal(t, "America/New_York", user.TimeZone)
+		assert.True(t, user.UpdatedAt.IsZero())
+	})
+}
@@ -0,0 +1,278 @@
+// Package webhook receives the submissions JotForm posts to webhooks
+// registered with CreateFormWebhook.
+package webhook
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultMaxBodyBytes is the largest post a Handler accepts by default.
+const DefaultMaxBodyBytes = 10 << 20
+
+// DefaultTokenParam is the query parameter a Handler reads its token from by default.
+const DefaultTokenParam = "token"
+
+// WebhookEvent is a submission posted by JotForm.
+type WebhookEvent struct {
+	FormID       int64
+	SubmissionID int64
+	FormTitle    string
+	Username     string
+	IP           string
+	// Type is the kind of submission, eg. "WEB".
+	Type string
+	// Pretty is a human-readable summary of the answers,
+	// eg. "Name:John Smith, Email:john@example.com".
+	Pretty string
+	// RawRequest is the submitted form data as JSON.
+	RawRequest json.RawMessage
+	// Answers holds RawRequest's fields, keyed like "q3_name".
+	Answers    map[string]json.RawMessage
+	ReceivedAt time.Time
+}
+
+// Answer returns the answer to question qid,
+// whose key in Answers is "q<qid>_<name>".
+func (e *WebhookEvent) Answer(qid int) (json.RawMessage, bool) {
+	prefix := "q" + strconv.Itoa(qid) + "_"
+	for key, value := range e.Answers {
+		if strings.HasPrefix(key, prefix) {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// Deduper remembers which submissions have been handled,
+// so that JotForm's repeated posts of a submission are only handled once.
+type Deduper interface {
+	// Seen reports whether submissionID has been recorded.
+	Seen(submissionID int64) bool
+	// Add records submissionID, once OnEvent has handled it.
+	Add(submissionID int64)
+}
+
+// Handler is an http.Handler for JotForm webhook posts.
+// It responds 200 once OnEvent has handled an event,
+// and 500 if OnEvent fails, so that JotForm posts it again.
+// With Dedup set, a post of a submission still being handled gets a 503,
+// so that it is posted again if the first post fails.
+type Handler struct {
+	// OnEvent is called with every valid event that is not a duplicate.
+	OnEvent func(ctx context.Context, event *WebhookEvent) error
+	// Token, if set, must match the TokenParam query parameter of every post.
+	// Register the webhook URL with the token, eg. https://example.com/hook?token=secret.
+	Token string
+	// TokenParam is the query parameter holding the token. Defaults to DefaultTokenParam.
+	TokenParam string
+	// MaxBodyBytes limits the size of a post. Defaults to DefaultMaxBodyBytes.
+	MaxBodyBytes int64
+	// Dedup, if set, drops posts of submissions that were already handled.
+	Dedup Deduper
+
+	mu       sync.Mutex
+	inFlight map[int64]bool
+}
+
+// NewHandler returns a Handler calling onEvent,
+// which drops duplicates of the last 10000 submissions.
+func NewHandler(onEvent func(ctx context.Context, event *WebhookEvent) error) *Handler {
+	return &Handler{
+		OnEvent: onEvent,
+		Dedup:   NewMemoryDeduper(10000),
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.authorized(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	event, err := h.parse(w, r)
+	if r.MultipartForm != nil {
+		// Files not kept in memory are written to temporary files.
+		defer r.MultipartForm.RemoveAll()
+	}
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "request too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if h.Dedup != nil {
+		if h.Dedup.Seen(event.SubmissionID) {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		if !h.start(event.SubmissionID) {
+			w.Header().Set("Retry-After", "60")
+			http.Error(w, "submission is being handled", http.StatusServiceUnavailable)
+			return
+		}
+		defer h.finish(event.SubmissionID)
+	}
+
+	if h.OnEvent != nil {
+		if err := h.OnEvent(r.Context(), event); err != nil {
+			http.Error(w, "failed to handle event", http.StatusInternalServerError)
+			return
+		}
+	}
+	if h.Dedup != nil {
+		h.Dedup.Add(event.SubmissionID)
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// start marks a submission as being handled, and reports false if it already is.
+func (h *Handler) start(submissionID int64) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.inFlight[submissionID] {
+		return false
+	}
+	if h.inFlight == nil {
+		h.inFlight = make(map[int64]bool)
+	}
+	h.inFlight[submissionID] = true
+	return true
+}
+
+func (h *Handler) finish(submissionID int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.inFlight, submissionID)
+}
+
+func (h *Handler) authorized(r *http.Request) bool {
+	if h.Token == "" {
+		return true
+	}
+
+	param := h.TokenParam
+	if param == "" {
+		param = DefaultTokenParam
+	}
+	token := r.URL.Query().Get(param)
+	return subtle.ConstantTimeCompare([]byte(token), []byte(h.Token)) == 1
+}
+
+// parse reads and validates the post, which JotForm sends as multipart form data.
+func (h *Handler) parse(w http.ResponseWriter, r *http.Request) (*WebhookEvent, error) {
+	maxBytes := h.MaxBodyBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBodyBytes
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+
+	err := r.ParseMultipartForm(maxBytes)
+	if errors.Is(err, http.ErrNotMultipart) {
+		err = r.ParseForm()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseForm(r.PostForm)
+}
+
+// ParseForm builds a WebhookEvent from the fields of a JotForm webhook post.
+func ParseForm(form map[string][]string) (*WebhookEvent, error) {
+	get := func(key string) string {
+		if values := form[key]; len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+
+	formID, err := strconv.ParseInt(get("formID"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("webhook: invalid formID %q", get("formID"))
+	}
+	submissionID, err := strconv.ParseInt(get("submissionID"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("webhook: invalid submissionID %q", get("submissionID"))
+	}
+
+	event := &WebhookEvent{
+		FormID:       formID,
+		SubmissionID: submissionID,
+		FormTitle:    get("formTitle"),
+		Username:     get("username"),
+		IP:           get("ip"),
+		Type:         get("type"),
+		Pretty:       get("pretty"),
+		ReceivedAt:   time.Now(),
+	}
+
+	if raw := get("rawRequest"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &event.Answers); err != nil {
+			return nil, fmt.Errorf("webhook: invalid rawRequest: %w", err)
+		}
+		event.RawRequest = json.RawMessage(raw)
+	}
+
+	return event, nil
+}
+
+// MemoryDeduper is a Deduper remembering the most recent submissions in memory.
+type MemoryDeduper struct {
+	mu    sync.Mutex
+	size  int
+	seen  map[int64]struct{}
+	order []int64
+}
+
+// NewMemoryDeduper returns a MemoryDeduper remembering up to size submissions.
+func NewMemoryDeduper(size int) *MemoryDeduper {
+	return &MemoryDeduper{
+		size: size,
+		seen: make(map[int64]struct{}, size),
+	}
+}
+
+func (d *MemoryDeduper) Seen(submissionID int64) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	_, ok := d.seen[submissionID]
+	return ok
+}
+
+func (d *MemoryDeduper) Add(submissionID int64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.seen[submissionID]; ok {
+		return
+	}
+	d.seen[submissionID] = struct{}{}
+	d.order = append(d.order, submissionID)
+	for len(d.seen) > d.size && len(d.order) > 0 {
+		delete(d.seen, d.order[0])
+		d.order = d.order[1:]
+	}
+}
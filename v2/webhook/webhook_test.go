@@ -0,0 +1,218 @@
+package webhook_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jotform/jotform-api-go/v2/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+const multipartType = "multipart/form-data; boundary=----JotFormBoundary7MA4YWxkTrZu0gW"
+
+func recordedPost(t *testing.T, target string, fixture string, contentType string) *http.Request {
+	body, err := ioutil.ReadFile("testdata/" + fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest("POST", target, bytes.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	return req
+}
+
+func TestHandler(t *testing.T) {
+	t.Run("happy - parses multipart post", func(t *testing.T) {
+		var event *webhook.WebhookEvent
+		handler := webhook.NewHandler(func(ctx context.Context, e *webhook.WebhookEvent) error {
+			event = e
+			return nil
+		})
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, recordedPost(t, "/hook", "submission.multipart", multipartType))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, int64(31751954731962), event.FormID)
+		assert.Equal(t, int64(5723914528516402331), event.SubmissionID)
+		assert.Equal(t, "Contact Us", event.FormTitle)
+		assert.Equal(t, "jotformuser", event.Username)
+		assert.Equal(t, "203.0.113.7", event.IP)
+		assert.Equal(t, "WEB", event.Type)
+		assert.Equal(t, "Name:John Smith, Email:john@example.com, Message:Hello there", event.Pretty)
+		assert.JSONEq(t, `{"first":"John","last":"Smith"}`, string(event.Answers["q3_name"]))
+
+		email, ok := event.Answer(4)
+		assert.True(t, ok)
+		assert.Equal(t, `"john@example.com"`, string(email))
+		_, ok = event.Answer(9)
+		assert.False(t, ok)
+	})
+
+	t.Run("happy - parses urlencoded post", func(t *testing.T) {
+		var event *webhook.WebhookEvent
+		handler := webhook.NewHandler(func(ctx context.Context, e *webhook.WebhookEvent) error {
+			event = e
+			return nil
+		})
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, recordedPost(t, "/hook", "submission.urlencoded", "application/x-www-form-urlencoded"))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, int64(5723914528516402332), event.SubmissionID)
+		assert.JSONEq(t, `"jane@example.com"`, string(event.Answers["q4_email"]))
+	})
+
+	t.Run("happy - accepts matching token", func(t *testing.T) {
+		handler := webhook.NewHandler(nil)
+		handler.Token = "secret"
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, recordedPost(t, "/hook?token=secret", "submission.multipart", multipartType))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("sad - rejects wrong or missing token", func(t *testing.T) {
+		called := false
+		handler := webhook.NewHandler(func(ctx context.Context, e *webhook.WebhookEvent) error {
+			called = true
+			return nil
+		})
+		handler.Token = "secret"
+
+		for _, target := range []string{"/hook", "/hook?token=wrong"} {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, recordedPost(t, target, "submission.multipart", multipartType))
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		}
+		assert.False(t, called)
+	})
+
+	t.Run("sad - rejects other methods", func(t *testing.T) {
+		handler := webhook.NewHandler(nil)
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest("GET", "/hook", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+
+	t.Run("sad - rejects oversized body", func(t *testing.T) {
+		handler := webhook.NewHandler(nil)
+		handler.MaxBodyBytes = 100
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, recordedPost(t, "/hook", "submission.multipart", multipartType))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	})
+
+	t.Run("sad - rejects invalid post", func(t *testing.T) {
+		handler := webhook.NewHandler(nil)
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, recordedPost(t, "/hook", "invalid.multipart", multipartType))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("happy - drops duplicate submissions", func(t *testing.T) {
+		calls := 0
+		handler := webhook.NewHandler(func(ctx context.Context, e *webhook.WebhookEvent) error {
+			calls++
+			return nil
+		})
+
+		for i := 0; i < 3; i++ {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, recordedPost(t, "/hook", "submission.multipart", multipartType))
+			assert.Equal(t, http.StatusOK, rec.Code)
+		}
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("sad - failed callback is retried by the next post", func(t *testing.T) {
+		calls := 0
+		handler := webhook.NewHandler(func(ctx context.Context, e *webhook.WebhookEvent) error {
+			calls++
+			if calls == 1 {
+				return errors.New("database unavailable")
+			}
+			return nil
+		})
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, recordedPost(t, "/hook", "submission.multipart", multipartType))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, recordedPost(t, "/hook", "submission.multipart", multipartType))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("sad - duplicates of a submission being handled are posted again", func(t *testing.T) {
+		started := make(chan struct{})
+		release := make(chan struct{})
+		calls := 0
+		handler := webhook.NewHandler(func(ctx context.Context, e *webhook.WebhookEvent) error {
+			calls++
+			close(started)
+			<-release
+			return errors.New("database unavailable")
+		})
+
+		first := httptest.NewRecorder()
+		done := make(chan struct{})
+		go func() {
+			handler.ServeHTTP(first, recordedPost(t, "/hook", "submission.multipart", multipartType))
+			close(done)
+		}()
+		<-started
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, recordedPost(t, "/hook", "submission.multipart", multipartType))
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+
+		close(release)
+		<-done
+		assert.Equal(t, http.StatusInternalServerError, first.Code)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("happy - serves over http", func(t *testing.T) {
+		events := make(chan *webhook.WebhookEvent, 1)
+		server := httptest.NewServer(webhook.NewHandler(func(ctx context.Context, e *webhook.WebhookEvent) error {
+			events <- e
+			return nil
+		}))
+		defer server.Close()
+
+		body, _ := ioutil.ReadFile("testdata/submission.multipart")
+		resp, err := http.Post(server.URL, multipartType, bytes.NewReader(body))
+		assert.Nil(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, int64(5723914528516402331), (<-events).SubmissionID)
+	})
+}
+
+func TestMemoryDeduper(t *testing.T) {
+	t.Run("happy - forgets oldest beyond size", func(t *testing.T) {
+		dedup := webhook.NewMemoryDeduper(2)
+
+		for _, id := range []int64{1, 2, 1} {
+			dedup.Add(id)
+		}
+		assert.True(t, dedup.Seen(1))
+		assert.True(t, dedup.Seen(2))
+
+		dedup.Add(3)
+		assert.False(t, dedup.Seen(1))
+		assert.True(t, dedup.Seen(3))
+		assert.False(t, dedup.Seen(4))
+	})
+}
@@ -0,0 +1,186 @@
+package jotform
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"reflect"
+	"strings"
+)
+
+// JotForm's XML output carries the same envelope and content as its json output:
+//
+//	<response>
+//	  <responseCode>200</responseCode>
+//	  <message>success</message>
+//	  <content>
+//	    <item><id>1234</id><title>Contact</title></item>
+//	  </content>
+//	  <limit-left>999</limit-left>
+//	</response>
+//
+// An element with child elements is an object, keyed by their names,
+// or by their key attribute if the key isn't a valid element name, eg. <item key="3">.
+// Arrays are elements of unkeyed <item>s, and may be marked type="array",
+// which keeps empty and single-item arrays apart from objects.
+// Other elements are strings, unless marked type="number", "boolean" or "null",
+// or type="object" for an empty object.
+//
+// The client converts XML into the json equivalent, so that typed calls
+// decode XML into the same models, and errors into the same APIError, as json.
+
+// xmlNode is an XML element, kept whole to be converted to json or written out again.
+type xmlNode struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Text    string     `xml:",chardata"`
+	Nodes   []xmlNode  `xml:",any"`
+}
+
+// parseXML parses an XML document into its root element.
+func parseXML(data []byte) (*xmlNode, error) {
+	var root xmlNode
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	root.trim()
+	return &root, nil
+}
+
+// trim drops the whitespace between child elements, which isn't part of the value.
+func (n *xmlNode) trim() {
+	if len(n.Nodes) == 0 {
+		return
+	}
+	if strings.TrimSpace(n.Text) == "" {
+		n.Text = ""
+	}
+	for i := range n.Nodes {
+		n.Nodes[i].trim()
+	}
+}
+
+func (n *xmlNode) attr(name string) (string, bool) {
+	for _, attr := range n.Attrs {
+		if attr.Name.Local == name {
+			return attr.Value, true
+		}
+	}
+	return "", false
+}
+
+// child returns the first child element with the name, or nil.
+func (n *xmlNode) child(name string) *xmlNode {
+	for i := range n.Nodes {
+		if n.Nodes[i].XMLName.Local == name {
+			return &n.Nodes[i]
+		}
+	}
+	return nil
+}
+
+func (n *xmlNode) isArray() bool {
+	if typ, ok := n.attr("type"); ok {
+		return typ == "array"
+	}
+	if len(n.Nodes) == 0 {
+		return false
+	}
+	for i := range n.Nodes {
+		if n.Nodes[i].XMLName.Local != "item" {
+			return false
+		}
+		if _, keyed := n.Nodes[i].attr("key"); keyed {
+			return false
+		}
+	}
+	return true
+}
+
+// isEmpty reports whether the element has no content and no type.
+func (n *xmlNode) isEmpty() bool {
+	_, typed := n.attr("type")
+	return !typed && len(n.Nodes) == 0 && strings.TrimSpace(n.Text) == ""
+}
+
+// value returns the json equivalent of the element.
+func (n *xmlNode) value() interface{} {
+	if n.isArray() {
+		values := make([]interface{}, len(n.Nodes))
+		for i := range n.Nodes {
+			values[i] = n.Nodes[i].value()
+		}
+		return values
+	}
+
+	if len(n.Nodes) > 0 {
+		values := make(map[string]interface{}, len(n.Nodes))
+		for i := range n.Nodes {
+			key, ok := n.Nodes[i].attr("key")
+			if !ok {
+				key = n.Nodes[i].XMLName.Local
+			}
+			values[key] = n.Nodes[i].value()
+		}
+		return values
+	}
+
+	typ, _ := n.attr("type")
+	text := strings.TrimSpace(n.Text)
+	switch typ {
+	case "number":
+		if text != "" {
+			return json.Number(text)
+		}
+	case "boolean":
+		return text == "true" || text == "1"
+	case "null":
+		return nil
+	case "object":
+		return map[string]interface{}{}
+	}
+	return n.Text
+}
+
+// decodeJSON decodes the json equivalent of the element into v.
+// An empty element without a type is an empty string, unless v is a slice or map,
+// as JotForm doesn't always mark empty arrays, eg. <content/> for a page with no submissions.
+func (n *xmlNode) decodeJSON(v interface{}) error {
+	value := n.value()
+	if n.isEmpty() {
+		switch reflect.Indirect(reflect.ValueOf(v)).Kind() {
+		case reflect.Slice:
+			value = []interface{}{}
+		case reflect.Map:
+			value = map[string]interface{}{}
+		}
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// marshal writes the element out as an XML document.
+func (n *xmlNode) marshal() ([]byte, error) {
+	if n == nil {
+		return nil, nil
+	}
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	if err := xml.NewEncoder(&buf).Encode(n); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// xmlToJSON converts an XML document into its json equivalent.
+func xmlToJSON(data []byte) ([]byte, error) {
+	root, err := parseXML(data)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(root.value())
+}
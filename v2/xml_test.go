@@ -0,0 +1,308 @@
+package jotform_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+
+	jotform "github.com/jotform/jotform-api-go/v2"
+	"github.com/jotform/jotform-api-go/v2/jotformtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// fixture is the same response in both output formats.
+type fixture struct {
+	status int
+	json   string
+	xml    string
+}
+
+// clients returns a client for each output format, responding with the fixture.
+func (f fixture) clients() (jsonClient, xmlClient *jotform.Client) {
+	jsonClient = jotform.NewTestClient(newJSONClient(f.status, f.json, nil))
+	xmlClient = jotform.NewTestClient(newJSONClient(f.status, f.xml, nil))
+	xmlClient.SetOutputType("xml")
+	return jsonClient, xmlClient
+}
+
+var formFixture = fixture{
+	status: 200,
+	json:   `{"responseCode":200,"message":"success","content":{"id":"31751954731962","username":"johnsmith","title":"Contact Us","height":"539","status":"ENABLED","created_at":"2013-06-24 18:52:59","updated_at":"2013-06-25 19:01:53","new":"2","count":"13","favorite":"0","archived":"0","url":"https://form.jotform.com/31751954731962"},"duration":"12ms","limit-left":"9876"}`,
+	xml: `<?xml version="1.0" encoding="UTF-8"?>
+<response>
+  <responseCode>200</responseCode>
+  <message>success</message>
+  <content>
+    <id>31751954731962</id>
+    <username>johnsmith</username>
+    <title>Contact Us</title>
+    <height>539</height>
+    <status>ENABLED</status>
+    <created_at>2013-06-24 18:52:59</created_at>
+    <updated_at>2013-06-25 19:01:53</updated_at>
+    <new>2</new>
+    <count>13</count>
+    <favorite>0</favorite>
+    <archived>0</archived>
+    <url>https://form.jotform.com/31751954731962</url>
+  </content>
+  <duration>12ms</duration>
+  <limit-left>9876</limit-left>
+</response>`,
+}
+
+var submissionsFixture = fixture{
+	status: 200,
+	json:   `{"responseCode":200,"content":[{"id":"237955080346633702","form_id":"31751954731962","ip":"123.123.123.123","created_at":"2013-06-25 03:38:00","updated_at":null,"status":"ACTIVE","new":"1","answers":{"3":{"name":"yourName","order":"1","text":"Your Name","type":"control_textbox","answer":"John"},"4":{"name":"files","order":"2","text":"Files","type":"control_fileupload","answer":["https://www.jotform.com/uploads/a.pdf"]}}}],"resultSet":{"offset":0,"limit":20,"count":1}}`,
+	xml: `<?xml version="1.0" encoding="UTF-8"?>
+<response>
+  <responseCode>200</responseCode>
+  <content>
+    <item>
+      <id>237955080346633702</id>
+      <form_id>31751954731962</form_id>
+      <ip>123.123.123.123</ip>
+      <created_at>2013-06-25 03:38:00</created_at>
+      <updated_at type="null"/>
+      <status>ACTIVE</status>
+      <new>1</new>
+      <answers>
+        <item key="3"><name>yourName</name><order>1</order><text>Your Name</text><type>control_textbox</type><answer>John</answer></item>
+        <item key="4"><name>files</name><order>2</order><text>Files</text><type>control_fileupload</type><answer><item>https://www.jotform.com/uploads/a.pdf</item></answer></item>
+      </answers>
+    </item>
+  </content>
+  <resultSet><offset type="number">0</offset><limit type="number">20</limit><count type="number">1</count></resultSet>
+</response>`,
+}
+
+var questionsFixture = fixture{
+	status: 200,
+	json:   `{"responseCode":200,"content":{"1":{"qid":"1","type":"control_head","text":"Header","order":"1"},"3":{"qid":"3","type":"control_email","text":"Email","order":"2","required":"Yes"},"2":{"qid":"2","type":"control_textbox","text":"Name","order":"3"}}}`,
+	xml: `<?xml version="1.0" encoding="UTF-8"?>
+<response>
+  <responseCode>200</responseCode>
+  <content>
+    <item key="1"><qid>1</qid><type>control_head</type><text>Header</text><order>1</order></item>
+    <item key="3"><qid>3</qid><type>control_email</type><text>Email</text><order>2</order><required>Yes</required></item>
+    <item key="2"><qid>2</qid><type>control_textbox</type><text>Name</text><order>3</order></item>
+  </content>
+</response>`,
+}
+
+var unauthorizedFixture = fixture{
+	status: 401,
+	json:   `{"responseCode":401,"message":"You're not authorized to use (/user-forms) ","content":"","duration":"7ms","info":"https://api.jotform.com/docs#user-forms","limit-left":"5"}`,
+	xml: `<?xml version="1.0" encoding="UTF-8"?>
+<response>
+  <responseCode>401</responseCode>
+  <message>You're not authorized to use (/user-forms) </message>
+  <content></content>
+  <duration>7ms</duration>
+  <info>https://api.jotform.com/docs#user-forms</info>
+  <limit-left>5</limit-left>
+</response>`,
+}
+
+var failedEnvelopeFixture = fixture{
+	status: 200,
+	json:   `{"responseCode":404,"message":"Form not found","content":""}`,
+	xml:    `<response><responseCode>404</responseCode><message>Form not found</message><content/></response>`,
+}
+
+var emptyPageFixture = fixture{
+	status: 200,
+	json:   `{"responseCode":200,"content":[],"resultSet":{"offset":0,"limit":20,"count":0}}`,
+	xml: `<?xml version="1.0" encoding="UTF-8"?>
+<response>
+  <responseCode>200</responseCode>
+  <content/>
+  <resultSet><offset>0</offset><limit>20</limit><count>0</count></resultSet>
+</response>`,
+}
+
+func TestXMLOutput(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("happy - form decodes as json does", func(t *testing.T) {
+		jsonClient, xmlClient := formFixture.clients()
+
+		want, err := jsonClient.GetFormTyped(ctx, 31751954731962)
+		assert.Nil(t, err)
+		got, err := xmlClient.GetFormTyped(ctx, 31751954731962)
+		assert.Nil(t, err)
+		assert.Equal(t, want, got)
+		assert.Equal(t, "Contact Us", got.Title)
+
+		remaining, ok := xmlClient.QuotaRemaining()
+		assert.True(t, ok)
+		assert.Equal(t, 9876, remaining)
+	})
+
+	t.Run("happy - submission page decodes as json does", func(t *testing.T) {
+		jsonClient, xmlClient := submissionsFixture.clients()
+
+		want, err := jsonClient.GetFormSubmissionsTyped(ctx, 31751954731962, nil)
+		assert.Nil(t, err)
+		got, err := xmlClient.GetFormSubmissionsTyped(ctx, 31751954731962, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(got))
+		assert.Equal(t, want[0].ID, got[0].ID)
+		assert.True(t, got[0].UpdatedAt.IsZero())
+		assert.Equal(t, `"John"`, string(got[0].Answers["3"].Answer))
+		assert.JSONEq(t, string(want[0].Answers["4"].Answer), string(got[0].Answers["4"].Answer))
+
+		it := xmlClient.IterFormSubmissions(ctx, 31751954731962, nil)
+		assert.True(t, it.Next())
+		assert.Nil(t, it.Err())
+		assert.Equal(t, jotform.ResultSet{Offset: 0, Limit: 20, Count: 1}, it.ResultSet())
+	})
+
+	t.Run("happy - empty list decodes as json does", func(t *testing.T) {
+		jsonClient, xmlClient := emptyPageFixture.clients()
+
+		want, err := jsonClient.GetFormSubmissionsTyped(ctx, 31751954731962, nil)
+		assert.Nil(t, err)
+		got, err := xmlClient.GetFormSubmissionsTyped(ctx, 31751954731962, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, want, got)
+		assert.Empty(t, got)
+
+		it := xmlClient.IterFormSubmissions(ctx, 31751954731962, nil)
+		assert.False(t, it.Next())
+		assert.Nil(t, it.Err())
+	})
+
+	t.Run("happy - questions keyed by qid decode as json does", func(t *testing.T) {
+		jsonClient, xmlClient := questionsFixture.clients()
+
+		want, err := jsonClient.GetFormQuestionsTyped(ctx, 1)
+		assert.Nil(t, err)
+		got, err := xmlClient.GetFormQuestionsTyped(ctx, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("happy - untyped calls return content without the envelope", func(t *testing.T) {
+		_, xmlClient := formFixture.clients()
+
+		content, err := xmlClient.GetForm(31751954731962)
+		assert.Nil(t, err)
+		assert.Contains(t, string(content), "<content>")
+		assert.Contains(t, string(content), "<title>Contact Us</title>")
+		assert.NotContains(t, string(content), "responseCode")
+		assert.NotContains(t, string(content), "limit-left")
+	})
+
+	t.Run("sad - error responses become the same APIError", func(t *testing.T) {
+		for _, f := range []fixture{unauthorizedFixture, failedEnvelopeFixture} {
+			jsonClient, xmlClient := f.clients()
+
+			_, jsonErr := jsonClient.GetFormTyped(ctx, 1)
+			_, xmlErr := xmlClient.GetFormTyped(ctx, 1)
+			var want, got *jotform.APIError
+			if assert.True(t, errors.As(jsonErr, &want)) && assert.True(t, errors.As(xmlErr, &got)) {
+				assert.Equal(t, want.StatusCode, got.StatusCode)
+				assert.Equal(t, want.ResponseCode, got.ResponseCode)
+				assert.Equal(t, want.Message, got.Message)
+				assert.Equal(t, want.Info, got.Info)
+			}
+
+			_, rawErr := xmlClient.GetForm(1)
+			assert.Equal(t, xmlErr.Error(), rawErr.Error())
+		}
+
+		_, xmlClient := unauthorizedFixture.clients()
+		_, err := xmlClient.GetFormTyped(ctx, 1)
+		assert.True(t, errors.Is(err, jotform.ErrUnauthorized))
+		remaining, _ := xmlClient.QuotaRemaining()
+		assert.Equal(t, 5, remaining)
+	})
+
+	t.Run("sad - malformed xml", func(t *testing.T) {
+		client := jotform.NewTestClient(newJSONClient(200, `<response><content>`, nil))
+		client.SetOutputType("xml")
+
+		_, err := client.GetFormTyped(ctx, 1)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestXMLOutputServer(t *testing.T) {
+	server := jotformtest.NewServer()
+	defer server.Close()
+	server.SetQuota(100)
+
+	form := server.AddForm(jotform.Form{Title: "Contact <Us> & Co"},
+		jotform.Question{Type: "control_textbox", Text: "Name"},
+		jotform.Question{Type: "control_fullname", Text: "Full name"})
+	server.AddSubmission(jotform.Submission{FormID: form.ID, Answers: map[string]jotform.Answer{
+		"1": {Type: "control_textbox", Answer: []byte(`"Ada"`)},
+		"2": {Type: "control_fullname", Answer: []byte(`{"first":"Ada","last":"Lovelace"}`)},
+	}})
+	formID := int64(form.ID)
+
+	newClient := func(output string) *jotform.Client {
+		client := jotform.New("key", jotform.WithBaseURL(server.URL), jotform.WithOutput(output))
+		client.Retry = nil
+		return client
+	}
+	jsonClient, xmlClient := newClient("json"), newClient("xml")
+	ctx := context.Background()
+
+	t.Run("happy - typed calls agree", func(t *testing.T) {
+		wantForm, err := jsonClient.GetFormTyped(ctx, formID)
+		assert.Nil(t, err)
+		gotForm, err := xmlClient.GetFormTyped(ctx, formID)
+		assert.Nil(t, err)
+		assert.Equal(t, wantForm, gotForm)
+		assert.Equal(t, "Contact <Us> & Co", gotForm.Title)
+
+		wantQuestions, err := jsonClient.GetFormQuestionsTyped(ctx, formID)
+		assert.Nil(t, err)
+		gotQuestions, err := xmlClient.GetFormQuestionsTyped(ctx, formID)
+		assert.Nil(t, err)
+		assert.Equal(t, wantQuestions, gotQuestions)
+
+		wantSubmissions, err := jsonClient.GetFormSubmissionsTyped(ctx, formID, nil)
+		assert.Nil(t, err)
+		gotSubmissions, err := xmlClient.GetFormSubmissionsTyped(ctx, formID, nil)
+		assert.Nil(t, err)
+		if assert.Equal(t, 1, len(gotSubmissions)) {
+			assert.Equal(t, wantSubmissions[0].ID, gotSubmissions[0].ID)
+			assert.Equal(t, wantSubmissions[0].CreatedAt, gotSubmissions[0].CreatedAt)
+			for qid, answer := range wantSubmissions[0].Answers {
+				assert.JSONEq(t, string(answer.Answer), string(gotSubmissions[0].Answers[qid].Answer))
+			}
+		}
+
+		wantUser, err := jsonClient.GetUserTyped(ctx)
+		assert.Nil(t, err)
+		gotUser, err := xmlClient.GetUserTyped(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, wantUser, gotUser)
+	})
+
+	t.Run("happy - requests are routed without the suffix", func(t *testing.T) {
+		_, err := xmlClient.GetFormTyped(ctx, formID)
+		assert.Nil(t, err)
+		requests := server.Requests()
+		assert.Equal(t, "form/"+strconv.FormatInt(formID, 10), requests[len(requests)-1].Path)
+
+		remaining, ok := xmlClient.QuotaRemaining()
+		assert.True(t, ok)
+		assert.True(t, remaining < 100)
+	})
+
+	t.Run("sad - not found", func(t *testing.T) {
+		_, err := xmlClient.GetFormTyped(ctx, 1)
+		assert.True(t, errors.Is(err, jotform.ErrNotFound))
+
+		var apiErr *jotform.APIError
+		if assert.True(t, errors.As(err, &apiErr)) {
+			assert.Equal(t, http.StatusNotFound, apiErr.ResponseCode)
+		}
+	})
+}